@@ -0,0 +1,205 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-import-file/internal/config"
+	"go-import-file/internal/db"
+	"go-import-file/internal/report"
+)
+
+// fixtureConfig returns a SQLite config rooted in a new directory, with
+// the schema migrated up.
+func fixtureConfig(t *testing.T) *config.Config {
+	t.Helper()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	yaml := fmt.Sprintf(`file_path: %[1]s/input
+process_dir: %[1]s/transfer
+process_success_dir: %[1]s/transfer/success
+process_failed_dir: %[1]s/transfer/failed
+log_path: %[1]s/logs
+time_zone: Asia/Jakarta
+database:
+  driver: sqlite
+  name: %[1]s/local.db
+ftp:
+  disabled: true
+`, filepath.ToSlash(dir))
+	if err := os.WriteFile(path, []byte(yaml), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	set, err := config.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	profiles, err := set.Select("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := profiles[0]
+
+	if err := migrateProfile(context.Background(), cfg, "up"); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	return cfg
+}
+
+// importFixture copies testdata/fixtures/A_<block>.txt into the input
+// directory and runs the block on it, expecting wantStatus and
+// wantRejected lines that did not parse.
+func importFixture(t *testing.T, cfg *config.Config, block, wantStatus string, wantRejected int64) report.Report {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "fixtures", "A_"+block+".txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(cfg.FilePath, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cfg.FilePath, "A_"+block+".txt"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.Run = config.Run{Block: block}
+	rep := runProfile(context.Background(), cfg, block, nil)
+	if rep.Status != wantStatus {
+		t.Fatalf("%s: status %s (%s), want %s", block, rep.Status, rep.Error, wantStatus)
+	}
+	if rep.Totals.Rejected != wantRejected {
+		t.Errorf("%s: %d lines rejected, want %d", block, rep.Totals.Rejected, wantRejected)
+	}
+	return rep
+}
+
+// rows returns every row of query as text, NULL as "NULL".
+func rows(t *testing.T, conn *sql.DB, query string) []string {
+	t.Helper()
+
+	rs, err := conn.Query(query)
+	if err != nil {
+		t.Fatalf("%s: %v", query, err)
+	}
+	defer rs.Close()
+
+	cols, _ := rs.Columns()
+	var out []string
+	for rs.Next() {
+		vals := make([]sql.NullString, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rs.Scan(ptrs...); err != nil {
+			t.Fatal(err)
+		}
+		fields := make([]string, len(vals))
+		for i, v := range vals {
+			fields[i] = "NULL"
+			if v.Valid {
+				fields[i] = v.String
+			}
+		}
+		out = append(out, strings.Join(fields, "|"))
+	}
+	if err := rs.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func checkRows(t *testing.T, conn *sql.DB, query string, want ...string) {
+	t.Helper()
+
+	got := rows(t, conn, query)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("%s\ngot:\n  %s\nwant:\n  %s", query, strings.Join(got, "\n  "), strings.Join(want, "\n  "))
+	}
+}
+
+func TestImportFixturesSQLite(t *testing.T) {
+	cfg := fixtureConfig(t)
+
+	importFixture(t, cfg, "MSKU", "SUCCESS", 0)
+	// The staging rows are committed; the finalize steps only run on SQL
+	// Server.
+	rep := importFixture(t, cfg, "MPRICE", "FAILED", 2)
+	if !strings.Contains(rep.Error, "finalize requires database.driver sqlserver") {
+		t.Errorf("MPRICE: error %q, want the finalize driver check", rep.Error)
+	}
+	importFixture(t, cfg, "SLSINV", "SUCCESS", 3)
+	importFixture(t, cfg, "MBACKORDER", "SUCCESS", 1)
+	importFixture(t, cfg, "MCUST", "SUCCESS", 0)
+
+	conn, err := db.Open(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	checkRows(t, conn, `SELECT PCODE FROM fmaster ORDER BY PCODE`, "P1", "P2")
+
+	// Prices are read without rounding and multiplied up the UOM ladder;
+	// the unknown unit XX and the price with 5 decimals are rejected.
+	checkRows(t, conn, `SELECT PCODE, CAST(PRICE_VALUE AS TEXT), PRICE_UOM,
+			CAST(SELPRICE1 AS TEXT), CAST(SELPRICE2 AS TEXT), CAST(SELPRICE3 AS TEXT), CAST(SELPRICE4 AS TEXT), CAST(SELPRICE5 AS TEXT)
+		FROM m_price_dummy ORDER BY LINE_NO`,
+		"P1|100|PCS|100|200|1200|4800|48000",
+		"P1|1234.5|BOX|0|0|0|1234.5|12345",
+		"P2|1000|PCS|1000|0|0|0|0",
+	)
+
+	// Empty-date sentinels become NULL, dates carry the configured zone
+	// and amounts keep their decimals. SO2 (bad date), SO4 (5 decimals)
+	// and SO5 (quantity x) are rejected.
+	checkRows(t, conn, `SELECT SFA_ORDER_NO, CAST(SFA_ORDER_DATE AS TEXT), CAST(ORDER_DATE AS TEXT), CAST(INVOICE_DATE AS TEXT),
+			CAST(QTY AS TEXT), CAST(PRICE AS TEXT), CAST(INVAMOUNT AS TEXT)
+		FROM sap_web_inv_sfa ORDER BY SFA_ORDER_NO`,
+		"SO1|2026-01-31 00:00:00+07:00|NULL|NULL|2|1000|2000",
+		"SO3|2026-01-31 00:00:00+07:00|2026-01-31 00:00:00+07:00|2026-02-01 00:00:00+07:00|2|1234.5|2469",
+	)
+
+	// The 00000000 order date is rejected, not stored as a zero date.
+	checkRows(t, conn, `SELECT ORDERNO, CAST(TGLORDER AS TEXT) FROM forder_hd_status ORDER BY ORDERNO`,
+		"O2|2026-01-31 00:00:00+07:00",
+	)
+
+	// Accounting amounts with thousands dots and a trailing minus.
+	checkRows(t, conn, `SELECT CUSTNO, CAST(CLIMIT AS TEXT), IS_ACTIVE FROM fcustmst ORDER BY CUSTNO`,
+		"C1|1500000.5|1",
+		"C2|-250000|1",
+	)
+}
+
+func TestImportWriterErrorFailsStep(t *testing.T) {
+	cfg := fixtureConfig(t)
+	cfg.BatchSize = 1
+	cfg.CommitModes = map[string]string{"MPRICE": config.CommitBatched}
+
+	conn, err := db.Open(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	for _, q := range []string{`DROP TABLE m_price_dummy`, `CREATE TABLE m_price_dummy (X INTEGER)`} {
+		if _, err := conn.Exec(q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	importFixture(t, cfg, "MSKU", "SUCCESS", 0)
+	// The load error fails the step before finalize runs.
+	rep := importFixture(t, cfg, "MPRICE", "FAILED", 2)
+	if !strings.Contains(rep.Error, "no column named") {
+		t.Errorf("MPRICE: error %q, want the m_price_dummy load error", rep.Error)
+	}
+}
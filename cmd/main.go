@@ -2,36 +2,497 @@ package main
 
 import (
 	"context"
+	"encoding/csv"
+	"errors"
 	"flag"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
 	"path/filepath"
 	"runtime"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/google/uuid"
 
 	"go-import-file/internal/config"
+	"go-import-file/internal/dates"
 	"go-import-file/internal/db"
 	"go-import-file/internal/ftp"
+	"go-import-file/internal/lock"
+	"go-import-file/internal/metrics"
+	"go-import-file/internal/migrate"
+	"go-import-file/internal/notify"
 	"go-import-file/internal/orchestrator"
+	"go-import-file/internal/report"
+	"go-import-file/internal/sink"
 	"go-import-file/internal/utils"
+	"go-import-file/internal/worker"
 )
 
 func main() {
 	block := flag.String("block", "", "Block filter ex: MPRICE")
+	configPath := flag.String("config", "", "Config file (YAML), default CONFIG_FILE or ./config.yaml")
+	profile := flag.String("profile", "", "Profile to run, or ALL for every profile in the config")
+	resume := flag.String("resume", "", "Process ID of a failed run whose batched loads should continue")
+	at := flag.String("at", "", "Date for the history command, e.g. 2026-01-31 or \"2026-01-31 08:00:00\"")
 	flag.Parse()
 
+	// Subcommands: "config check", "check integrity", "migrate up", "migrate status", "rollback", "history"
+	if flag.NArg() > 0 {
+		switch cmd := strings.Join(flag.Args(), " "); cmd {
+		case "config check":
+			if err := config.Check(os.Stdout, config.ResolvePath(*configPath)); err != nil {
+				os.Exit(1)
+			}
+			return
+		case "check integrity":
+			if err := runCheckIntegrity(context.Background(), config.ResolvePath(*configPath), *profile, strings.TrimSpace(*block)); err != nil {
+				log.Fatalf("Integrity check failed: %v", err)
+			}
+			return
+		case "migrate up", "migrate status":
+			if err := runMigrate(context.Background(), config.ResolvePath(*configPath), *profile, flag.Arg(1)); err != nil {
+				log.Fatalf("Migrate failed: %v", err)
+			}
+			return
+		case "rollback":
+			if err := runRollback(context.Background(), config.ResolvePath(*configPath), *profile, strings.TrimSpace(*block)); err != nil {
+				log.Fatalf("Rollback failed: %v", err)
+			}
+			return
+		case "history":
+			if err := runHistory(context.Background(), config.ResolvePath(*configPath), *profile, strings.TrimSpace(*block), *at); err != nil {
+				log.Fatalf("History failed: %v", err)
+			}
+			return
+		default:
+			log.Fatalf("Unknown command: %s", strings.Join(flag.Args(), " "))
+		}
+	}
+
 	blockID := strings.TrimSpace(*block)
 	if blockID == "" {
 		log.Fatalf("No block specified")
 	}
 
 	start := time.Now()
-	ctx := context.Background()
 
-	cfg := config.Load()
+	// Config is loaded and validated before anything connects or downloads.
+	set, err := config.LoadFile(config.ResolvePath(*configPath))
+	if err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+
+	profiles, err := set.Select(*profile)
+	if err != nil {
+		log.Fatalf("Invalid profile: %v", err)
+	}
+
+	if *resume != "" && len(profiles) > 1 {
+		log.Fatalf("-resume needs a single profile, process IDs are per profile")
+	}
+	for _, cfg := range profiles {
+		cfg.Run = config.Run{Block: blockID, ProcessID: *resume, Resume: *resume != ""}
+	}
+
+	notifiers := make(map[*config.Config]*notify.Dispatcher, len(profiles))
+	for _, cfg := range profiles {
+		d, err := notify.New(cfg.Notify)
+		if err != nil {
+			log.Fatalf("Invalid notification config: %v", err)
+		}
+		notifiers[cfg] = d
+	}
+
+	var grace time.Duration
+	for _, cfg := range profiles {
+		grace = max(grace, time.Duration(cfg.ShutdownGraceSeconds)*time.Second)
+	}
+	ctx := shutdownContext(grace)
+
+	// Profiles run one after another, each with its own process ID,
+	// directories, logs and report.
+	summary := report.NewSummary(blockID)
+	for _, cfg := range profiles {
+		if ctx.Err() != nil {
+			log.Printf("Shutdown requested, profile %s not started", cfg.Profile)
+			continue
+		}
+		rep := runProfile(ctx, cfg, blockID, notifiers[cfg])
+		summary.Add(cfg.Profile, rep)
+	}
+	summary.Finish()
+
+	if len(profiles) > 1 {
+		path, err := report.WriteSummary(set.Base.LogsDir, summary)
+		if err != nil {
+			log.Printf("Failed to write run summary: %v", err)
+		} else {
+			log.Printf("Run summary: %s", path)
+		}
+		for _, p := range summary.Profiles {
+			log.Printf("PROFILE %-20s %-7s process=%s lines=%d rejected=%d inserted=%d updated=%d %s",
+				p.Profile, p.Status, p.ProcessID, p.Totals.Lines, p.Totals.Rejected, p.Totals.Inserted, p.Totals.Updated, p.Error)
+		}
+	}
+
+	if summary.Failed > 0 {
+		log.Fatalf("IMPORT FAILED: %d of %d profile run(s) failed", summary.Failed, len(summary.Profiles))
+	}
+	if ctx.Err() != nil {
+		log.Printf("IMPORT CANCELLED after %s", time.Since(start))
+		os.Exit(exitCancelled)
+	}
+
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	log.Printf("Alloc=%dMB Sys=%dMB", m.Alloc/1024/1024, m.Sys/1024/1024)
+	log.Printf("ALL IMPORTS COMPLETED IN %s\n", time.Since(start))
+}
+
+// exitCancelled is the exit code of a run stopped by SIGINT or SIGTERM,
+// as a shell reports a process killed by Ctrl-C.
+const exitCancelled = 130
+
+// shutdownContext is cancelled by the first SIGINT or SIGTERM. The run
+// then has grace to roll back and put its files back; a second signal or
+// the end of grace exits at once, leaving the rollback to the database.
+func shutdownContext(grace time.Duration) context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	sigs := make(chan os.Signal, 2)
+	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		sig := <-sigs
+		log.Printf("SHUTDOWN: %s received, stopping (grace %s)", sig, grace)
+		cancel()
+
+		select {
+		case sig = <-sigs:
+			log.Printf("SHUTDOWN: %s received again, exiting now", sig)
+		case <-time.After(grace):
+			log.Printf("SHUTDOWN: not stopped within %s, exiting now", grace)
+		}
+		os.Exit(exitCancelled)
+	}()
+
+	return ctx
+}
+
+// runProfile imports blockID for one resolved config and always returns a
+// report, also when the run failed before the import chain started.
+func runProfile(ctx context.Context, cfg *config.Config, blockID string, notifier *notify.Dispatcher) report.Report {
+	if cfg.Profile != "" {
+		log.SetPrefix("[" + cfg.Profile + "] ")
+		defer log.SetPrefix("")
+	}
+
+	processID := cfg.Run.ProcessID
+	if cfg.Run.Resume {
+		log.Printf("Resuming process ID %s\n", processID)
+	} else {
+		processID = uuid.New().String()
+		cfg.Run.ProcessID = processID
+		log.Printf("Process ID %s\n", processID)
+	}
+
+	metrics.BeginRun(processID)
+	dates.SetZone(cfg.Location())
+
+	runErr := importBlock(ctx, cfg, blockID, processID)
+	if runErr != nil && ctx.Err() != nil && !errors.Is(runErr, context.Canceled) {
+		// Drivers report an aborted query in their own words.
+		runErr = fmt.Errorf("%w: %w", ctx.Err(), runErr)
+	}
+
+	rep := report.Build(metrics.Snapshot(), runErr)
+	rep.Profile = cfg.Profile
+
+	jsonPath, htmlPath, err := report.Write(cfg.LogsDir, rep)
+	if err != nil {
+		log.Printf("Failed to write run report: %v", err)
+	} else {
+		log.Printf("Run report: %s, %s", jsonPath, htmlPath)
+	}
+
+	// A cancelled run is still reported.
+	for _, ev := range notify.RunEvents(rep, cfg.JobName, blockID, cfg.Notify.RejectRateThreshold) {
+		notifier.Dispatch(context.WithoutCancel(ctx), ev)
+	}
+
+	switch {
+	case rep.Status == "CANCELLED":
+		log.Printf("IMPORT CANCELLED: %v", runErr)
+	case runErr != nil:
+		log.Printf("IMPORT FAILED: %v", runErr)
+	}
+
+	return rep
+}
+
+// runMigrate applies ("up") or lists ("status") the embedded schema
+// migrations for every selected profile.
+func runMigrate(ctx context.Context, configPath, profile, action string) error {
+	set, err := config.LoadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	profiles, err := set.Select(profile)
+	if err != nil {
+		return err
+	}
+
+	for _, cfg := range profiles {
+		if err := migrateProfile(ctx, cfg, action); err != nil {
+			if cfg.Profile != "" {
+				return fmt.Errorf("profile %s: %w", cfg.Profile, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func migrateProfile(ctx context.Context, cfg *config.Config, action string) error {
+	dbConn, err := db.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("DB connection failed: %w", err)
+	}
+	defer dbConn.Close()
+
+	title := fmt.Sprintf("%s %s", cfg.DB.Driver, cfg.DB.Name)
+	if cfg.Profile != "" {
+		title = "[" + cfg.Profile + "] " + title
+	}
+	fmt.Println(title)
+
+	m := migrate.New(cfg, dbConn)
+
+	if action == "up" {
+		applied, err := m.Up(ctx)
+		for _, mig := range applied {
+			fmt.Printf("  applied %04d %s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("  up to date")
+		}
+		return nil
+	}
+
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+	for _, st := range statuses {
+		state := "pending"
+		if st.Applied {
+			state = "applied " + st.AppliedAt.Format(time.DateTime)
+		}
+		fmt.Printf("  %04d %-20s %s\n", st.Version, st.Name, state)
+	}
+	return nil
+}
+
+// runRollback restores the previous generation of a swapped full-refresh
+// block for every selected profile.
+func runRollback(ctx context.Context, configPath, profile, blockID string) error {
+	if !strings.EqualFold(blockID, "SDEAL") {
+		return fmt.Errorf("rollback supports -block=SDEAL only, got %q", blockID)
+	}
+
+	set, err := config.LoadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	profiles, err := set.Select(profile)
+	if err != nil {
+		return err
+	}
+
+	for _, cfg := range profiles {
+		if cfg.DB.Driver != "sqlserver" {
+			return fmt.Errorf("rollback requires database.driver sqlserver, got %s", cfg.DB.Driver)
+		}
+
+		dbConn, err := db.Open(cfg)
+		if err != nil {
+			return fmt.Errorf("DB connection failed: %w", err)
+		}
+		err = orchestrator.RunSalesDealRollback(ctx, dbConn)
+		dbConn.Close()
+		if err != nil {
+			if cfg.Profile != "" {
+				return fmt.Errorf("profile %s: %w", cfg.Profile, err)
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// runCheckIntegrity reports the rows referencing missing master rows of
+// blockID (all blocks when empty) for every selected profile and fails
+// when there are any.
+func runCheckIntegrity(ctx context.Context, configPath, profile, blockID string) error {
+	set, err := config.LoadFile(configPath)
+	if err != nil {
+		return err
+	}
+
+	profiles, err := set.Select(profile)
+	if err != nil {
+		return err
+	}
+
+	var dangling int64
+	for _, cfg := range profiles {
+		dbConn, err := db.Open(cfg)
+		if err != nil {
+			return fmt.Errorf("DB connection failed: %w", err)
+		}
+
+		var results []orchestrator.IntegrityResult
+		snk, err := sink.New(cfg, dbConn)
+		if err == nil {
+			results, err = orchestrator.CheckIntegrity(ctx, snk, blockID, "")
+		}
+		dbConn.Close()
+		if err != nil {
+			if cfg.Profile != "" {
+				return fmt.Errorf("profile %s: %w", cfg.Profile, err)
+			}
+			return err
+		}
+
+		title := fmt.Sprintf("%s %s", cfg.DB.Driver, cfg.DB.Name)
+		if cfg.Profile != "" {
+			title = "[" + cfg.Profile + "] " + title
+		}
+		fmt.Println(title)
+
+		for _, r := range results {
+			state := "ok"
+			if r.Rows > 0 {
+				state = fmt.Sprintf("%d dangling, e.g. %s", r.Rows, r.SampleText())
+				dangling += r.Rows
+			}
+			fmt.Printf("  %-10s %-36s %s\n", r.Rule.Block, r.Rule.Name(), state)
+		}
+	}
+
+	if dangling > 0 {
+		return fmt.Errorf("%d rows reference missing master rows", dangling)
+	}
+	return nil
+}
+
+// runHistory writes the state of a master block's table as of a date, as
+// recorded in its history table, to stdout as CSV.
+func runHistory(ctx context.Context, configPath, profile, blockID, at string) error {
+	spec, ok := worker.MasterTable(blockID)
+	if !ok {
+		return fmt.Errorf("history needs -block of a master block, got %q", blockID)
+	}
+
+	t, err := parseAt(at)
+	if err != nil {
+		return err
+	}
+
+	set, err := config.LoadFile(configPath)
+	if err != nil {
+		return err
+	}
 
+	profiles, err := set.Select(profile)
+	if err != nil {
+		return err
+	}
+	if len(profiles) > 1 {
+		return fmt.Errorf("history needs a single profile")
+	}
+	cfg := profiles[0]
+
+	dbConn, err := db.Open(cfg)
+	if err != nil {
+		return fmt.Errorf("DB connection failed: %w", err)
+	}
+	defer dbConn.Close()
+
+	snk, err := sink.New(cfg, dbConn)
+	if err != nil {
+		return err
+	}
+
+	rows, err := snk.AsOf(ctx, spec, t)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	w.Write(cols)
+
+	values := make([]any, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	record := make([]string, len(cols))
+
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return err
+		}
+		for i, v := range values {
+			switch v := v.(type) {
+			case nil:
+				record[i] = ""
+			case []byte:
+				record[i] = string(v)
+			case time.Time:
+				record[i] = v.Format(time.DateTime)
+			default:
+				record[i] = fmt.Sprint(v)
+			}
+		}
+		w.Write(record)
+	}
+	w.Flush()
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+func parseAt(at string) (time.Time, error) {
+	if at == "" {
+		return time.Now(), nil
+	}
+	for _, layout := range []string{time.DateTime, time.DateOnly, time.RFC3339} {
+		if t, err := time.ParseInLocation(layout, at, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid -at %q, use 2006-01-02 or \"2006-01-02 15:04:05\"", at)
+}
+
+func importBlock(ctx context.Context, cfg *config.Config, blockID, processID string) error {
 	for _, dir := range []string{
 		cfg.FilePath,
 		cfg.FileDir,
@@ -40,67 +501,175 @@ func main() {
 		cfg.LogsDir,
 	} {
 		if err := utils.EnsureDir(dir); err != nil {
-			log.Fatalf("Failed to create dir %s: %v", dir, err)
+			return fmt.Errorf("failed to create dir %s: %w", dir, err)
 		}
 	}
 
-	dbConn, err := db.NewSQLServer(
-		cfg.DBHost,
-		cfg.DBPort,
-		cfg.DBUser,
-		cfg.DBPass,
-		cfg.DBName,
-	)
+	dbConn, err := db.Open(cfg)
 	if err != nil {
-		log.Fatalf("DB connection failed: %v", err)
+		return fmt.Errorf("DB connection failed: %w", err)
 	}
 	defer dbConn.Close()
 
+	snk, err := sink.New(cfg, dbConn)
+	if err != nil {
+		return err
+	}
+
+	// Held from before the download until after finalize, so a second
+	// instance never picks up files or merges into the same targets.
+	lk, err := lock.Acquire(ctx, cfg, blockID)
+	var busy *lock.BusyError
+	if errors.As(err, &busy) && cfg.Lock.Busy == config.LockSkip {
+		log.Printf("LOCK %s busy, skipping run: held by %s", busy.Resource, busy.Holder)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("lock: %w", err)
+	}
+	log.Printf("LOCK %s acquired by %s", lk.Resource, lock.Owner())
+	defer func() {
+		if err := lk.Release(); err != nil {
+			log.Printf("LOCK %s release failed: %v", lk.Resource, err)
+		}
+	}()
+
+	// A lost lock stops the run like a shutdown does, but fails it.
+	ctx, stop := context.WithCancelCause(ctx)
+	defer stop(nil)
+	go func() {
+		select {
+		case <-lk.Lost():
+			stop(lock.ErrLost)
+		case <-ctx.Done():
+		}
+	}()
+
+	if cfg.FTP.Disabled {
+		log.Printf("FTP disabled, importing files already in %s", cfg.FilePath)
+	} else if err := download(ctx, cfg); err != nil {
+		return err
+	}
+
+	worker.ResetMoves()
+	chain := orchestrator.New()
+	chain.Timeout = cfg.StepTimeout
+	chain.Idle = time.Duration(cfg.IdleTimeoutSeconds) * time.Second
+	blocks := blockRegistry(cfg, snk, processID)
+
+	// =========================================================
+	// EXECUTION
+	// =========================================================
+	steps, ok := blocks[blockID]
+	if !ok {
+		return errors.New("unknown block: " + blockID)
+	}
+	metrics.BeginBlock(blockID)
+	log.Printf("Running block: %s\n", blockID)
+	addSteps(chain, steps)
+
+	err = chain.Run(ctx)
+	if err != nil || ctx.Err() != nil {
+		restoreFiles(cfg)
+	}
+	if cause := context.Cause(ctx); err != nil && errors.Is(cause, lock.ErrLost) {
+		return fmt.Errorf("%w: %s, run stopped: %v", cause, lk.Resource, err)
+	}
+	return err
+}
+
+func addSteps(chain *orchestrator.ImportChain, steps []blockStep) {
+	for _, step := range steps {
+		if step.Watch {
+			chain.AddWatched(step.Name, step.Fn)
+		} else {
+			chain.Add(step.Name, step.Fn)
+		}
+	}
+}
+
+// restoreFiles puts the files a stopped or failed run moved to the success
+// and failed dirs back into file_path, as far as the step that loaded them
+// did not commit.
+func restoreFiles(cfg *config.Config) {
+	n, err := worker.RestoreMoves()
+	if err != nil {
+		log.Printf("%d files returned to %s, others not: %v", n, cfg.FilePath, err)
+		return
+	}
+	log.Printf("%d files returned to %s", n, cfg.FilePath)
+}
+
+// requireSQLServer guards the finalize steps, which are written in T-SQL
+// and run against SQL Server only.
+func requireSQLServer(snk sink.Sink) error {
+	if snk.Driver() != sink.DriverSQLServer {
+		return fmt.Errorf("finalize requires database.driver sqlserver, got %s", snk.Driver())
+	}
+	return nil
+}
+
+func download(ctx context.Context, cfg *config.Config) error {
 	// Initialize FTP client
 	ftpClient, err := ftp.NewClient(cfg.FTP)
 	if err != nil {
-		log.Fatalf("Failed to create FTP client: %v", err)
+		return fmt.Errorf("failed to create FTP client: %w", err)
 	}
 	defer ftpClient.Close()
 
 	// Download files from FTP
 	// File akan LANGSUNG di-move/delete setelah download
 	log.Println("Starting FTP download...")
-	files, err := ftpClient.DownloadFiles(cfg.FilePath)
+	files, err := ftpClient.DownloadFiles(ctx, cfg.FilePath)
 	if err != nil {
-		log.Fatalf("Failed to download files: %v", err)
+		return fmt.Errorf("failed to download files: %w", err)
 	}
 	log.Printf("Downloaded %d files (files moved/deleted from FTP immediately)", len(files))
 
-	processID := uuid.New().String()
-	chain := orchestrator.New()
+	return nil
+}
 
-	log.Printf("Process ID %s\n", processID)
+type blockStep struct {
+	Name  string
+	Fn    func(context.Context) error
+	Watch bool // reads files; watched for stalls (idle_timeout_seconds)
+}
 
-	// =========================================================
-	// BLOCK REGISTRY
-	// =========================================================
-	blocks := map[string][]struct {
-		Name string
-		Fn   func(context.Context) error
-	}{
+// =========================================================
+// BLOCK REGISTRY
+// =========================================================
+func blockRegistry(cfg *config.Config, snk sink.Sink, processID string) map[string][]blockStep {
+	dbConn := snk.DB()
+
+	return map[string][]blockStep{
 		"MPRICE": {
 			{
-				Name: "IMPORT MPRICE",
+				Name:  "IMPORT MPRICE",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMPrice(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
 			},
+			{
+				Name: "CHECK INTEGRITY MPRICE",
+				Fn: func(ctx context.Context) error {
+					return orchestrator.RunIntegrityCheck(ctx, cfg, snk, "MPRICE", processID)
+				},
+			},
 			{
 				Name: "FINALIZE MPRICE",
 				Fn: func(ctx context.Context) error {
+					if err := requireSQLServer(snk); err != nil {
+						return err
+					}
 					return orchestrator.RunMPriceFinalizeIdempotent(
 						ctx,
+						cfg,
 						dbConn,
 						processID,
 					)
@@ -109,12 +678,13 @@ func main() {
 		},
 		"MPRICEGRP": {
 			{
-				Name: "IMPORT MPRICEGRP",
+				Name:  "IMPORT MPRICEGRP",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMPriceGrp(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -122,25 +692,33 @@ func main() {
 		},
 		"MCUST": {
 			{
-				Name: "IMPORT MCUST",
+				Name:  "IMPORT MCUST",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMCust(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
 			},
+			{
+				Name: "CHECK INTEGRITY MCUST",
+				Fn: func(ctx context.Context) error {
+					return orchestrator.RunIntegrityCheck(ctx, cfg, snk, "MCUST", processID)
+				},
+			},
 		},
 		"MSKU": {
 			{
-				Name: "IMPORT MSKU",
+				Name:  "IMPORT MSKU",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMsku(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -148,12 +726,13 @@ func main() {
 		},
 		"MCUSTGRP": {
 			{
-				Name: "IMPORT MCUSTGRP",
+				Name:  "IMPORT MCUSTGRP",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMCustGrp(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -161,12 +740,13 @@ func main() {
 		},
 		"MCUSTINDUS": {
 			{
-				Name: "IMPORT MCUSTINDUS",
+				Name:  "IMPORT MCUSTINDUS",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMCustIndus(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -174,12 +754,13 @@ func main() {
 		},
 		"MSALESMAN": {
 			{
-				Name: "IMPORT MSALESMAN",
+				Name:  "IMPORT MSALESMAN",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMsalesman(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -187,12 +768,13 @@ func main() {
 		},
 		"SLSINV": {
 			{
-				Name: "IMPORT SLSINV",
+				Name:  "IMPORT SLSINV",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunSlsInv(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -200,12 +782,13 @@ func main() {
 		},
 		"ARINVOICE": {
 			{
-				Name: "IMPORT ARINVOICE",
+				Name:  "IMPORT ARINVOICE",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunArInvoice(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -213,12 +796,13 @@ func main() {
 		},
 		"IMSTKBAL": {
 			{
-				Name: "IMPORT IMSTKBAL",
+				Name:  "IMPORT IMSTKBAL",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunImStkbal(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -226,12 +810,13 @@ func main() {
 		},
 		"MBACKORDER": {
 			{
-				Name: "IMPORT MBACKORDER",
+				Name:  "IMPORT MBACKORDER",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMBackOrder(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -239,12 +824,13 @@ func main() {
 		},
 		"MBEAT": {
 			{
-				Name: "IMPORT MBEAT",
+				Name:  "IMPORT MBEAT",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMBeat(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -252,12 +838,13 @@ func main() {
 		},
 		"MCUSTCL": {
 			{
-				Name: "IMPORT MCUSTCL",
+				Name:  "IMPORT MCUSTCL",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMCustCl(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -265,12 +852,13 @@ func main() {
 		},
 		"MCUSTINVD": {
 			{
-				Name: "IMPORT MCUSTINVD",
+				Name:  "IMPORT MCUSTINVD",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMCustInvD(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -278,12 +866,13 @@ func main() {
 		},
 		"MCUSTINVH": {
 			{
-				Name: "IMPORT MCUSTINVH",
+				Name:  "IMPORT MCUSTINVH",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMCustInvH(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -291,12 +880,13 @@ func main() {
 		},
 		"MCUSTTYPE": {
 			{
-				Name: "IMPORT MCUSTTYPE",
+				Name:  "IMPORT MCUSTTYPE",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMCustType(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -304,12 +894,13 @@ func main() {
 		},
 		"MDISTRICT": {
 			{
-				Name: "IMPORT MDISTRICT",
+				Name:  "IMPORT MDISTRICT",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMDistrict(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -317,12 +908,13 @@ func main() {
 		},
 		"MKAT": {
 			{
-				Name: "IMPORT MKAT",
+				Name:  "IMPORT MKAT",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMKat(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -330,12 +922,13 @@ func main() {
 		},
 		"MKPLPRICE": {
 			{
-				Name: "IMPORT MKPLPRICE",
+				Name:  "IMPORT MKPLPRICE",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMkplPrice(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -343,8 +936,12 @@ func main() {
 			{
 				Name: "FINALIZE MKPLPRICE",
 				Fn: func(ctx context.Context) error {
+					if err := requireSQLServer(snk); err != nil {
+						return err
+					}
 					return orchestrator.RunMkplPriceFinalizeIdempotent(
 						ctx,
+						cfg,
 						dbConn,
 						processID,
 					)
@@ -353,12 +950,13 @@ func main() {
 		},
 		"MMARKET": {
 			{
-				Name: "IMPORT MMARKET",
+				Name:  "IMPORT MMARKET",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMmarket(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -366,12 +964,13 @@ func main() {
 		},
 		"MPAYERTO": {
 			{
-				Name: "IMPORT MPAYERTO",
+				Name:  "IMPORT MPAYERTO",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMPayerTo(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -379,12 +978,13 @@ func main() {
 		},
 		"MPROVINCE": {
 			{
-				Name: "IMPORT MPROVINCE",
+				Name:  "IMPORT MPROVINCE",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMProvince(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -392,12 +992,13 @@ func main() {
 		},
 		"MRUTE": {
 			{
-				Name: "IMPORT MRUTE",
+				Name:  "IMPORT MRUTE",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMRute(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -405,12 +1006,13 @@ func main() {
 		},
 		"MSBRAND": {
 			{
-				Name: "IMPORT MSBRAND",
+				Name:  "IMPORT MSBRAND",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMSBrand(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -418,12 +1020,13 @@ func main() {
 		},
 		"MSHIPTO": {
 			{
-				Name: "IMPORT MSHIPTO",
+				Name:  "IMPORT MSHIPTO",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMShipTo(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -431,12 +1034,13 @@ func main() {
 		},
 		"MSLINE": {
 			{
-				Name: "IMPORT MSLINE",
+				Name:  "IMPORT MSLINE",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMSline(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -444,12 +1048,13 @@ func main() {
 		},
 		"MSUBBEAT": {
 			{
-				Name: "IMPORT MSUBBEAT",
+				Name:  "IMPORT MSUBBEAT",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMSubBeat(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -457,12 +1062,13 @@ func main() {
 		},
 		"MSUBBRAND": {
 			{
-				Name: "IMPORT MSUBBRAND",
+				Name:  "IMPORT MSUBBRAND",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMSubBrand(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -470,12 +1076,13 @@ func main() {
 		},
 		"MTOP": {
 			{
-				Name: "IMPORT MTOP",
+				Name:  "IMPORT MTOP",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunMTop(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -487,8 +1094,8 @@ func main() {
 				Fn: func(ctx context.Context) error {
 					return orchestrator.RunSalesDeal(
 						ctx,
-						dbConn,
-						cfg.FilePath,
+						cfg,
+						snk,
 						processID,
 					)
 				},
@@ -509,22 +1116,56 @@ func main() {
 				},
 			},
 			{
-				Name: "TRUNCATE SDEAL",
+				Name: "PREPARE SDEAL",
 				Fn: func(ctx context.Context) error {
+					if cfg.SwapFullRefresh() {
+						// A resumed run keeps the shadow rows already committed.
+						return orchestrator.RunSalesDealPrepareShadow(
+							ctx,
+							dbConn,
+							cfg.Run.Resume,
+						)
+					}
+					if cfg.Run.Resume {
+						log.Println("Resume: SDEAL tables are not truncated")
+						return nil
+					}
+					if cfg.FullRefresh == config.FullRefreshSwap {
+						log.Printf("full_refresh swap needs sqlserver, truncating SDEAL tables on %s", snk.Driver())
+					}
 					return orchestrator.RunSalesDealTruncate(
 						ctx,
-						dbConn,
+						snk,
 						processID,
 					)
 				},
 			},
 			{
-				Name: "IMPORT SDEAL",
+				Name:  "IMPORT SDEAL",
+				Watch: true,
 				Fn: func(ctx context.Context) error {
+					target := snk
+					if cfg.SwapFullRefresh() {
+						target = orchestrator.SalesDealShadowSink(snk)
+					}
 					return orchestrator.RunSalesDeal(
 						ctx,
+						cfg,
+						target,
+						processID,
+					)
+				},
+			},
+			{
+				Name: "SWAP SDEAL",
+				Fn: func(ctx context.Context) error {
+					if !cfg.SwapFullRefresh() {
+						return nil
+					}
+					return orchestrator.RunSalesDealSwapIdempotent(
+						ctx,
+						cfg,
 						dbConn,
-						cfg.FilePath,
 						processID,
 					)
 				},
@@ -532,8 +1173,12 @@ func main() {
 			{
 				Name: "FINALIZE SDEAL",
 				Fn: func(ctx context.Context) error {
+					if err := requireSQLServer(snk); err != nil {
+						return err
+					}
 					return orchestrator.RunSalesDealFinalizeIdempotent(
 						ctx,
+						cfg,
 						dbConn,
 						processID,
 					)
@@ -541,35 +1186,4 @@ func main() {
 			},
 		},
 	}
-
-	// =========================================================
-	// EXECUTION
-	// =========================================================
-	if blockID == "" {
-		for blockName, steps := range blocks {
-			log.Printf("Register block: %s\n", blockName)
-			for _, step := range steps {
-				chain.Add(step.Name, step.Fn)
-			}
-		}
-	} else {
-		steps, ok := blocks[blockID]
-		if !ok {
-			log.Fatalf("Unknown block: %s", blockID)
-		}
-
-		log.Printf("Running block: %s\n", blockID)
-		for _, step := range steps {
-			chain.Add(step.Name, step.Fn)
-		}
-	}
-
-	if err := chain.Run(ctx); err != nil {
-		log.Fatalf("IMPORT FAILED: %v", err)
-	}
-
-	var m runtime.MemStats
-	runtime.ReadMemStats(&m)
-	log.Printf("Alloc=%dMB Sys=%dMB", m.Alloc/1024/1024, m.Sys/1024/1024)
-	log.Printf("ALL IMPORTS COMPLETED IN %s\n", time.Since(start))
 }
@@ -0,0 +1,168 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Check loads and validates the configuration at path and writes the
+// effective settings (secrets masked) to w. It never touches the network.
+func Check(w io.Writer, path string) error {
+	source := path
+	if source == "" {
+		source = "(environment only)"
+	}
+	fmt.Fprintf(w, "config source : %s\n", source)
+
+	set, err := LoadFile(path)
+	if err != nil {
+		fmt.Fprintf(w, "config INVALID\n%v\n", err)
+		return err
+	}
+
+	if len(set.Profiles) == 0 {
+		set.Base.Describe(w)
+	}
+	for _, name := range set.Names() {
+		fmt.Fprintf(w, "profile %s\n", name)
+		set.Profiles[name].Describe(w)
+	}
+	fmt.Fprintln(w, "config OK")
+	return nil
+}
+
+func (c *Config) Describe(w io.Writer) {
+	row := func(k string, v any) {
+		fmt.Fprintf(w, "  %-28s %v\n", k, v)
+	}
+
+	row("job_name", c.JobName)
+	row("file_path", c.FilePath)
+	row("process_dir", c.FileDir)
+	row("process_success_dir", c.FileSuccessDir)
+	row("process_failed_dir", c.FileFailedDir)
+	row("log_path", c.LogsDir)
+	if c.DB.Driver == "sqlite" {
+		row("database", "sqlite "+c.DB.Name)
+	} else {
+		row("database", fmt.Sprintf("%s %s@%s:%s/%s (password %s)", c.DB.Driver, c.DB.User, c.DB.Host, c.DB.Port, c.DB.Name, mask(c.DB.Password)))
+	}
+	row("worker_count", c.Worker)
+	row("buffer_size", c.BufferSize)
+	row("batch_size", c.BatchSize)
+	row("commit_modes", commitModes(c))
+	row("snapshots", snapshots(c))
+	row("history", historyBlocks(c))
+	row("full_refresh", c.FullRefresh)
+	row("price_movement_pct", c.PriceMovementPct)
+	row("orphan_price_max", orphanPriceMax(c))
+	row("integrity_gate", integrityGate(c))
+	row("retry", fmt.Sprintf("%d attempts, backoff %dms..%dms, jitter %g", c.Retry.Attempts, c.Retry.BackoffMs, c.Retry.MaxBackoffMs, c.Retry.Jitter))
+	row("lock", lockBusy(c))
+	row("timeout_seconds", c.TimeoutSeconds)
+	row("idle_timeout_seconds", c.IdleTimeoutSeconds)
+	row("step_timeouts", stepTimeouts(c))
+	row("finalize_lease_seconds", c.FinalizeLeaseSeconds)
+	row("shutdown_grace_seconds", c.ShutdownGraceSeconds)
+	row("kodecabang", c.Kodecabang)
+	row("time_zone", c.Location())
+	row("uom_buy", c.UomBuy)
+	row("uom_main", c.UomMain)
+	if c.FTP.Disabled {
+		row("ftp", "disabled")
+	} else {
+		row("ftp", fmt.Sprintf("%s@%s:%d%s (password %s)", c.FTP.Username, c.FTP.Host, c.FTP.Port, c.FTP.RemoteDir, mask(c.FTP.Password)))
+		row("ftp.file_pattern", c.FTP.FilePattern)
+		row("ftp.filename_pattern", c.FTP.FilenamePattern)
+		row("ftp.move/delete", fmt.Sprintf("%t/%t (archive %q)", c.FTP.MoveAfterDownload, c.FTP.DeleteAfterDownload, c.FTP.ArchiveDir))
+	}
+	row("notify.reject_rate", c.Notify.RejectRateThreshold)
+	for i, t := range c.Notify.Targets {
+		dest := t.URL
+		if t.Type == "smtp" {
+			dest = fmt.Sprintf("%s:%d -> %s", t.SMTPHost, t.SMTPPort, strings.Join(t.To, ","))
+		}
+		row(fmt.Sprintf("notify.targets[%d]", i), fmt.Sprintf("%s %s blocks=%v min=%s", t.Type, dest, t.Blocks, t.MinSeverity))
+	}
+}
+
+func mask(secret string) string {
+	if secret == "" {
+		return "<empty>"
+	}
+	return "<set>"
+}
+
+func commitModes(c *Config) string {
+	if len(c.CommitModes) == 0 {
+		return CommitAtomic + " (all blocks)"
+	}
+	parts := make([]string, 0, len(c.CommitModes))
+	for _, block := range slices.Sorted(maps.Keys(c.CommitModes)) {
+		parts = append(parts, block+"="+c.CommitModes[block])
+	}
+	return strings.Join(parts, ", ") + " (others " + CommitAtomic + ")"
+}
+
+func snapshots(c *Config) string {
+	if len(c.Snapshots) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(c.Snapshots))
+	for _, block := range slices.Sorted(maps.Keys(c.Snapshots)) {
+		snap := c.Snapshots[block]
+		ratio := snap.MaxRatio
+		if ratio == 0 {
+			ratio = DefaultSnapshotMaxRatio
+		}
+		parts = append(parts, fmt.Sprintf("%s=%s (max %g)", block, snap.Mode, ratio))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func historyBlocks(c *Config) string {
+	if len(c.History) == 0 {
+		return "none"
+	}
+	return strings.Join(c.History, ", ")
+}
+
+func orphanPriceMax(c *Config) string {
+	if c.OrphanPriceMax < 0 {
+		return "report only"
+	}
+	return strconv.Itoa(c.OrphanPriceMax)
+}
+
+func integrityGate(c *Config) string {
+	if len(c.IntegrityGate) == 0 {
+		return "none (report only)"
+	}
+	return strings.Join(c.IntegrityGate, ", ")
+}
+
+func lockBusy(c *Config) string {
+	if c.Lock.Busy == LockSkip {
+		return "skip when busy"
+	}
+	return fmt.Sprintf("wait up to %ds when busy", c.Lock.WaitSeconds)
+}
+
+func stepTimeouts(c *Config) string {
+	def := "none"
+	if c.StepTimeoutSeconds > 0 {
+		def = strconv.Itoa(c.StepTimeoutSeconds) + "s"
+	}
+	if len(c.StepTimeouts) == 0 {
+		return def
+	}
+	parts := make([]string, 0, len(c.StepTimeouts))
+	for _, step := range slices.Sorted(maps.Keys(c.StepTimeouts)) {
+		parts = append(parts, fmt.Sprintf("%s=%ds", step, c.StepTimeouts[step]))
+	}
+	return strings.Join(parts, ", ") + " (others " + def + ")"
+}
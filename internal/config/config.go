@@ -1,102 +1,631 @@
 package config
 
 import (
-	"log"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
 	"os"
-	"strconv"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	_ "time/tzdata" // time_zone must resolve on Windows too
 
 	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
 )
 
+// DefaultFile is used when neither -config nor CONFIG_FILE is given and the
+// file exists in the working directory.
+const DefaultFile = "config.yaml"
+
 type Config struct {
-	JobName  string
-	FilePath string
+	// Profile is the name of the profile this config was resolved for,
+	// empty for a config file without profiles.
+	Profile string `yaml:"-"`
+
+	// Run is filled by main for the current invocation.
+	Run Run `yaml:"-"`
+
+	JobName  string `yaml:"job_name"`
+	FilePath string `yaml:"file_path"`
+
+	FileDir        string `yaml:"process_dir"`
+	FileSuccessDir string `yaml:"process_success_dir"`
+	FileFailedDir  string `yaml:"process_failed_dir"`
+	LogsDir        string `yaml:"log_path"`
+
+	DB DBConfig `yaml:"database"`
+
+	Worker     int `yaml:"worker_count"`
+	BufferSize int `yaml:"buffer_size"`
+	BatchSize  int `yaml:"batch_size"`
+
+	// TimeoutSeconds bounds connecting and logging in to the database.
+	TimeoutSeconds int `yaml:"timeout_seconds"`
+
+	// IdleTimeoutSeconds is how long an import step may go without reading
+	// a line or writing a row, while it reads its files, before the
+	// watchdog cancels it; 0 disables.
+	IdleTimeoutSeconds int `yaml:"idle_timeout_seconds"`
+
+	// StepTimeoutSeconds is the deadline of every step, 0 for none. It also
+	// bounds the writes an import step makes after its files are read,
+	// which the watchdog no longer sees.
+	// StepTimeouts overrides it per step name, e.g. "FINALIZE MPRICE": 900.
+	StepTimeoutSeconds int            `yaml:"step_timeout_seconds"`
+	StepTimeouts       map[string]int `yaml:"step_timeouts"`
+
+	// FinalizeLeaseSeconds is how long a RUNNING finalize stays claimed
+	// without a heartbeat; after that another run may take it over.
+	FinalizeLeaseSeconds int `yaml:"finalize_lease_seconds"`
+
+	// ShutdownGraceSeconds is how long a run may take to roll back and
+	// put its files back after SIGINT/SIGTERM before the process exits.
+	ShutdownGraceSeconds int `yaml:"shutdown_grace_seconds"`
+
+	// CommitModes picks CommitAtomic (default) or CommitBatched per block,
+	// e.g. SDEAL: batched. Only insert-only tables are batched; upserts
+	// always merge in one transaction.
+	CommitModes map[string]string `yaml:"commit_modes"`
+
+	// Snapshots treats the file of a block as a full snapshot of its master
+	// table within each KODECABANG it contains: rows missing from it are
+	// retired, e.g. MCUST: {mode: deactivate}.
+	Snapshots map[string]SnapshotConfig `yaml:"snapshots"`
+
+	// History lists blocks whose upserts keep a type 2 history of their
+	// master table in <table>_history, e.g. [MCUST, MSKU].
+	History []string `yaml:"history"`
+
+	// FullRefresh is how full-refresh blocks (SDEAL) replace their tables:
+	// FullRefreshSwap loads shadow tables and swaps them in (SQL Server
+	// only, other drivers truncate), FullRefreshTruncate empties the live
+	// tables before loading.
+	FullRefresh string `yaml:"full_refresh"`
+
+	// PriceMovementPct flags price changes above this percentage in the
+	// price movement report of the MPRICE and MKPLPRICE finalize steps.
+	PriceMovementPct float64 `yaml:"price_movement_pct"`
+
+	// OrphanPriceMax fails the MPRICE and MKPLPRICE finalize steps when more
+	// staged prices than this have a PCODE missing from fmaster; -1 only
+	// reports them.
+	OrphanPriceMax int `yaml:"orphan_price_max"`
+
+	// IntegrityGate lists blocks whose run fails, before any finalize step,
+	// when the tables they load reference missing master rows. Other
+	// blocks only report them.
+	IntegrityGate []string `yaml:"integrity_gate"`
 
-	FileDir        string
-	FileSuccessDir string
-	FileFailedDir  string
-	LogsDir        string
+	Retry RetryConfig `yaml:"retry"`
 
-	DBHost string
-	DBPort string
-	DBUser string
-	DBPass string
-	DBName string
+	Lock LockConfig `yaml:"lock"`
 
-	Worker             int
-	BufferSize         int
-	TimeoutSeconds     int
-	IdleTimeoutSeconds int
-	BatchSize          int
+	Kodecabang string `yaml:"kodecabang"`
 
-	UomBuy  string
-	UomMain string
+	// TimeZone is the business time zone, e.g. Asia/Jakarta: dates in the
+	// files are read in it and CORE_PROCESSDATE is stamped in it. Empty
+	// means the zone of the machine.
+	TimeZone string `yaml:"time_zone"`
 
-	FTP FTPConfig
+	UomBuy  string `yaml:"uom_buy"`
+	UomMain string `yaml:"uom_main"`
+
+	FTP FTPConfig `yaml:"ftp"`
+
+	Notify NotifyConfig `yaml:"notify"`
+}
+
+const (
+	// CommitAtomic loads each table of a block in one transaction.
+	CommitAtomic = "atomic"
+	// CommitBatched commits every batch_size rows and records checkpoints,
+	// so a failed load can be resumed with -resume.
+	CommitBatched = "batched"
+)
+
+const (
+	FullRefreshSwap     = "swap"
+	FullRefreshTruncate = "truncate"
+)
+
+// What a run does when another instance holds its block lock.
+const (
+	LockWait = "wait" // wait up to wait_seconds, then fail
+	LockSkip = "skip" // log the holder and end without importing
+)
+
+type Run struct {
+	Block     string
+	ProcessID string
+	Resume    bool // ProcessID is an earlier run being resumed
+}
+
+// SwapFullRefresh reports whether full-refresh blocks load into shadow
+// tables and swap them in.
+func (c *Config) SwapFullRefresh() bool {
+	return c.FullRefresh == FullRefreshSwap && c.DB.Driver == "sqlserver"
+}
+
+// CommitMode returns the commit mode of the block being run.
+func (c *Config) CommitMode() string {
+	for block, mode := range c.CommitModes {
+		if strings.EqualFold(block, c.Run.Block) {
+			return strings.ToLower(mode)
+		}
+	}
+	return CommitAtomic
+}
+
+const (
+	SnapshotDeactivate = "deactivate"
+	SnapshotFlag       = "flag"
+	SnapshotDelete     = "delete"
+
+	DefaultSnapshotMaxRatio = 0.05
+)
+
+// snapshotBlocks are the blocks a snapshot may be configured for: their
+// master table carries IS_ACTIVE and DELETED_AT (see the migrations) and
+// has KODECABANG in its key, so a file only retires rows of the branches
+// it contains.
+var snapshotBlocks = []string{"MCUST"}
+
+type SnapshotConfig struct {
+	Mode string `yaml:"mode"` // deactivate, flag or delete
+
+	// MaxRatio caps the share of rows in scope one run may retire,
+	// DefaultSnapshotMaxRatio when zero.
+	MaxRatio float64 `yaml:"max_ratio"`
+}
+
+// Snapshot returns the snapshot settings of the block being run.
+func (c *Config) Snapshot() (SnapshotConfig, bool) {
+	for block, snap := range c.Snapshots {
+		if strings.EqualFold(block, c.Run.Block) {
+			snap.Mode = strings.ToLower(snap.Mode)
+			if snap.MaxRatio == 0 {
+				snap.MaxRatio = DefaultSnapshotMaxRatio
+			}
+			return snap, true
+		}
+	}
+	return SnapshotConfig{}, false
+}
+
+// HistoryEnabled reports whether the block being run keeps history.
+func (c *Config) HistoryEnabled() bool {
+	return slices.ContainsFunc(c.History, func(block string) bool {
+		return strings.EqualFold(block, c.Run.Block)
+	})
+}
+
+// StepTimeout returns the deadline of the step called name, 0 for none.
+func (c *Config) StepTimeout(name string) time.Duration {
+	for step, secs := range c.StepTimeouts {
+		if strings.EqualFold(step, name) {
+			return time.Duration(secs) * time.Second
+		}
+	}
+	return time.Duration(c.StepTimeoutSeconds) * time.Second
+}
+
+// Location returns the business time zone. TimeZone is checked by
+// validate, so an unknown zone here falls back to the machine's.
+func (c *Config) Location() *time.Location {
+	if c.TimeZone == "" {
+		return time.Local // LoadLocation("") would be UTC
+	}
+	loc, err := time.LoadLocation(c.TimeZone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// IntegrityGated reports whether dangling references fail block.
+func (c *Config) IntegrityGated(block string) bool {
+	return slices.ContainsFunc(c.IntegrityGate, func(b string) bool {
+		return strings.EqualFold(b, block)
+	})
+}
+
+// RetryConfig governs retries of deadlocks, lock timeouts and dropped
+// connections in upsert merges and finalize steps.
+type RetryConfig struct {
+	Attempts     int     `yaml:"attempts"` // total tries, 1 disables retries
+	BackoffMs    int     `yaml:"backoff_ms"`
+	MaxBackoffMs int     `yaml:"max_backoff_ms"`
+	Jitter       float64 `yaml:"jitter"` // 0..1
+}
+
+// LockConfig governs the per-block lock that keeps two instances from
+// running the same block at once.
+type LockConfig struct {
+	Busy        string `yaml:"busy"` // LockWait or LockSkip
+	WaitSeconds int    `yaml:"wait_seconds"`
+}
+
+type DBConfig struct {
+	Driver   string `yaml:"driver"` // sqlserver (default), postgres or sqlite
+	Host     string `yaml:"host"`
+	Port     string `yaml:"port"`
+	User     string `yaml:"user"`
+	Password string `yaml:"password"`
+	Name     string `yaml:"name"`    // database name, or the file for sqlite
+	Schema   string `yaml:"schema"`  // postgres only, replaces dbo
+	SSLMode  string `yaml:"sslmode"` // postgres only
 }
 
 type FTPConfig struct {
-	Host                string
-	Port                int
-	Username            string
-	Password            string
-	RemoteDir           string
-	FilePattern         string
-	ArchiveDir          string
-	DeleteAfterDownload bool
-	MoveAfterDownload   bool
-}
-
-func Load() *Config {
-	_ = godotenv.Load()
+	// Disabled skips the download and imports whatever is already in
+	// file_path (local runs, CI fixtures).
+	Disabled            bool   `yaml:"disabled"`
+	Host                string `yaml:"host"`
+	Port                int    `yaml:"port"`
+	Username            string `yaml:"username"`
+	Password            string `yaml:"password"`
+	RemoteDir           string `yaml:"remote_dir"`
+	FilePattern         string `yaml:"file_pattern"`
+	FilenamePattern     string `yaml:"filename_pattern"`
+	ArchiveDir          string `yaml:"archive_dir"`
+	DeleteAfterDownload bool   `yaml:"delete_after_download"`
+	MoveAfterDownload   bool   `yaml:"move_after_download"`
+}
+
+type NotifyConfig struct {
+	// RejectRateThreshold is a percentage of rejected lines per block above
+	// which a WARNING notification is sent. 0 disables the check.
+	RejectRateThreshold float64        `yaml:"reject_rate_threshold"`
+	Targets             []NotifyTarget `yaml:"targets"`
+}
+
+type NotifyTarget struct {
+	Type        string   `yaml:"type"` // webhook, slack or smtp
+	URL         string   `yaml:"url"`
+	SMTPHost    string   `yaml:"smtp_host"`
+	SMTPPort    int      `yaml:"smtp_port"`
+	Username    string   `yaml:"username"`
+	Password    string   `yaml:"password"`
+	From        string   `yaml:"from"`
+	To          []string `yaml:"to"`
+	Blocks      []string `yaml:"blocks"`       // empty = all blocks
+	MinSeverity string   `yaml:"min_severity"` // INFO, WARNING or ERROR
+}
+
+func Defaults() *Config {
+	return &Config{
+		FileDir:        "./transfer",
+		FileSuccessDir: "./transfer/success",
+		FileFailedDir:  "./transfer/failed",
+		LogsDir:        "./logs",
+
+		DB: DBConfig{
+			Driver: "sqlserver",
+		},
 
-	workerCount, _ := strconv.Atoi(os.Getenv("WORKER_COUNT"))
-	bufferSize, _ := strconv.Atoi(os.Getenv("BUFFER_SIZE"))
-	timeoutSeconds, _ := strconv.Atoi(os.Getenv("TIMEOUT_SECONDS"))
-	idleTimeoutSeconds, _ := strconv.Atoi(os.Getenv("IDLE_TIMEOUT_SECONDS"))
-	batch, _ := strconv.Atoi(os.Getenv("BATCH_SIZE"))
-	port, _ := strconv.Atoi(os.Getenv("FTP_PORT"))
-	deleteAfterDownload, _ := strconv.ParseBool(os.Getenv("FTP_DELETE"))
-	moveAfterDownload, _ := strconv.ParseBool(os.Getenv("FTP_MOVE"))
-
-	cfg := &Config{
-		JobName:  os.Getenv("JOB_NAME"),
-		FilePath: os.Getenv("FILE_PATH"),
-
-		DBHost: os.Getenv("SQLSERVER_HOST"),
-		DBPort: os.Getenv("SQLSERVER_PORT"),
-		DBUser: os.Getenv("SQLSERVER_USER"),
-		DBPass: os.Getenv("SQLSERVER_PASSWORD"),
-		DBName: os.Getenv("SQLSERVER_DB"),
-
-		FileDir:            os.Getenv("PROCESS_DIR"),
-		FileSuccessDir:     os.Getenv("PROCESS_SUCCESS_DIR"),
-		FileFailedDir:      os.Getenv("PROCESS_FAILED_DIR"),
-		LogsDir:            os.Getenv("LOG_PATH"),
-		UomBuy:             os.Getenv("UOM_BUY"),
-		UomMain:            os.Getenv("UOM_MAIN"),
-		Worker:             workerCount,
-		BufferSize:         bufferSize,
-		TimeoutSeconds:     timeoutSeconds,
-		IdleTimeoutSeconds: idleTimeoutSeconds,
-		BatchSize:          batch,
+		Worker:             4,
+		BufferSize:         1000,
+		TimeoutSeconds:     30,
+		IdleTimeoutSeconds: 300,
+		StepTimeoutSeconds: 3600,
+		BatchSize:          10000,
+
+		FinalizeLeaseSeconds: 120,
+		ShutdownGraceSeconds: 30,
+
+		FullRefresh:      FullRefreshSwap,
+		PriceMovementPct: 20,
+		OrphanPriceMax:   -1,
+
+		Retry: RetryConfig{
+			Attempts:     3,
+			BackoffMs:    500,
+			MaxBackoffMs: 10000,
+			Jitter:       0.2,
+		},
+
+		Lock: LockConfig{
+			Busy:        LockWait,
+			WaitSeconds: 600,
+		},
 
 		FTP: FTPConfig{
-			Host:                os.Getenv("FTP_HOST"),
-			Port:                port,
-			Username:            os.Getenv("FTP_USERNAME"),
-			Password:            os.Getenv("FTP_PASSWORD"),
-			RemoteDir:           os.Getenv("FTP_REMOTE_DIR"),
-			FilePattern:         os.Getenv("FTP_FILE_PATTERN"),
-			ArchiveDir:          os.Getenv("FTP_ARCHIVE_DIR"),
-			DeleteAfterDownload: deleteAfterDownload,
-			MoveAfterDownload:   moveAfterDownload,
+			Port:        21,
+			FilePattern: "*.txt",
 		},
 	}
+}
+
+// ResolvePath picks the config file: the explicit path, then CONFIG_FILE,
+// then DefaultFile when it exists. An empty result means env-only.
+func ResolvePath(path string) string {
+	if path != "" {
+		return path
+	}
+	if p := os.Getenv("CONFIG_FILE"); p != "" {
+		return p
+	}
+	if _, err := os.Stat(DefaultFile); err == nil {
+		return DefaultFile
+	}
+	return ""
+}
+
+// fileConfig is the on-disk layout: the base settings plus named profiles
+// that override any of them.
+type fileConfig struct {
+	Config   `yaml:",inline"`
+	Profiles map[string]yaml.Node `yaml:"profiles"`
+}
+
+// LoadFile builds the configuration from defaults, the YAML file at path
+// (optional) and environment variables (.env included), in that order, then
+// resolves every profile on top of that base and validates the result.
+// Nothing is connected or downloaded here.
+func LoadFile(path string) (*Set, error) {
+	_ = godotenv.Load()
+
+	fc := fileConfig{Config: *Defaults()}
+
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, &FileError{Path: path, Err: err}
+		}
+		defer f.Close()
 
-	if cfg.FilePath == "" {
-		log.Fatal("FILE_PATH wajib diisi")
+		dec := yaml.NewDecoder(f)
+		dec.KnownFields(true)
+		if err := dec.Decode(&fc); err != nil && !errors.Is(err, io.EOF) {
+			return nil, &FileError{Path: path, Err: err}
+		}
 	}
 
-	return cfg
+	var errs ValidationError
+	base := &fc.Config
+	applyEnv(base, &errs)
+
+	set := &Set{Base: base, Profiles: map[string]*Config{}}
+
+	for name, node := range fc.Profiles {
+		if strings.EqualFold(name, AllProfiles) {
+			errs.Add("profiles."+name, "name is reserved for -profile="+AllProfiles)
+			continue
+		}
+
+		cfg, err := resolveProfile(base, name, &node)
+		if err != nil {
+			return nil, &FileError{Path: path, Err: fmt.Errorf("profile %s: %w", name, err)}
+		}
+		cfg.validate(&errs, "profiles."+name+".")
+		set.Profiles[name] = cfg
+	}
+
+	// Profiles are resolved from the unfilled base so driver dependent
+	// defaults follow the profile's own driver.
+	base.fillDefaults()
+	if len(fc.Profiles) == 0 {
+		base.validate(&errs, "")
+	}
+
+	checkIsolation(set, &errs)
+
+	if len(errs.Fields) > 0 {
+		return nil, &errs
+	}
+
+	return set, nil
+}
+
+// resolveProfile decodes a profile on top of a copy of the base config.
+// Directories the profile leaves untouched get the profile name appended so
+// two profiles never share an inbox, archive or log directory.
+func resolveProfile(base *Config, name string, node *yaml.Node) (*Config, error) {
+	cfg := *base
+	cfg.Profile = name
+	cfg.Notify.Targets = append([]NotifyTarget(nil), base.Notify.Targets...)
+	cfg.CommitModes = maps.Clone(base.CommitModes)
+	cfg.Snapshots = maps.Clone(base.Snapshots)
+	cfg.StepTimeouts = maps.Clone(base.StepTimeouts)
+
+	// Round trip through bytes so unknown keys are rejected like in the base.
+	raw, err := yaml.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(raw))
+	dec.KnownFields(true)
+	if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	cfg.fillDefaults()
+
+	for _, dir := range []struct {
+		dst  *string
+		base string
+	}{
+		{&cfg.FilePath, base.FilePath},
+		{&cfg.FileDir, base.FileDir},
+		{&cfg.FileSuccessDir, base.FileSuccessDir},
+		{&cfg.FileFailedDir, base.FileFailedDir},
+		{&cfg.LogsDir, base.LogsDir},
+	} {
+		if *dir.dst == dir.base && dir.base != "" {
+			*dir.dst = filepath.Join(dir.base, name)
+		}
+	}
+
+	return &cfg, nil
+}
+
+func checkIsolation(set *Set, errs *ValidationError) {
+	seen := map[string]string{}
+	for _, name := range set.Names() {
+		p := filepath.Clean(set.Profiles[name].FilePath)
+		if other, ok := seen[p]; ok {
+			errs.Add("profiles."+name+".file_path", fmt.Sprintf("is shared with profile %s", other))
+			continue
+		}
+		seen[p] = name
+	}
+}
+
+// fillDefaults sets defaults that depend on other settings.
+func (c *Config) fillDefaults() {
+	if c.DB.Port == "" && c.DB.Driver != "sqlite" {
+		switch c.DB.Driver {
+		case "postgres":
+			c.DB.Port = "5432"
+		default:
+			c.DB.Port = "1433"
+		}
+	}
+}
+
+// validate reports invalid settings; prefix is prepended to every field
+// name so errors point at the profile they belong to.
+func (c *Config) validate(errs *ValidationError, prefix string) {
+	add := func(field, message string) {
+		errs.Add(prefix+field, message)
+	}
+	required := func(field, value string) {
+		if value == "" {
+			add(field, "is required")
+		}
+	}
+	positive := func(field string, value int) {
+		if value < 1 {
+			add(field, fmt.Sprintf("must be at least 1, got %d", value))
+		}
+	}
+	nonNegative := func(field string, value int) {
+		if value < 0 {
+			add(field, fmt.Sprintf("must not be negative, got %d", value))
+		}
+	}
+
+	required("file_path", c.FilePath)
+	required("process_dir", c.FileDir)
+	required("process_success_dir", c.FileSuccessDir)
+	required("process_failed_dir", c.FileFailedDir)
+	required("log_path", c.LogsDir)
+
+	switch c.DB.Driver {
+	case "sqlserver", "postgres":
+		required("database.host", c.DB.Host)
+		required("database.port", c.DB.Port)
+		required("database.user", c.DB.User)
+	case "sqlite":
+	default:
+		add("database.driver", fmt.Sprintf("must be sqlserver, postgres or sqlite, got %q", c.DB.Driver))
+	}
+	required("database.name", c.DB.Name)
+
+	if _, err := time.LoadLocation(c.TimeZone); err != nil {
+		add("time_zone", fmt.Sprintf("unknown time zone %q", c.TimeZone))
+	}
+
+	positive("worker_count", c.Worker)
+	positive("buffer_size", c.BufferSize)
+	nonNegative("timeout_seconds", c.TimeoutSeconds)
+	nonNegative("idle_timeout_seconds", c.IdleTimeoutSeconds)
+	nonNegative("step_timeout_seconds", c.StepTimeoutSeconds)
+	for step, secs := range c.StepTimeouts {
+		nonNegative("step_timeouts."+step, secs)
+	}
+	positive("finalize_lease_seconds", c.FinalizeLeaseSeconds)
+	positive("shutdown_grace_seconds", c.ShutdownGraceSeconds)
+	positive("batch_size", c.BatchSize)
+	switch c.FullRefresh {
+	case FullRefreshSwap, FullRefreshTruncate:
+	default:
+		add("full_refresh", fmt.Sprintf("must be swap or truncate, got %q", c.FullRefresh))
+	}
+	if c.PriceMovementPct < 0 {
+		add("price_movement_pct", fmt.Sprintf("must be >= 0, got %g", c.PriceMovementPct))
+	}
+	if c.OrphanPriceMax < -1 {
+		add("orphan_price_max", fmt.Sprintf("must be >= -1, got %d", c.OrphanPriceMax))
+	}
+	positive("retry.attempts", c.Retry.Attempts)
+	nonNegative("retry.backoff_ms", c.Retry.BackoffMs)
+	nonNegative("retry.max_backoff_ms", c.Retry.MaxBackoffMs)
+	if c.Retry.Jitter < 0 || c.Retry.Jitter > 1 {
+		add("retry.jitter", fmt.Sprintf("must be between 0 and 1, got %g", c.Retry.Jitter))
+	}
+	switch c.Lock.Busy {
+	case LockWait, LockSkip:
+	default:
+		add("lock.busy", fmt.Sprintf("must be wait or skip, got %q", c.Lock.Busy))
+	}
+	nonNegative("lock.wait_seconds", c.Lock.WaitSeconds)
+	for block, mode := range c.CommitModes {
+		switch strings.ToLower(mode) {
+		case CommitAtomic, CommitBatched:
+		default:
+			add("commit_modes."+block, fmt.Sprintf("must be atomic or batched, got %q", mode))
+		}
+	}
+	for block, snap := range c.Snapshots {
+		if !slices.ContainsFunc(snapshotBlocks, func(b string) bool { return strings.EqualFold(b, block) }) {
+			add("snapshots."+block, fmt.Sprintf(
+				"is only supported for %s: the table needs IS_ACTIVE, DELETED_AT and KODECABANG in its key",
+				strings.Join(snapshotBlocks, ", "),
+			))
+		}
+		switch strings.ToLower(snap.Mode) {
+		case SnapshotDeactivate, SnapshotFlag, SnapshotDelete:
+		default:
+			add("snapshots."+block+".mode", fmt.Sprintf("must be deactivate, flag or delete, got %q", snap.Mode))
+		}
+		if snap.MaxRatio < 0 || snap.MaxRatio > 1 {
+			add("snapshots."+block+".max_ratio", fmt.Sprintf("must be between 0 and 1, got %g", snap.MaxRatio))
+		}
+	}
+
+	if !c.FTP.Disabled {
+		required("ftp.host", c.FTP.Host)
+		if c.FTP.Port < 1 || c.FTP.Port > 65535 {
+			add("ftp.port", fmt.Sprintf("must be between 1 and 65535, got %d", c.FTP.Port))
+		}
+		if c.FTP.MoveAfterDownload && c.FTP.ArchiveDir == "" {
+			add("ftp.archive_dir", "is required when ftp.move_after_download is true")
+		}
+	}
+
+	if c.Notify.RejectRateThreshold < 0 || c.Notify.RejectRateThreshold > 100 {
+		add("notify.reject_rate_threshold", fmt.Sprintf("must be a percentage between 0 and 100, got %g", c.Notify.RejectRateThreshold))
+	}
+	for i, t := range c.Notify.Targets {
+		field := fmt.Sprintf("notify.targets[%d]", i)
+
+		switch strings.ToLower(t.Type) {
+		case "webhook", "slack":
+			if t.URL == "" {
+				add(field+".url", "is required for type "+t.Type)
+			}
+		case "smtp":
+			if t.SMTPHost == "" {
+				add(field+".smtp_host", "is required for type smtp")
+			}
+			if t.From == "" {
+				add(field+".from", "is required for type smtp")
+			}
+			if len(t.To) == 0 {
+				add(field+".to", "needs at least one recipient")
+			}
+		default:
+			add(field+".type", fmt.Sprintf("must be webhook, slack or smtp, got %q", t.Type))
+		}
+
+		switch strings.ToUpper(t.MinSeverity) {
+		case "", "INFO", "SUCCESS", "WARNING", "WARN", "ERROR":
+		default:
+			add(field+".min_severity", fmt.Sprintf("must be INFO, WARNING or ERROR, got %q", t.MinSeverity))
+		}
+	}
 }
@@ -0,0 +1,303 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig writes body to a config file and returns its path.
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// minimal is the smallest valid config file.
+const minimal = `
+file_path: ./in
+database: {driver: sqlite, name: import.db}
+ftp: {disabled: true}
+`
+
+func TestLoadFile(t *testing.T) {
+	set, err := LoadFile(writeConfig(t, minimal+`
+worker_count: 8
+commit_modes: {SDEAL: batched}
+step_timeouts: {FINALIZE MPRICE: 900}
+time_zone: Asia/Jakarta
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := set.Base
+
+	if c.Worker != 8 || c.CommitModes["SDEAL"] != CommitBatched || c.TimeZone != "Asia/Jakarta" {
+		t.Errorf("file values not read: %+v", c)
+	}
+	// Everything the file leaves out keeps its default.
+	if c.BufferSize != 1000 || c.BatchSize != 10000 || c.Retry.Attempts != 3 || c.Lock.Busy != LockWait {
+		t.Errorf("defaults lost: %+v", c)
+	}
+	if got := c.StepTimeout("finalize mprice"); got != 900*time.Second {
+		t.Errorf("StepTimeout(finalize mprice) = %v, want 15m", got)
+	}
+	if got := c.StepTimeout("IMPORT MPRICE"); got != time.Hour {
+		t.Errorf("StepTimeout(IMPORT MPRICE) = %v, want the 1h default", got)
+	}
+	if len(set.Profiles) != 0 {
+		t.Errorf("profiles = %v, want none", set.Names())
+	}
+}
+
+func TestLoadFileDefaultPort(t *testing.T) {
+	tests := []struct {
+		driver, want string
+	}{
+		{"sqlserver", "1433"},
+		{"postgres", "5432"},
+		{"sqlite", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.driver, func(t *testing.T) {
+			set, err := LoadFile(writeConfig(t, `
+file_path: ./in
+database: {driver: `+tt.driver+`, host: db, user: u, name: n}
+ftp: {disabled: true}
+`))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if set.Base.DB.Port != tt.want {
+				t.Errorf("port %q, want %q", set.Base.DB.Port, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadFileExample(t *testing.T) {
+	if _, err := LoadFile("../../config.example.yaml"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    func(t *testing.T) string
+		wantErr string
+	}{
+		{"missing file", func(t *testing.T) string { return filepath.Join(t.TempDir(), "none.yaml") }, "no such file"},
+		{"unknown key", func(t *testing.T) string { return writeConfig(t, minimal+"worker: 4\n") }, "field worker not found"},
+		{"wrong type", func(t *testing.T) string { return writeConfig(t, minimal+"worker_count: four\n") }, "cannot unmarshal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFile(tt.path(t))
+
+			var fe *FileError
+			if !errors.As(err, &fe) || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want a FileError with %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestEnvOverrides(t *testing.T) {
+	t.Setenv("WORKER_COUNT", "2")
+	t.Setenv("COMMIT_MODES", "SDEAL=batched,MPRICE=atomic")
+	t.Setenv("HISTORY", "MCUST|MSKU")
+	t.Setenv("FTP_DISABLED", "true")
+	t.Setenv("RETRY_JITTER", " 0.5 ")
+
+	set, err := LoadFile(writeConfig(t, minimal+"worker_count: 8\ncommit_modes: {MSKU: batched}\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := set.Base
+
+	if c.Worker != 2 {
+		t.Errorf("worker_count %d, want the env value 2", c.Worker)
+	}
+	if len(c.CommitModes) != 2 || c.CommitModes["SDEAL"] != CommitBatched || c.CommitModes["MPRICE"] != CommitAtomic {
+		t.Errorf("commit_modes %v, want the env map only", c.CommitModes)
+	}
+	if len(c.History) != 2 || c.History[1] != "MSKU" {
+		t.Errorf("history %v", c.History)
+	}
+	if c.Retry.Jitter != 0.5 {
+		t.Errorf("retry.jitter %g, want 0.5", c.Retry.Jitter)
+	}
+}
+
+func TestEnvErrors(t *testing.T) {
+	tests := []struct {
+		env, value string
+		field      string
+		wantErr    string
+	}{
+		{"WORKER_COUNT", "abc", "worker_count (env WORKER_COUNT)", `invalid integer "abc"`},
+		{"WORKER_COUNT", "0", "worker_count", "must be at least 1, got 0"},
+		{"FTP_DISABLED", "maybe", "ftp.disabled (env FTP_DISABLED)", "invalid boolean"},
+		{"RETRY_JITTER", "x", "retry.jitter (env RETRY_JITTER)", "invalid number"},
+		{"COMMIT_MODES", "SDEAL", "commit_modes (env COMMIT_MODES)", `invalid entry "SDEAL" (use KEY=value)`},
+		{"STEP_TIMEOUTS", "IMPORT SDEAL=soon", "step_timeouts (env STEP_TIMEOUTS)", "invalid number"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env+"="+tt.value, func(t *testing.T) {
+			t.Setenv(tt.env, tt.value)
+
+			_, err := LoadFile(writeConfig(t, minimal))
+
+			var ve *ValidationError
+			if !errors.As(err, &ve) {
+				t.Fatalf("err = %v, want a ValidationError", err)
+			}
+			if want := tt.field + ": " + tt.wantErr; !strings.Contains(err.Error(), want) {
+				t.Errorf("err = %v, want %q", err, want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  func(c *Config)
+		field   string
+		wantErr string
+	}{
+		{"missing file path", func(c *Config) { c.FilePath = "" }, "file_path", "is required"},
+		{"unknown driver", func(c *Config) { c.DB.Driver = "mysql" }, "database.driver", `got "mysql"`},
+		{"server without host", func(c *Config) { c.DB.Driver = "sqlserver" }, "database.host", "is required"},
+		{"zero workers", func(c *Config) { c.Worker = 0 }, "worker_count", "must be at least 1, got 0"},
+		{"negative step timeout", func(c *Config) { c.StepTimeoutSeconds = -1 }, "step_timeout_seconds", "must not be negative"},
+		{"negative step override", func(c *Config) { c.StepTimeouts = map[string]int{"IMPORT SDEAL": -5} }, "step_timeouts.IMPORT SDEAL", "must not be negative"},
+		{"unknown time zone", func(c *Config) { c.TimeZone = "Mars/Base" }, "time_zone", `unknown time zone "Mars/Base"`},
+		{"full refresh", func(c *Config) { c.FullRefresh = "drop" }, "full_refresh", "must be swap or truncate"},
+		{"jitter", func(c *Config) { c.Retry.Jitter = 2 }, "retry.jitter", "between 0 and 1"},
+		{"lock busy", func(c *Config) { c.Lock.Busy = "queue" }, "lock.busy", "must be wait or skip"},
+		{"commit mode", func(c *Config) { c.CommitModes = map[string]string{"SDEAL": "lazy"} }, "commit_modes.SDEAL", "must be atomic or batched"},
+		{"snapshot mode", func(c *Config) { c.Snapshots = map[string]SnapshotConfig{"MCUST": {Mode: "drop"}} }, "snapshots.MCUST.mode", "must be deactivate, flag or delete"},
+		{"snapshot ratio", func(c *Config) { c.Snapshots = map[string]SnapshotConfig{"MCUST": {Mode: SnapshotFlag, MaxRatio: 2}} }, "snapshots.MCUST.max_ratio", "between 0 and 1"},
+		{"orphan price max", func(c *Config) { c.OrphanPriceMax = -2 }, "orphan_price_max", "must be >= -1"},
+		{"ftp host", func(c *Config) { c.FTP.Disabled = false }, "ftp.host", "is required"},
+		{"ftp archive", func(c *Config) {
+			c.FTP = FTPConfig{Host: "ftp", Port: 21, MoveAfterDownload: true}
+		}, "ftp.archive_dir", "is required when ftp.move_after_download is true"},
+		{"notify target", func(c *Config) { c.Notify.Targets = []NotifyTarget{{Type: "smtp"}} }, "notify.targets[0].smtp_host", "is required for type smtp"},
+		{"notify severity", func(c *Config) {
+			c.Notify.Targets = []NotifyTarget{{Type: "slack", URL: "https://hooks", MinSeverity: "LOUD"}}
+		}, "notify.targets[0].min_severity", "must be INFO, WARNING or ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := valid()
+			tt.change(c)
+
+			errs := fieldErrors(c)
+			if got, ok := errs[tt.field]; !ok || !strings.Contains(got, tt.wantErr) {
+				t.Errorf("%s: %q, want %q (all: %v)", tt.field, got, tt.wantErr, errs)
+			}
+		})
+	}
+}
+
+func TestValidateAcceptsDefaults(t *testing.T) {
+	if errs := fieldErrors(valid()); len(errs) > 0 {
+		t.Errorf("errors = %v", errs)
+	}
+}
+
+func TestLocation(t *testing.T) {
+	jakarta, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		zone string
+		want *time.Location
+	}{
+		{"", time.Local},
+		{"Asia/Jakarta", jakarta},
+		{"UTC", time.UTC},
+		{"Mars/Base", time.Local},
+	}
+	for _, tt := range tests {
+		t.Run(tt.zone, func(t *testing.T) {
+			c := Config{TimeZone: tt.zone}
+			if got := c.Location(); got.String() != tt.want.String() {
+				t.Errorf("Location() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	var out bytes.Buffer
+	if err := Check(&out, writeConfig(t, minimal)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "config OK") {
+		t.Errorf("output:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := Check(&out, writeConfig(t, minimal+"worker_count: 0\n")); err == nil {
+		t.Fatal("invalid config passed")
+	}
+	if !strings.Contains(out.String(), "config INVALID") || !strings.Contains(out.String(), "worker_count: must be at least 1") {
+		t.Errorf("output:\n%s", out.String())
+	}
+}
+
+// valid is a base config that passes validate.
+func valid() *Config {
+	c := Defaults()
+	c.FilePath = "./in"
+	c.DB = DBConfig{Driver: "sqlite", Name: "import.db"}
+	c.FTP.Disabled = true
+	return c
+}
+
+// fieldErrors runs validate on c and returns the errors by field.
+func fieldErrors(c *Config) map[string]string {
+	var errs ValidationError
+	c.validate(&errs, "")
+	out := map[string]string{}
+	for _, f := range errs.Fields {
+		out[f.Field] = f.Message
+	}
+	return out
+}
+
+func TestValidateSnapshots(t *testing.T) {
+	tests := []struct {
+		block   string
+		wantErr string
+	}{
+		{"MCUST", ""},
+		{"mcust", ""},
+		{"MPRICE", "is only supported for MCUST"},
+		{"SLSINV", "is only supported for MCUST"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.block, func(t *testing.T) {
+			c := valid()
+			c.Snapshots = map[string]SnapshotConfig{tt.block: {Mode: SnapshotDeactivate, MaxRatio: 0.05}}
+
+			got := fieldErrors(c)["snapshots."+tt.block]
+			if tt.wantErr == "" && got != "" || !strings.Contains(got, tt.wantErr) {
+				t.Errorf("snapshots.%s: %q, want %q", tt.block, got, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type envVar struct {
+	name  string
+	field string
+	set   func(c *Config, v string) error
+}
+
+func str(dst func(c *Config) *string) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		*dst(c) = v
+		return nil
+	}
+}
+
+func integer(dst func(c *Config) *int) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid integer %q", v)
+		}
+		*dst(c) = n
+		return nil
+	}
+}
+
+func boolean(dst func(c *Config) *bool) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q (use true or false)", v)
+		}
+		*dst(c) = b
+		return nil
+	}
+}
+
+func number(dst func(c *Config) *float64) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid number %q", v)
+		}
+		*dst(c) = f
+		return nil
+	}
+}
+
+// list parses "A,B" (or "A|B") and replaces the whole list.
+func list(dst func(c *Config) *[]string) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		*dst(c) = splitList(v)
+		return nil
+	}
+}
+
+// keyValues parses "KEY=value,KEY=value" and replaces the whole map.
+func keyValues(dst func(c *Config) *map[string]string) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		m := map[string]string{}
+		for _, p := range splitList(v) {
+			key, value, ok := strings.Cut(p, "=")
+			if !ok {
+				return fmt.Errorf("invalid entry %q (use KEY=value)", p)
+			}
+			m[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+		*dst(c) = m
+		return nil
+	}
+}
+
+// keyIntegers parses "KEY=1,KEY=2" and replaces the whole map.
+func keyIntegers(dst func(c *Config) *map[string]int) func(*Config, string) error {
+	return func(c *Config, v string) error {
+		m := map[string]int{}
+		for _, p := range splitList(v) {
+			key, value, ok := strings.Cut(p, "=")
+			if !ok {
+				return fmt.Errorf("invalid entry %q (use KEY=number)", p)
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return fmt.Errorf("invalid number in %q", p)
+			}
+			m[strings.TrimSpace(key)] = n
+		}
+		*dst(c) = m
+		return nil
+	}
+}
+
+// envVars maps the historical environment variables onto config fields.
+// A set variable wins over the base settings of the config file; values a
+// profile sets itself still take precedence for that profile.
+var envVars = []envVar{
+	{"JOB_NAME", "job_name", str(func(c *Config) *string { return &c.JobName })},
+	{"FILE_PATH", "file_path", str(func(c *Config) *string { return &c.FilePath })},
+	{"PROCESS_DIR", "process_dir", str(func(c *Config) *string { return &c.FileDir })},
+	{"PROCESS_SUCCESS_DIR", "process_success_dir", str(func(c *Config) *string { return &c.FileSuccessDir })},
+	{"PROCESS_FAILED_DIR", "process_failed_dir", str(func(c *Config) *string { return &c.FileFailedDir })},
+	{"LOG_PATH", "log_path", str(func(c *Config) *string { return &c.LogsDir })},
+
+	{"DB_DRIVER", "database.driver", str(func(c *Config) *string { return &c.DB.Driver })},
+	{"DB_SCHEMA", "database.schema", str(func(c *Config) *string { return &c.DB.Schema })},
+	{"DB_SSLMODE", "database.sslmode", str(func(c *Config) *string { return &c.DB.SSLMode })},
+	{"SQLSERVER_HOST", "database.host", str(func(c *Config) *string { return &c.DB.Host })},
+	{"SQLSERVER_PORT", "database.port", str(func(c *Config) *string { return &c.DB.Port })},
+	{"SQLSERVER_USER", "database.user", str(func(c *Config) *string { return &c.DB.User })},
+	{"SQLSERVER_PASSWORD", "database.password", str(func(c *Config) *string { return &c.DB.Password })},
+	{"SQLSERVER_DB", "database.name", str(func(c *Config) *string { return &c.DB.Name })},
+
+	{"WORKER_COUNT", "worker_count", integer(func(c *Config) *int { return &c.Worker })},
+	{"BUFFER_SIZE", "buffer_size", integer(func(c *Config) *int { return &c.BufferSize })},
+	{"TIMEOUT_SECONDS", "timeout_seconds", integer(func(c *Config) *int { return &c.TimeoutSeconds })},
+	{"IDLE_TIMEOUT_SECONDS", "idle_timeout_seconds", integer(func(c *Config) *int { return &c.IdleTimeoutSeconds })},
+	{"STEP_TIMEOUT_SECONDS", "step_timeout_seconds", integer(func(c *Config) *int { return &c.StepTimeoutSeconds })},
+	{"STEP_TIMEOUTS", "step_timeouts", keyIntegers(func(c *Config) *map[string]int { return &c.StepTimeouts })},
+	{"FINALIZE_LEASE_SECONDS", "finalize_lease_seconds", integer(func(c *Config) *int { return &c.FinalizeLeaseSeconds })},
+	{"SHUTDOWN_GRACE_SECONDS", "shutdown_grace_seconds", integer(func(c *Config) *int { return &c.ShutdownGraceSeconds })},
+	{"BATCH_SIZE", "batch_size", integer(func(c *Config) *int { return &c.BatchSize })},
+	{"HISTORY", "history", list(func(c *Config) *[]string { return &c.History })},
+	{"FULL_REFRESH", "full_refresh", str(func(c *Config) *string { return &c.FullRefresh })},
+	{"PRICE_MOVEMENT_PCT", "price_movement_pct", number(func(c *Config) *float64 { return &c.PriceMovementPct })},
+	{"INTEGRITY_GATE", "integrity_gate", list(func(c *Config) *[]string { return &c.IntegrityGate })},
+	{"ORPHAN_PRICE_MAX", "orphan_price_max", integer(func(c *Config) *int { return &c.OrphanPriceMax })},
+	{"MAX_RETRY", "retry.attempts", integer(func(c *Config) *int { return &c.Retry.Attempts })},
+	{"RETRY_BACKOFF_MS", "retry.backoff_ms", integer(func(c *Config) *int { return &c.Retry.BackoffMs })},
+	{"RETRY_MAX_BACKOFF_MS", "retry.max_backoff_ms", integer(func(c *Config) *int { return &c.Retry.MaxBackoffMs })},
+	{"RETRY_JITTER", "retry.jitter", number(func(c *Config) *float64 { return &c.Retry.Jitter })},
+	{"LOCK_BUSY", "lock.busy", str(func(c *Config) *string { return &c.Lock.Busy })},
+	{"LOCK_WAIT_SECONDS", "lock.wait_seconds", integer(func(c *Config) *int { return &c.Lock.WaitSeconds })},
+	{"COMMIT_MODES", "commit_modes", keyValues(func(c *Config) *map[string]string { return &c.CommitModes })},
+
+	{"KODECABANG", "kodecabang", str(func(c *Config) *string { return &c.Kodecabang })},
+	{"TIME_ZONE", "time_zone", str(func(c *Config) *string { return &c.TimeZone })},
+	{"UOM_BUY", "uom_buy", str(func(c *Config) *string { return &c.UomBuy })},
+	{"UOM_MAIN", "uom_main", str(func(c *Config) *string { return &c.UomMain })},
+
+	{"FTP_DISABLED", "ftp.disabled", boolean(func(c *Config) *bool { return &c.FTP.Disabled })},
+	{"FTP_HOST", "ftp.host", str(func(c *Config) *string { return &c.FTP.Host })},
+	{"FTP_PORT", "ftp.port", integer(func(c *Config) *int { return &c.FTP.Port })},
+	{"FTP_USERNAME", "ftp.username", str(func(c *Config) *string { return &c.FTP.Username })},
+	{"FTP_PASSWORD", "ftp.password", str(func(c *Config) *string { return &c.FTP.Password })},
+	{"FTP_REMOTE_DIR", "ftp.remote_dir", str(func(c *Config) *string { return &c.FTP.RemoteDir })},
+	{"FTP_FILE_PATTERN", "ftp.file_pattern", str(func(c *Config) *string { return &c.FTP.FilePattern })},
+	{"FTP_FILENAME_PATTERN", "ftp.filename_pattern", str(func(c *Config) *string { return &c.FTP.FilenamePattern })},
+	{"FTP_ARCHIVE_DIR", "ftp.archive_dir", str(func(c *Config) *string { return &c.FTP.ArchiveDir })},
+	{"FTP_DELETE", "ftp.delete_after_download", boolean(func(c *Config) *bool { return &c.FTP.DeleteAfterDownload })},
+	{"FTP_MOVE", "ftp.move_after_download", boolean(func(c *Config) *bool { return &c.FTP.MoveAfterDownload })},
+}
+
+func applyEnv(cfg *Config, errs *ValidationError) {
+	for _, ev := range envVars {
+		v, ok := os.LookupEnv(ev.name)
+		if !ok {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		if err := ev.set(cfg, v); err != nil {
+			errs.AddSource(ev.field, "env "+ev.name, err.Error())
+		}
+	}
+
+	applyNotifyEnv(cfg, errs)
+}
+
+func applyNotifyEnv(cfg *Config, errs *ValidationError) {
+	if v := strings.TrimSpace(os.Getenv("NOTIFY_REJECT_RATE")); v != "" {
+		threshold, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			errs.AddSource("notify.reject_rate_threshold", "env NOTIFY_REJECT_RATE", fmt.Sprintf("invalid number %q", v))
+		} else {
+			cfg.Notify.RejectRateThreshold = threshold
+		}
+	}
+
+	if url := os.Getenv("NOTIFY_WEBHOOK_URL"); url != "" {
+		cfg.Notify.Targets = append(cfg.Notify.Targets, NotifyTarget{
+			Type:        "webhook",
+			URL:         url,
+			Blocks:      splitList(os.Getenv("NOTIFY_WEBHOOK_BLOCKS")),
+			MinSeverity: os.Getenv("NOTIFY_WEBHOOK_SEVERITY"),
+		})
+	}
+
+	if url := os.Getenv("NOTIFY_SLACK_URL"); url != "" {
+		cfg.Notify.Targets = append(cfg.Notify.Targets, NotifyTarget{
+			Type:        "slack",
+			URL:         url,
+			Blocks:      splitList(os.Getenv("NOTIFY_SLACK_BLOCKS")),
+			MinSeverity: os.Getenv("NOTIFY_SLACK_SEVERITY"),
+		})
+	}
+
+	if host := os.Getenv("NOTIFY_SMTP_HOST"); host != "" {
+		port := 0
+		if v := strings.TrimSpace(os.Getenv("NOTIFY_SMTP_PORT")); v != "" {
+			p, err := strconv.Atoi(v)
+			if err != nil {
+				errs.AddSource("notify.targets.smtp_port", "env NOTIFY_SMTP_PORT", fmt.Sprintf("invalid integer %q", v))
+			}
+			port = p
+		}
+
+		cfg.Notify.Targets = append(cfg.Notify.Targets, NotifyTarget{
+			Type:        "smtp",
+			SMTPHost:    host,
+			SMTPPort:    port,
+			Username:    os.Getenv("NOTIFY_SMTP_USERNAME"),
+			Password:    os.Getenv("NOTIFY_SMTP_PASSWORD"),
+			From:        os.Getenv("NOTIFY_SMTP_FROM"),
+			To:          splitList(os.Getenv("NOTIFY_SMTP_TO")),
+			Blocks:      splitList(os.Getenv("NOTIFY_SMTP_BLOCKS")),
+			MinSeverity: os.Getenv("NOTIFY_SMTP_SEVERITY"),
+		})
+	}
+}
+
+func splitList(v string) []string {
+	var out []string
+	for _, p := range strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == '|' }) {
+		if p = strings.TrimSpace(p); p != "" {
+			out = append(out, p)
+		}
+	}
+	return out
+}
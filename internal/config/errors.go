@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FileError is returned when the config file cannot be read or parsed.
+type FileError struct {
+	Path string
+	Err  error
+}
+
+func (e *FileError) Error() string {
+	return fmt.Sprintf("config file %s: %v", e.Path, e.Err)
+}
+
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// FieldError describes one invalid setting. Source tells where the bad
+// value came from when it was not the config file (e.g. "env WORKER_COUNT").
+type FieldError struct {
+	Field   string
+	Source  string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	if e.Source != "" {
+		return fmt.Sprintf("%s (%s): %s", e.Field, e.Source, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// ValidationError collects every invalid setting so they can be fixed in
+// one go instead of one per run.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Add(field, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+func (e *ValidationError) AddSource(field, source, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Source: source, Message: message})
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		lines[i] = "  - " + f.Error()
+	}
+	return fmt.Sprintf("%d invalid setting(s):\n%s", len(e.Fields), strings.Join(lines, "\n"))
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AllProfiles selects every profile defined in the config file.
+const AllProfiles = "ALL"
+
+// Set is everything one config file defines: the shared base settings and
+// a resolved Config per profile. Profiles is empty when the file has none.
+type Set struct {
+	Base     *Config
+	Profiles map[string]*Config
+}
+
+// Names returns the profile names in a stable order.
+func (s *Set) Names() []string {
+	names := make([]string, 0, len(s.Profiles))
+	for name := range s.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Select returns the configs to run for the -profile flag value. Without
+// profiles only the base config can run; with profiles one must be chosen.
+func (s *Set) Select(profile string) ([]*Config, error) {
+	profile = strings.TrimSpace(profile)
+
+	if len(s.Profiles) == 0 {
+		if profile != "" {
+			return nil, fmt.Errorf("profile %q requested but the config defines no profiles", profile)
+		}
+		return []*Config{s.Base}, nil
+	}
+
+	switch {
+	case profile == "":
+		return nil, fmt.Errorf("config defines profiles (%s), use -profile=NAME or -profile=%s", strings.Join(s.Names(), ", "), AllProfiles)
+	case strings.EqualFold(profile, AllProfiles):
+		var out []*Config
+		for _, name := range s.Names() {
+			out = append(out, s.Profiles[name])
+		}
+		return out, nil
+	}
+
+	cfg, ok := s.Profiles[profile]
+	if !ok {
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", profile, strings.Join(s.Names(), ", "))
+	}
+	return []*Config{cfg}, nil
+}
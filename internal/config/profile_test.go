@@ -0,0 +1,157 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const profiles = minimal + `
+kodecabang: BASE
+process_dir: ./transfer
+log_path: ./logs
+commit_modes: {SDEAL: batched}
+profiles:
+  jakarta:
+    file_path: ./in/jakarta
+    kodecabang: JKT
+    commit_modes: {MPRICE: batched}
+  surabaya:
+    file_path: ./in/surabaya
+    log_path: ./logs/sby
+`
+
+func TestProfiles(t *testing.T) {
+	set, err := LoadFile(writeConfig(t, profiles))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(set.Names(), ","); got != "jakarta,surabaya" {
+		t.Fatalf("profiles %s", got)
+	}
+	jkt, sby := set.Profiles["jakarta"], set.Profiles["surabaya"]
+
+	if jkt.Profile != "jakarta" || jkt.Kodecabang != "JKT" || sby.Kodecabang != "BASE" {
+		t.Errorf("kodecabang jakarta %s, surabaya %s", jkt.Kodecabang, sby.Kodecabang)
+	}
+
+	// Directories a profile leaves alone are split per profile.
+	tests := []struct {
+		name, got, want string
+	}{
+		{"jakarta process_dir", jkt.FileDir, filepath.Join("./transfer", "jakarta")},
+		{"jakarta log_path", jkt.LogsDir, filepath.Join("./logs", "jakarta")},
+		{"surabaya log_path", sby.LogsDir, "./logs/sby"},
+		{"surabaya file_path", sby.FilePath, "./in/surabaya"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s = %s, want %s", tt.name, tt.got, tt.want)
+		}
+	}
+
+	// A profile map adds to a copy of the base one.
+	if len(jkt.CommitModes) != 2 || jkt.CommitModes["MPRICE"] != CommitBatched || jkt.CommitModes["SDEAL"] != CommitBatched {
+		t.Errorf("jakarta commit_modes %v", jkt.CommitModes)
+	}
+	if len(set.Base.CommitModes) != 1 || set.Base.CommitModes["SDEAL"] != CommitBatched {
+		t.Errorf("base commit_modes %v", set.Base.CommitModes)
+	}
+	if sby.CommitModes["SDEAL"] != CommitBatched {
+		t.Errorf("surabaya commit_modes %v, want the base", sby.CommitModes)
+	}
+}
+
+func TestProfileOverridesEnv(t *testing.T) {
+	t.Setenv("KODECABANG", "ENV")
+	t.Setenv("WORKER_COUNT", "2")
+
+	set, err := LoadFile(writeConfig(t, profiles+"    worker_count: 6\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	jkt, sby := set.Profiles["jakarta"], set.Profiles["surabaya"]
+
+	// env wins over the base, a profile's own value wins over env.
+	if set.Base.Kodecabang != "ENV" || jkt.Kodecabang != "JKT" || sby.Kodecabang != "ENV" {
+		t.Errorf("kodecabang base %s, jakarta %s, surabaya %s", set.Base.Kodecabang, jkt.Kodecabang, sby.Kodecabang)
+	}
+	if jkt.Worker != 2 || sby.Worker != 6 {
+		t.Errorf("worker_count jakarta %d, surabaya %d, want 2 and 6", jkt.Worker, sby.Worker)
+	}
+}
+
+func TestProfileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"invalid value", profiles + "    worker_count: 0\n", "profiles.surabaya.worker_count: must be at least 1, got 0"},
+		{"unknown key", profiles + "    workers: 2\n", "profile surabaya: yaml: unmarshal errors"},
+		{"reserved name", minimal + "profiles:\n  all: {kodecabang: X}\n", "profiles.all: name is reserved for -profile=ALL"},
+		{"shared inbox", minimal + "profiles:\n  a: {file_path: ./in/x}\n  b: {file_path: ./in/x}\n", "profiles.b.file_path: is shared with profile a"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFile(writeConfig(t, tt.body))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("err = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSelect(t *testing.T) {
+	withProfiles := &Set{Base: valid(), Profiles: map[string]*Config{
+		"jakarta":  {Profile: "jakarta"},
+		"surabaya": {Profile: "surabaya"},
+	}}
+	without := &Set{Base: valid(), Profiles: map[string]*Config{}}
+
+	tests := []struct {
+		name    string
+		set     *Set
+		profile string
+		want    []string
+		wantErr string
+	}{
+		{"no profiles", without, "", []string{""}, ""},
+		{"profile without profiles", without, "jakarta", nil, "config defines no profiles"},
+		{"profile required", withProfiles, "", nil, "use -profile=NAME or -profile=ALL"},
+		{"one profile", withProfiles, " surabaya ", []string{"surabaya"}, ""},
+		{"all profiles", withProfiles, "all", []string{"jakarta", "surabaya"}, ""},
+		{"unknown profile", withProfiles, "bandung", nil, `unknown profile "bandung" (available: jakarta, surabaya)`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfgs, err := tt.set.Select(tt.profile)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, c := range cfgs {
+				got = append(got, c.Profile)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Select(%q) = %v, want %v", tt.profile, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfileFileError(t *testing.T) {
+	_, err := LoadFile(writeConfig(t, profiles+"    worker_count: many\n"))
+
+	var fe *FileError
+	if !errors.As(err, &fe) {
+		t.Errorf("err = %v, want a FileError", err)
+	}
+}
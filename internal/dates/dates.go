@@ -0,0 +1,48 @@
+// Package dates reads the dates of import files in the business time zone
+// and stamps processing times in it.
+package dates
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var zone = time.Local
+
+// SetZone makes loc the business time zone. It is set per profile before
+// any file is parsed.
+func SetZone(loc *time.Location) {
+	zone = loc
+}
+
+// Now is the current time in the business time zone, for CORE_PROCESSDATE
+// and the other processing timestamps.
+func Now() time.Time {
+	return time.Now().In(zone)
+}
+
+// Field is a date or date-time field of an import file, declared by the Go
+// layout it is written in.
+type Field struct {
+	Layout string
+}
+
+// YMD is a date written as YYYYMMDD, e.g. 20260131.
+var YMD = Field{Layout: "20060102"}
+
+// Parse reads raw in the business time zone. An empty value or one of
+// only zeros ("00000000") is NULL.
+func (f Field) Parse(raw string) (sql.NullTime, error) {
+	raw = strings.TrimSpace(raw)
+	if strings.Trim(raw, "0") == "" {
+		return sql.NullTime{}, nil
+	}
+
+	t, err := time.ParseInLocation(f.Layout, raw, zone)
+	if err != nil {
+		return sql.NullTime{}, fmt.Errorf("invalid date %q, want layout %s", raw, f.Layout)
+	}
+	return sql.NullTime{Time: t, Valid: true}, nil
+}
@@ -0,0 +1,65 @@
+package dates
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFieldParse(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	SetZone(jakarta)
+	t.Cleanup(func() { SetZone(time.Local) })
+
+	tests := []struct {
+		name  string
+		field Field
+		raw   string
+		want  time.Time // zero is NULL
+		err   string
+	}{
+		{"date", YMD, "20260131", time.Date(2026, 1, 31, 0, 0, 0, 0, jakarta), ""},
+		{"spaces", YMD, " 20260131 ", time.Date(2026, 1, 31, 0, 0, 0, 0, jakarta), ""},
+		{"empty", YMD, "", time.Time{}, ""},
+		{"blank", YMD, "   ", time.Time{}, ""},
+		{"zeros", YMD, "00000000", time.Time{}, ""},
+		{"short zeros", YMD, "0", time.Time{}, ""},
+		{"no such day", YMD, "20260230", time.Time{}, `invalid date "20260230", want layout 20060102`},
+		{"wrong layout", YMD, "2026-01-31", time.Time{}, `invalid date "2026-01-31", want layout 20060102`},
+		{"date-time layout", Field{Layout: "20060102150405"}, "20260131235959", time.Date(2026, 1, 31, 23, 59, 59, 0, jakarta), ""},
+		{"date-time without time", Field{Layout: "20060102150405"}, "20260131", time.Time{}, `want layout 20060102150405`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.field.Parse(tt.raw)
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Fatalf("err = %v, want %q", err, tt.err)
+				}
+				if got.Valid {
+					t.Errorf("got %v with the error, want NULL", got.Time)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Valid != !tt.want.IsZero() || !got.Time.Equal(tt.want) {
+				t.Fatalf("got %v (valid %v), want %v", got.Time, got.Valid, tt.want)
+			}
+			if got.Valid && got.Time.Location() != jakarta {
+				t.Errorf("zone = %v, want %v", got.Time.Location(), jakarta)
+			}
+		})
+	}
+}
+
+func TestNowIsInZone(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	SetZone(jakarta)
+	t.Cleanup(func() { SetZone(time.Local) })
+
+	if loc := Now().Location(); loc != jakarta {
+		t.Errorf("Now() zone = %v, want %v", loc, jakarta)
+	}
+}
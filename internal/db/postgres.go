@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"go-import-file/internal/config"
+	"net/url"
+	"time"
+
+	_ "github.com/lib/pq"
+)
+
+func NewPostgres(cfg *config.Config) (*sql.DB, error) {
+	sslMode := cfg.DB.SSLMode
+	if sslMode == "" {
+		sslMode = "disable"
+	}
+
+	dsn := fmt.Sprintf(
+		"postgres://%s:%s@%s:%s/%s?sslmode=%s&connect_timeout=%d",
+		url.QueryEscape(cfg.DB.User), url.QueryEscape(cfg.DB.Password),
+		cfg.DB.Host, cfg.DB.Port, cfg.DB.Name, sslMode, cfg.TimeoutSeconds,
+	)
+
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(cfg.Worker + 2)
+	db.SetMaxIdleConns(cfg.Worker)
+
+	return db, ping(cfg, db)
+}
+
+// ping checks the connection within timeout_seconds. The SQL Server
+// "connection timeout" would also cut off every long-running query.
+func ping(cfg *config.Config, db *sql.DB) error {
+	ctx := context.Background()
+	if cfg.TimeoutSeconds > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(cfg.TimeoutSeconds)*time.Second)
+		defer cancel()
+	}
+	return db.PingContext(ctx)
+}
+
+// Open connects to the database selected by cfg.DB.Driver.
+func Open(cfg *config.Config) (*sql.DB, error) {
+	switch cfg.DB.Driver {
+	case "postgres":
+		return NewPostgres(cfg)
+	case "sqlite":
+		return NewSQLite(cfg)
+	default:
+		return NewSQLServer(cfg)
+	}
+}
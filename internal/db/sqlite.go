@@ -0,0 +1,23 @@
+package db
+
+import (
+	"database/sql"
+	"go-import-file/internal/config"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// NewSQLite opens cfg.DB.Name as a SQLite file (":memory:" works for a
+// single run). The driver needs cgo.
+func NewSQLite(cfg *config.Config) (*sql.DB, error) {
+	db, err := sql.Open("sqlite3", cfg.DB.Name+"?_busy_timeout=5000")
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite has a single writer; one connection also keeps ":memory:"
+	// and temp tables on the same database.
+	db.SetMaxOpenConns(1)
+
+	return db, db.Ping()
+}
@@ -4,27 +4,45 @@ import (
 	"database/sql"
 	"fmt"
 	"go-import-file/internal/config"
-	"time"
 
 	_ "github.com/microsoft/go-mssqldb"
 )
 
-func NewSQLServer(host, port, user, pass, dbname string) (*sql.DB, error) {
-	cfg := config.Load()
-
-	dsn := fmt.Sprintf(
-		"sqlserver://%s:%s@%s:%s?database=%s",
-		user, pass, host, port, dbname,
-	)
-
-	db, err := sql.Open("sqlserver", dsn)
+func NewSQLServer(cfg *config.Config) (*sql.DB, error) {
+	db, err := sql.Open("sqlserver", sqlServerDSN(cfg))
 	if err != nil {
 		return nil, err
 	}
 
 	db.SetMaxOpenConns(cfg.Worker + 2)
 	db.SetMaxIdleConns(cfg.Worker)
-	db.SetConnMaxLifetime(time.Duration(cfg.TimeoutSeconds) * time.Minute)
 
-	return db, db.Ping()
+	return db, ping(cfg, db)
+}
+
+// NewSQLServerSession opens a pool of one connection next to the work
+// pool, for a session that has to live as long as the run (the block
+// lock) without taking a connection from the writers' Worker+2 budget.
+func NewSQLServerSession(cfg *config.Config) (*sql.DB, error) {
+	db, err := sql.Open("sqlserver", sqlServerDSN(cfg))
+	if err != nil {
+		return nil, err
+	}
+
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+
+	if err := ping(cfg, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func sqlServerDSN(cfg *config.Config) string {
+	return fmt.Sprintf(
+		"sqlserver://%s:%s@%s:%s?database=%s&dial+timeout=%d",
+		cfg.DB.User, cfg.DB.Password, cfg.DB.Host, cfg.DB.Port, cfg.DB.Name,
+		cfg.TimeoutSeconds,
+	)
 }
@@ -0,0 +1,128 @@
+// Package decimals reads the amounts, prices and quantities of import files
+// as exact decimals, declared by the DECIMAL column they are stored in.
+package decimals
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Format is how a file writes its numbers.
+type Format int
+
+const (
+	// Number is 12.5, 0,01, 35,000 or 1.234,56: a comma followed by three
+	// digits groups thousands, any other comma is the decimal point.
+	Number Format = iota
+
+	// Accounting is SAP's 1.234,56-: a dot followed by three digits groups
+	// thousands, a comma is the decimal point and the minus may trail.
+	Accounting
+
+	// Grouped is 1,234.56: commas group thousands.
+	Grouped
+)
+
+var (
+	// ErrNotNumber means the value is not a number in its format.
+	ErrNotNumber = errors.New("not a number")
+
+	// ErrInexact means the value has more decimals than the column keeps.
+	ErrInexact = errors.New("too many decimals")
+
+	// ErrRange means the value is too large for the column.
+	ErrRange = errors.New("out of range")
+)
+
+// Field is a DECIMAL(Precision, Scale) column.
+type Field struct {
+	Precision int32
+	Scale     int32
+}
+
+// Money holds prices, amounts and quantities, as DECIMAL(19,4).
+var Money = Field{Precision: 19, Scale: 4}
+
+// Parse reads raw, written in format, exactly. An empty value is 0. A value
+// with more decimals than Scale or too large for the column is an error,
+// it is never rounded.
+func (f Field) Parse(raw string, format Format) (decimal.Decimal, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return decimal.Zero, nil
+	}
+
+	d, err := decimal.NewFromString(normalize(raw, format))
+	if err != nil {
+		return decimal.Zero, fmt.Errorf("%q: %w", raw, ErrNotNumber)
+	}
+	if err := f.Check(d); err != nil {
+		return decimal.Zero, fmt.Errorf("%q: %w", raw, err)
+	}
+	return d, nil
+}
+
+// Check reports whether d fits the column without rounding.
+func (f Field) Check(d decimal.Decimal) error {
+	if !d.Equal(d.Truncate(f.Scale)) {
+		return fmt.Errorf("%w for DECIMAL(%d,%d)", ErrInexact, f.Precision, f.Scale)
+	}
+	if d.Abs().Cmp(decimal.New(1, f.Precision-f.Scale)) >= 0 {
+		return fmt.Errorf("%w for DECIMAL(%d,%d)", ErrRange, f.Precision, f.Scale)
+	}
+	return nil
+}
+
+// normalize rewrites s as -1234.56 for decimal.NewFromString.
+func normalize(s string, format Format) string {
+	negative := false
+	if format == Accounting && strings.HasSuffix(s, "-") {
+		negative = true
+		s = strings.TrimSuffix(s, "-")
+	}
+	if strings.HasPrefix(s, "-") {
+		negative = !negative
+		s = strings.TrimPrefix(s, "-")
+	}
+
+	hasDot := strings.Contains(s, ".")
+	hasComma := strings.Contains(s, ",")
+
+	switch format {
+	case Grouped:
+		s = strings.ReplaceAll(s, ",", "")
+	case Accounting:
+		switch {
+		case hasDot && hasComma:
+			s = strings.ReplaceAll(s, ".", "")
+			s = strings.ReplaceAll(s, ",", ".")
+		case hasDot && thousands(s, "."):
+			s = strings.ReplaceAll(s, ".", "")
+		case hasComma:
+			s = strings.ReplaceAll(s, ",", ".")
+		}
+	default:
+		switch {
+		case hasDot && hasComma:
+			s = strings.ReplaceAll(s, ".", "")
+			s = strings.ReplaceAll(s, ",", ".")
+		case hasComma && thousands(s, ","):
+			s = strings.ReplaceAll(s, ",", "")
+		case hasComma:
+			s = strings.ReplaceAll(s, ",", ".")
+		}
+	}
+
+	if negative {
+		return "-" + s
+	}
+	return s
+}
+
+// thousands reports whether the last sep in s is followed by three digits.
+func thousands(s, sep string) bool {
+	return len(s)-strings.LastIndex(s, sep)-1 == 3
+}
@@ -0,0 +1,78 @@
+package decimals
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		format Format
+		want   string
+		err    error
+	}{
+		// The formats the float parsers ParseNumber and ParseAccountingFloat
+		// read before the columns became DECIMAL.
+		{"number with decimal dot", "12.5", Number, "12.5", nil},
+		{"number with decimal comma", "0,01", Number, "0.01", nil},
+		{"number with thousands comma", "35,000", Number, "35000", nil},
+		{"number with both separators", "1.234,56", Number, "1234.56", nil},
+		{"number with leading minus", "-12,5", Number, "-12.5", nil},
+		{"accounting with trailing minus", "1.234,56-", Accounting, "-1234.56", nil},
+		{"accounting with thousands dot", "12.345", Accounting, "12345", nil},
+		{"accounting with decimal dot", "12.5", Accounting, "12.5", nil},
+		{"accounting with decimal comma", "12,5", Accounting, "12.5", nil},
+		{"accounting with leading minus", "-100", Accounting, "-100", nil},
+		{"accounting with several groups", "1.234.567,89-", Accounting, "-1234567.89", nil},
+		{"grouped", "1,234.56", Grouped, "1234.56", nil},
+		{"grouped negative", "-1,234,567", Grouped, "-1234567", nil},
+		{"empty is zero", "  ", Number, "0", nil},
+		{"surrounding spaces", " 7,25 ", Number, "7.25", nil},
+
+		// Values a float would have rounded or read as 0 are errors.
+		{"four decimals are kept", "0.0001", Number, "0.0001", nil},
+		{"largest value", "999999999999999.9999", Number, "999999999999999.9999", nil},
+		{"too many decimals", "1.23456", Number, "0", ErrInexact},
+		{"too many decimals after the comma", "0,00001", Accounting, "0", ErrInexact},
+		{"too large", "1000000000000000", Number, "0", ErrRange},
+		{"not a number", "abc", Number, "0", ErrNotNumber},
+		{"two decimal points", "1.2.3", Grouped, "0", ErrNotNumber},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Money.Parse(tt.raw, tt.format)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Parse(%q) err = %v, want %v", tt.raw, err, tt.err)
+			}
+			if want := decimal.RequireFromString(tt.want); !got.Equal(want) {
+				t.Errorf("Parse(%q) = %s, want %s", tt.raw, got, want)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name  string
+		field Field
+		value string
+		err   error
+	}{
+		{"fits", Money, "123.4500", nil},
+		{"trailing zeros are not decimals", Field{Precision: 5, Scale: 2}, "123.4000", nil},
+		{"too many decimals", Field{Precision: 5, Scale: 2}, "1.234", ErrInexact},
+		{"too large", Field{Precision: 5, Scale: 2}, "1000", ErrRange},
+		{"too large negative", Field{Precision: 5, Scale: 2}, "-1000", ErrRange},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.field.Check(decimal.RequireFromString(tt.value)); !errors.Is(err, tt.err) {
+				t.Errorf("Check(%s) = %v, want %v", tt.value, err, tt.err)
+			}
+		})
+	}
+}
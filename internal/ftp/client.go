@@ -2,6 +2,7 @@
 package ftp
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -39,7 +40,7 @@ func NewClient(cfg config.FTPConfig) (*Client, error) {
 	}, nil
 }
 
-func (c *Client) DownloadFiles(localFolder string) ([]string, error) {
+func (c *Client) DownloadFiles(ctx context.Context, localFolder string) ([]string, error) {
 	if err := os.MkdirAll(localFolder, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create local folder: %w", err)
 	}
@@ -57,9 +58,14 @@ func (c *Client) DownloadFiles(localFolder string) ([]string, error) {
 
 	var downloadedFiles []string
 
-	FilenamePattern := os.Getenv("FTP_FILENAME_PATTERN")
-	namePattern := strings.Split(FilenamePattern, "|")
+	namePattern := strings.Split(c.config.FilenamePattern, "|")
 	for _, entry := range entries {
+		// A file is downloaded and removed remotely as a whole; after a
+		// shutdown request no further file is started.
+		if err := ctx.Err(); err != nil {
+			return downloadedFiles, err
+		}
+
 		if entry.Type != ftp.EntryTypeFile {
 			continue
 		}
@@ -7,53 +7,47 @@ import (
 	"time"
 )
 
+// RunMkplPriceFinalize merges the prices staged by processID into
+// fkpl_price inside tx, which the caller commits.
 func RunMkplPriceFinalize(
 	ctx context.Context,
-	dbConn *sql.DB,
+	tx *sql.Tx,
 	processID string,
-) error {
+	kodecabang string,
+) (inserted, updated int64, err error) {
 
-	start := time.Now()
 	log.Println("Import MKPL PRICE FINAL started")
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
-	tx, err := dbConn.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
 	query := `
 	SET NOCOUNT ON;
 
 	DECLARE @SummaryOfChanges TABLE (
-		ACTION VARCHAR(10)
+		ACTION VARCHAR(10),
+		KODECABANG NVARCHAR(50),
+		CUSTNO NVARCHAR(50),
+		PCODE NVARCHAR(50),
+		CORE_FILENAME NVARCHAR(255),
+		OLD1 DECIMAL(19,4), OLD2 DECIMAL(19,4), OLD3 DECIMAL(19,4), OLD4 DECIMAL(19,4), OLD5 DECIMAL(19,4),
+		NEW1 DECIMAL(19,4), NEW2 DECIMAL(19,4), NEW3 DECIMAL(19,4), NEW4 DECIMAL(19,4), NEW5 DECIMAL(19,4)
 	);
 
 	WITH CTE_MPD AS (
 		SELECT
-			ISNULL(fm.UNIT1, '') AS UOM1,
-			ISNULL(fm.UNIT2, '') AS UOM2,
-			ISNULL(fm.UNIT3, '') AS UOM3,
-			ISNULL(fm.UNIT4, '') AS UOM4,
-			ISNULL(fm.UNIT5, '') AS UOM5,
-			ISNULL(fm.CONVUNIT2, 1) AS CON2,
-			ISNULL(fm.CONVUNIT3, 1) AS CON3,
-			ISNULL(fm.CONVUNIT4, 1) AS CON4,
-			ISNULL(fm.CONVUNIT5, 1) AS CON5,
 			mpd.PRICE_UOM AS PRICE_UOM,
 			mpd.PRICE_VALUE AS PRICE_VAL,
-			'' AS KODECABANG,
+			@KODECABANG AS KODECABANG,
 			mpd.CUST_CODE AS CUSTNO,
 			mpd.PCODE AS PCODE,
 			'' AS MG3,
-			CASE mpd.PRICE_UOM WHEN fm.UNIT5 THEN mpd.PRICE_VALUE  ELSE 0 END AS SELLPRICE1,
-			CASE mpd.PRICE_UOM WHEN fm.UNIT4 THEN mpd.PRICE_VALUE  WHEN fm.UNIT5 THEN mpd.PRICE_VALUE  *  fm.CONVUNIT2 ELSE 0 END AS SELLPRICE2,
-			CASE mpd.PRICE_UOM WHEN fm.UNIT3 THEN mpd.PRICE_VALUE WHEN fm.UNIT4 THEN mpd.PRICE_VALUE *  fm.CONVUNIT3 WHEN fm.UNIT5 THEN mpd.PRICE_VALUE *  fm.CONVUNIT3 *  fm.CONVUNIT2 ELSE 0 END AS SELLPRICE3,
-			CASE mpd.PRICE_UOM WHEN fm.UNIT2 THEN mpd.PRICE_VALUE WHEN fm.UNIT3 THEN mpd.PRICE_VALUE * fm.CONVUNIT4 WHEN fm.UNIT4 THEN mpd.PRICE_VALUE * fm.CONVUNIT4 *  fm.CONVUNIT3 WHEN fm.UNIT5 THEN mpd.PRICE_VALUE * fm.CONVUNIT4 *  fm.CONVUNIT3 *  fm.CONVUNIT2 ELSE 0 END AS SELLPRICE4,
-			CASE mpd.PRICE_UOM WHEN fm.UNIT1 THEN mpd.PRICE_VALUE WHEN fm.UNIT2 THEN mpd.PRICE_VALUE * fm.CONVUNIT5 WHEN fm.UNIT3 THEN mpd.PRICE_VALUE * fm.CONVUNIT5 * fm.CONVUNIT4 WHEN fm.UNIT4 THEN mpd.PRICE_VALUE * fm.CONVUNIT5 * fm.CONVUNIT4 *  fm.CONVUNIT3 WHEN fm.UNIT5 THEN mpd.PRICE_VALUE * fm.CONVUNIT5 * fm.CONVUNIT4 *  fm.CONVUNIT3 *  fm.CONVUNIT2 ELSE 0 END AS SELLPRICE5,
+			-- converted from PRICE_UOM along the fmaster unit ladder by the parser
+			mpd.SELLPRICE1,
+			mpd.SELLPRICE2,
+			mpd.SELLPRICE3,
+			mpd.SELLPRICE4,
+			mpd.SELLPRICE5,
 			0 AS BUYPRICE1,
 			0 AS BUYPRICE2,
 			0 AS BUYPRICE3,
@@ -62,7 +56,8 @@ func RunMkplPriceFinalize(
 			mpd.CDATE AS CREATED_DATE,
 			mpd.UNIQ_ID AS CREATED_BY,
 			mpd.MDATE AS UPDATED_DATE,
-			mpd.UNIQ_ID AS UPDATED_BY
+			mpd.UNIQ_ID AS UPDATED_BY,
+			mpd.CORE_FILENAME
 		FROM
 			dbo.mkplprice_dummy AS mpd
 		INNER JOIN dbo.fmaster AS fm ON mpd.PCODE = fm.PCODE
@@ -93,29 +88,37 @@ func RunMkplPriceFinalize(
 		SOURCE.KODECABANG, SOURCE.CUSTNO, SOURCE.PCODE, SOURCE.SELLPRICE1, SOURCE.SELLPRICE2, SOURCE.SELLPRICE3, SOURCE.SELLPRICE4, SOURCE.SELLPRICE5,
 		SOURCE.CREATED_DATE, SOURCE.CREATED_BY, SOURCE.UPDATED_DATE, SOURCE.UPDATED_BY
 	)
-	OUTPUT $action INTO @SummaryOfChanges;
+	OUTPUT
+		$action, inserted.KODECABANG, inserted.CUSTNO, inserted.PCODE, SOURCE.CORE_FILENAME,
+		deleted.SELLPRICE1, deleted.SELLPRICE2, deleted.SELLPRICE3, deleted.SELLPRICE4, deleted.SELLPRICE5,
+		inserted.SELLPRICE1, inserted.SELLPRICE2, inserted.SELLPRICE3, inserted.SELLPRICE4, inserted.SELLPRICE5
+	INTO @SummaryOfChanges;
+
+	-- Audit: one row per changed price column of an updated price
+	INSERT INTO dbo.price_audit (
+		process_id, price_table, KODECABANG, GHARGA, CUSTNO, PCODE,
+		price_column, old_value, new_value, source_file, changed_at
+	)
+	SELECT
+		@UNIQ_ID, 'fkpl_price', c.KODECABANG, NULL, c.CUSTNO, c.PCODE,
+		v.price_column, v.old_value, v.new_value, c.CORE_FILENAME, SYSDATETIME()
+	FROM @SummaryOfChanges c
+	CROSS APPLY (VALUES
+		('SELLPRICE1', c.OLD1, c.NEW1),
+		('SELLPRICE2', c.OLD2, c.NEW2),
+		('SELLPRICE3', c.OLD3, c.NEW3),
+		('SELLPRICE4', c.OLD4, c.NEW4),
+		('SELLPRICE5', c.OLD5, c.NEW5)
+	) v (price_column, old_value, new_value)
+	WHERE c.ACTION = 'UPDATE'
+		AND EXISTS (SELECT v.old_value EXCEPT SELECT v.new_value);
 
 	SELECT
-		SUM(CASE WHEN ACTION = 'INSERT' THEN 1 ELSE 0 END),
-		SUM(CASE WHEN ACTION = 'UPDATE' THEN 1 ELSE 0 END)
+		ISNULL(SUM(CASE WHEN ACTION = 'INSERT' THEN 1 ELSE 0 END), 0),
+		ISNULL(SUM(CASE WHEN ACTION = 'UPDATE' THEN 1 ELSE 0 END), 0)
 	FROM @SummaryOfChanges;
 	`
 
-	var inserted, updated int
-	if err := tx.QueryRowContext(ctx, query, sql.Named("UNIQ_ID", processID)).Scan(&inserted, &updated); err != nil {
-		return err
-	}
-
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-
-	log.Printf(
-		"PRICE FINAL completed | inserted=%d updated=%d duration=%s",
-		inserted,
-		updated,
-		time.Since(start),
-	)
-
-	return nil
+	err = tx.QueryRowContext(ctx, query, sql.Named("UNIQ_ID", processID), sql.Named("KODECABANG", kodecabang)).Scan(&inserted, &updated)
+	return inserted, updated, err
 }
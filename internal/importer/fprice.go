@@ -7,53 +7,47 @@ import (
 	"time"
 )
 
+// RunMPriceFinalize merges the prices staged by processID into fprice
+// inside tx, which the caller commits.
 func RunMPriceFinalize(
 	ctx context.Context,
-	dbConn *sql.DB,
+	tx *sql.Tx,
 	processID string,
-) error {
+	kodecabang string,
+) (inserted, updated int64, err error) {
 
-	start := time.Now()
 	log.Println("Import PRICE FINAL started")
 
 	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
 	defer cancel()
 
-	tx, err := dbConn.BeginTx(ctx, nil)
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
 	query := `
 	SET NOCOUNT ON;
 
 	DECLARE @SummaryOfChanges TABLE (
-		ACTION VARCHAR(10)
+		ACTION VARCHAR(10),
+		KODECABANG NVARCHAR(50),
+		GHARGA NVARCHAR(50),
+		PCODE NVARCHAR(50),
+		CORE_FILENAME NVARCHAR(255),
+		OLD1 DECIMAL(19,4), OLD2 DECIMAL(19,4), OLD3 DECIMAL(19,4), OLD4 DECIMAL(19,4), OLD5 DECIMAL(19,4),
+		NEW1 DECIMAL(19,4), NEW2 DECIMAL(19,4), NEW3 DECIMAL(19,4), NEW4 DECIMAL(19,4), NEW5 DECIMAL(19,4)
 	);
 
 	WITH CTE_MPD AS (
 		SELECT
-			ISNULL(fm.UNIT1, '') AS UOM1,
-			ISNULL(fm.UNIT2, '') AS UOM2,
-			ISNULL(fm.UNIT3, '') AS UOM3,
-			ISNULL(fm.UNIT4, '') AS UOM4,
-			ISNULL(fm.UNIT5, '') AS UOM5,
-			ISNULL(fm.CONVUNIT2, 1) AS CON2,
-			ISNULL(fm.CONVUNIT3, 1) AS CON3,
-			ISNULL(fm.CONVUNIT4, 1) AS CON4,
-			ISNULL(fm.CONVUNIT5, 1) AS CON5,
 			mpd.PRICE_UOM,
 			mpd.PRICE_VALUE,
-			'' AS KODECABANG,
+			@KODECABANG AS KODECABANG,
 			mpd.PRICE_CODE AS GHARGA,
 			mpd.PCODE,
 			'' AS MG3,
-			CASE mpd.PRICE_UOM WHEN fm.UNIT5 THEN mpd.PRICE_VALUE ELSE 0 END AS SELPRICE1,
-			CASE mpd.PRICE_UOM WHEN fm.UNIT4 THEN mpd.PRICE_VALUE WHEN fm.UNIT5 THEN mpd.PRICE_VALUE * fm.CONVUNIT2 ELSE 0 END AS SELPRICE2,
-			CASE mpd.PRICE_UOM WHEN fm.UNIT3 THEN mpd.PRICE_VALUE WHEN fm.UNIT4 THEN mpd.PRICE_VALUE * fm.CONVUNIT3 WHEN fm.UNIT5 THEN mpd.PRICE_VALUE * fm.CONVUNIT3 * fm.CONVUNIT2 ELSE 0 END AS SELPRICE3,
-			CASE mpd.PRICE_UOM WHEN fm.UNIT2 THEN mpd.PRICE_VALUE WHEN fm.UNIT3 THEN mpd.PRICE_VALUE * fm.CONVUNIT4 WHEN fm.UNIT4 THEN mpd.PRICE_VALUE * fm.CONVUNIT4 * fm.CONVUNIT3 WHEN fm.UNIT5 THEN mpd.PRICE_VALUE * fm.CONVUNIT4 * fm.CONVUNIT3 * fm.CONVUNIT2 ELSE 0 END AS SELPRICE4,
-			CASE mpd.PRICE_UOM WHEN fm.UNIT1 THEN mpd.PRICE_VALUE WHEN fm.UNIT2 THEN mpd.PRICE_VALUE * fm.CONVUNIT5 WHEN fm.UNIT3 THEN mpd.PRICE_VALUE * fm.CONVUNIT5 * fm.CONVUNIT4 WHEN fm.UNIT4 THEN mpd.PRICE_VALUE * fm.CONVUNIT5 * fm.CONVUNIT4 * fm.CONVUNIT3 WHEN fm.UNIT5 THEN mpd.PRICE_VALUE * fm.CONVUNIT5 * fm.CONVUNIT4 * fm.CONVUNIT3 * fm.CONVUNIT2 ELSE 0 END AS SELPRICE5,
+			-- converted from PRICE_UOM along the fmaster unit ladder by the parser
+			mpd.SELPRICE1,
+			mpd.SELPRICE2,
+			mpd.SELPRICE3,
+			mpd.SELPRICE4,
+			mpd.SELPRICE5,
 			0 AS BUYPRICE1,
 			0 AS BUYPRICE2,
 			0 AS BUYPRICE3,
@@ -61,7 +55,8 @@ func RunMPriceFinalize(
 			0 AS BUYPRICE5,
 			mpd.CDATE,
 			mpd.UNIQ_ID,
-			mpd.MDATE
+			mpd.MDATE,
+			mpd.CORE_FILENAME
 		FROM dbo.m_price_dummy mpd
 		INNER JOIN dbo.fmaster fm ON mpd.PCODE = fm.PCODE
 		WHERE mpd.UNIQ_ID = @UNIQ_ID
@@ -100,29 +95,37 @@ func RunMPriceFinalize(
 		0,0,0,0,0,
 		SOURCE.CDATE, SOURCE.UNIQ_ID, SOURCE.MDATE, SOURCE.UNIQ_ID
 	)
-	OUTPUT $action INTO @SummaryOfChanges;
+	OUTPUT
+		$action, inserted.KODECABANG, inserted.GHARGA, inserted.PCODE, SOURCE.CORE_FILENAME,
+		deleted.SELPRICE1, deleted.SELPRICE2, deleted.SELPRICE3, deleted.SELPRICE4, deleted.SELPRICE5,
+		inserted.SELPRICE1, inserted.SELPRICE2, inserted.SELPRICE3, inserted.SELPRICE4, inserted.SELPRICE5
+	INTO @SummaryOfChanges;
+
+	-- Audit: one row per changed price column of an updated price
+	INSERT INTO dbo.price_audit (
+		process_id, price_table, KODECABANG, GHARGA, CUSTNO, PCODE,
+		price_column, old_value, new_value, source_file, changed_at
+	)
+	SELECT
+		@UNIQ_ID, 'fprice', c.KODECABANG, c.GHARGA, NULL, c.PCODE,
+		v.price_column, v.old_value, v.new_value, c.CORE_FILENAME, SYSDATETIME()
+	FROM @SummaryOfChanges c
+	CROSS APPLY (VALUES
+		('SELPRICE1', c.OLD1, c.NEW1),
+		('SELPRICE2', c.OLD2, c.NEW2),
+		('SELPRICE3', c.OLD3, c.NEW3),
+		('SELPRICE4', c.OLD4, c.NEW4),
+		('SELPRICE5', c.OLD5, c.NEW5)
+	) v (price_column, old_value, new_value)
+	WHERE c.ACTION = 'UPDATE'
+		AND EXISTS (SELECT v.old_value EXCEPT SELECT v.new_value);
 
 	SELECT
-		SUM(CASE WHEN ACTION = 'INSERT' THEN 1 ELSE 0 END),
-		SUM(CASE WHEN ACTION = 'UPDATE' THEN 1 ELSE 0 END)
+		ISNULL(SUM(CASE WHEN ACTION = 'INSERT' THEN 1 ELSE 0 END), 0),
+		ISNULL(SUM(CASE WHEN ACTION = 'UPDATE' THEN 1 ELSE 0 END), 0)
 	FROM @SummaryOfChanges;
 	`
 
-	var inserted, updated int
-	if err := tx.QueryRowContext(ctx, query, sql.Named("UNIQ_ID", processID)).Scan(&inserted, &updated); err != nil {
-		return err
-	}
-
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-
-	log.Printf(
-		"PRICE FINAL completed | inserted=%d updated=%d duration=%s",
-		inserted,
-		updated,
-		time.Since(start),
-	)
-
-	return nil
+	err = tx.QueryRowContext(ctx, query, sql.Named("UNIQ_ID", processID), sql.Named("KODECABANG", kodecabang)).Scan(&inserted, &updated)
+	return inserted, updated, err
 }
@@ -0,0 +1,152 @@
+package lock
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go-import-file/internal/config"
+	"go-import-file/internal/db"
+)
+
+// appLockCheck is how often a held applock is checked to still be there.
+const appLockCheck = 30 * time.Second
+
+// appLock takes an exclusive sp_getapplock on a connection of its own,
+// outside the work pool; the lock lives as long as that session. The
+// session is checked every appLockCheck and the lock reported lost when it
+// dropped.
+func appLock(ctx context.Context, cfg *config.Config, resource string, wait time.Duration) (*Lock, error) {
+	lockDB, err := db.NewSQLServerSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := lockDB.Conn(ctx)
+	if err != nil {
+		lockDB.Close()
+		return nil, err
+	}
+	closeAll := func() {
+		conn.Close()
+		lockDB.Close()
+	}
+
+	var status int
+	err = conn.QueryRowContext(ctx, `
+		DECLARE @r int;
+		EXEC @r = sp_getapplock
+			@Resource = @res,
+			@LockMode = 'Exclusive',
+			@LockOwner = 'Session',
+			@LockTimeout = @ms;
+		SELECT @r;
+	`, sql.Named("res", resource), sql.Named("ms", wait.Milliseconds())).Scan(&status)
+	if err != nil {
+		closeAll()
+		return nil, err
+	}
+
+	switch {
+	case status == -1:
+		holder := appLockHolder(ctx, conn, resource)
+		closeAll()
+		return nil, &BusyError{Resource: resource, Holder: holder}
+	case status < 0:
+		closeAll()
+		return nil, fmt.Errorf("sp_getapplock %s returned %d", resource, status)
+	}
+
+	// Only informational: a missing import_lock table (migrate up not run)
+	// does not stop the import.
+	_, err = conn.ExecContext(ctx, `
+		MERGE dbo.import_lock AS t
+		USING (SELECT @res AS resource) s
+		ON t.resource = s.resource
+		WHEN MATCHED THEN
+			UPDATE SET holder=@holder, process_id=@pid, acquired_at=SYSDATETIME()
+		WHEN NOT MATCHED THEN
+			INSERT (resource, holder, process_id, acquired_at)
+			VALUES (@res, @holder, @pid, SYSDATETIME());
+	`, sql.Named("res", resource), sql.Named("holder", Owner()), sql.Named("pid", cfg.Run.ProcessID))
+	if err != nil {
+		log.Printf("LOCK %s: holder not recorded: %v", resource, err)
+	}
+
+	lost := make(chan struct{})
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		t := time.NewTicker(appLockCheck)
+		defer t.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-t.C:
+			}
+			if err := checkAppLock(conn, resource); err != nil {
+				log.Printf("LOCK %s lost: %v", resource, err)
+				close(lost)
+				return
+			}
+		}
+	}()
+
+	return &Lock{
+		Resource: resource,
+		lost:     lost,
+		release: func() error {
+			close(done)
+			wg.Wait()
+			defer closeAll()
+
+			_, err := conn.ExecContext(context.Background(),
+				"EXEC sp_releaseapplock @Resource = @res, @LockOwner = 'Session'",
+				sql.Named("res", resource),
+			)
+			return err
+		},
+	}, nil
+}
+
+// checkAppLock fails when the session no longer holds resource, which is
+// the case once its connection dropped.
+func checkAppLock(conn *sql.Conn, resource string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), appLockCheck)
+	defer cancel()
+
+	var mode string
+	err := conn.QueryRowContext(ctx,
+		"SELECT APPLOCK_MODE('public', @res, 'Session')",
+		sql.Named("res", resource),
+	).Scan(&mode)
+	if err != nil {
+		return err
+	}
+	if mode != "Exclusive" {
+		return fmt.Errorf("session holds %s", mode)
+	}
+	return nil
+}
+
+func appLockHolder(ctx context.Context, conn *sql.Conn, resource string) string {
+	var (
+		holder    string
+		processID sql.NullString
+		since     time.Time
+	)
+	err := conn.QueryRowContext(ctx,
+		"SELECT holder, process_id, acquired_at FROM dbo.import_lock WHERE resource = @res",
+		sql.Named("res", resource),
+	).Scan(&holder, &processID, &since)
+	if err != nil {
+		return "another instance"
+	}
+	return fmt.Sprintf("%s (process %s, since %s)", holder, processID.String, since.Format(time.DateTime))
+}
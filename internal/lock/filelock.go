@@ -0,0 +1,155 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// A lockfile is touched every fileHeartbeat while held; one untouched for
+// fileStale belongs to a crashed run and is removed. A busy lock is tried
+// again every fileRetry.
+var (
+	fileHeartbeat = 30 * time.Second
+	fileStale     = 2 * time.Minute
+	fileRetry     = time.Second
+)
+
+func fileLock(ctx context.Context, path, processID string, wait time.Duration) (*Lock, error) {
+	holder := fmt.Sprintf("%s (process %s, since %s)", Owner(), processID, time.Now().Format(time.DateTime))
+	deadline := time.Now().Add(wait)
+
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			_, err = f.WriteString(holder + "\n")
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+			if err != nil {
+				os.Remove(path)
+				return nil, err
+			}
+			return heldFile(path, holder), nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return nil, err
+		}
+
+		other, stale := fileHolder(path)
+		if stale {
+			takeOver(path, other)
+			continue
+		}
+		if !time.Now().Before(deadline) {
+			return nil, &BusyError{Resource: path, Holder: other}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(fileRetry):
+		}
+	}
+}
+
+// fileHolder reads who holds path and whether the lock went stale. A file
+// that vanished meanwhile counts as stale so the caller retries at once.
+func fileHolder(path string) (string, bool) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", true
+	}
+	b, _ := os.ReadFile(path)
+	return strings.TrimSpace(string(b)), time.Since(info.ModTime()) > fileStale
+}
+
+// takeOver removes the stale lockfile of other. The file is first renamed
+// to a name of its own, so of two runs that found it stale only one gets
+// it; when what it got is not the stale lock but one a faster run already
+// created, that lock is put back. The caller then races for the path with
+// O_EXCL as usual.
+func takeOver(path, other string) {
+	grabbed := fmt.Sprintf("%s.stale-%d-%d", path, os.Getpid(), time.Now().UnixNano())
+	if err := os.Rename(path, grabbed); err != nil {
+		return
+	}
+	defer os.Remove(grabbed)
+
+	if holder, stale := fileHolder(grabbed); stale && holder == other {
+		log.Printf("LOCK %s: removed stale lock of %s", path, other)
+		return
+	}
+	if err := os.Link(grabbed, path); err != nil {
+		log.Printf("LOCK %s: could not put back the lock of another run: %v", path, err)
+	}
+}
+
+// heldFile touches path every fileHeartbeat while it still holds holder.
+// The lock is lost once the file holds another run, or has been gone for
+// two heartbeats in a row (one miss may be a takeover putting it back).
+func heldFile(path, holder string) *Lock {
+	lost := make(chan struct{})
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		t := time.NewTicker(fileHeartbeat)
+		defer t.Stop()
+		missing := 0
+		for {
+			var now time.Time
+			select {
+			case <-done:
+				return
+			case now = <-t.C:
+			}
+
+			b, err := os.ReadFile(path)
+			switch {
+			case errors.Is(err, fs.ErrNotExist):
+				missing++
+				if missing < 2 {
+					continue
+				}
+				log.Printf("LOCK %s lost: the lockfile is gone", path)
+			case err != nil:
+				log.Printf("LOCK %s: heartbeat failed: %v", path, err)
+				continue
+			case strings.TrimSpace(string(b)) != holder:
+				log.Printf("LOCK %s lost: taken over by %s", path, strings.TrimSpace(string(b)))
+			default:
+				missing = 0
+				if err := os.Chtimes(path, now, now); err != nil {
+					log.Printf("LOCK %s: heartbeat failed: %v", path, err)
+				}
+				continue
+			}
+			close(lost)
+			return
+		}
+	}()
+
+	return &Lock{
+		Resource: path,
+		lost:     lost,
+		release: func() error {
+			close(done)
+			wg.Wait()
+
+			// Leave a lock taken over by another run alone.
+			if b, err := os.ReadFile(path); err != nil || strings.TrimSpace(string(b)) != holder {
+				return nil
+			}
+			return os.Remove(path)
+		},
+	}
+}
@@ -0,0 +1,220 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"go-import-file/internal/config"
+)
+
+// fast shortens the lockfile timings for one test.
+func fast(t *testing.T) {
+	t.Helper()
+	heartbeat, stale, retry := fileHeartbeat, fileStale, fileRetry
+	fileHeartbeat, fileStale, fileRetry = 20*time.Millisecond, time.Minute, 20*time.Millisecond
+	t.Cleanup(func() { fileHeartbeat, fileStale, fileRetry = heartbeat, stale, retry })
+}
+
+func lockConfig(dir, busy string, waitSeconds int, processID string) *config.Config {
+	cfg := &config.Config{
+		LogsDir: dir,
+		DB:      config.DBConfig{Driver: "sqlite"},
+		Lock:    config.LockConfig{Busy: busy, WaitSeconds: waitSeconds},
+	}
+	cfg.Run.ProcessID = processID
+	return cfg
+}
+
+func mustAcquire(t *testing.T, cfg *config.Config, block string) *Lock {
+	t.Helper()
+	l, err := Acquire(context.Background(), cfg, block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Release() })
+	return l
+}
+
+func holderOf(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// writeStale leaves the lockfile of a crashed run at path.
+func writeStale(t *testing.T, path, holder string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(holder+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * fileStale)
+	if err := os.Chtimes(path, old, old); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileLockBusy(t *testing.T) {
+	fast(t)
+	dir := t.TempDir()
+
+	first := mustAcquire(t, lockConfig(dir, config.LockSkip, 0, "p1"), "sdeal")
+	if first.Resource != filepath.Join(dir, "SDEAL.lock") {
+		t.Errorf("resource %s", first.Resource)
+	}
+
+	tests := []struct {
+		name        string
+		busy        string
+		waitSeconds int
+		minWait     time.Duration
+	}{
+		{"skip", config.LockSkip, 5, 0},
+		{"wait times out", config.LockWait, 1, time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start := time.Now()
+			_, err := Acquire(context.Background(), lockConfig(dir, tt.busy, tt.waitSeconds, "p2"), "SDEAL")
+
+			var busy *BusyError
+			if !errors.As(err, &busy) {
+				t.Fatalf("err = %v, want a BusyError", err)
+			}
+			if !strings.Contains(busy.Holder, "(process p1, since ") {
+				t.Errorf("holder %q, want the first run", busy.Holder)
+			}
+			if waited := time.Since(start); waited < tt.minWait || waited > tt.minWait+time.Second {
+				t.Errorf("waited %v, want about %v", waited, tt.minWait)
+			}
+		})
+	}
+
+	// Another block is not affected.
+	mustAcquire(t, lockConfig(dir, config.LockSkip, 0, "p3"), "MPRICE")
+}
+
+func TestFileLockWaitsForRelease(t *testing.T) {
+	fast(t)
+	dir := t.TempDir()
+
+	first, err := Acquire(context.Background(), lockConfig(dir, config.LockWait, 0, "p1"), "SDEAL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.AfterFunc(100*time.Millisecond, func() { first.Release() })
+
+	second := mustAcquire(t, lockConfig(dir, config.LockWait, 5, "p2"), "SDEAL")
+	if h := holderOf(t, second.Resource); !strings.Contains(h, "process p2") {
+		t.Errorf("lockfile holds %q, want p2", h)
+	}
+}
+
+func TestFileLockWaitIsCancelled(t *testing.T) {
+	fast(t)
+	dir := t.TempDir()
+	mustAcquire(t, lockConfig(dir, config.LockWait, 0, "p1"), "SDEAL")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	if _, err := Acquire(ctx, lockConfig(dir, config.LockWait, 60, "p2"), "SDEAL"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, want the context deadline", err)
+	}
+}
+
+func TestFileLockTakesOverStaleLock(t *testing.T) {
+	fast(t)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "SDEAL.lock")
+	writeStale(t, path, "crashed:1 (process p0)")
+
+	l := mustAcquire(t, lockConfig(dir, config.LockSkip, 0, "p1"), "SDEAL")
+
+	if h := holderOf(t, l.Resource); !strings.Contains(h, "process p1") {
+		t.Errorf("lockfile holds %q, want p1", h)
+	}
+	if left, _ := filepath.Glob(path + ".stale-*"); len(left) > 0 {
+		t.Errorf("left behind %v", left)
+	}
+}
+
+func TestTakeOver(t *testing.T) {
+	fast(t)
+	path := filepath.Join(t.TempDir(), "SDEAL.lock")
+
+	// The stale lock that was read is removed.
+	writeStale(t, path, "crashed:1")
+	takeOver(path, "crashed:1")
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("stale lock still there: %v", err)
+	}
+
+	// A run that read the stale lock after a faster run replaced it puts
+	// the new lock back instead of removing it.
+	if err := os.WriteFile(path, []byte("faster:2\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	takeOver(path, "crashed:1")
+	if h := holderOf(t, path); h != "faster:2" {
+		t.Errorf("lockfile holds %q, want the faster run", h)
+	}
+	if left, _ := filepath.Glob(path + ".stale-*"); len(left) > 0 {
+		t.Errorf("left behind %v", left)
+	}
+}
+
+func TestFileLockLost(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(path string) error
+	}{
+		{"taken over", func(path string) error { return os.WriteFile(path, []byte("other:9\n"), 0o644) }},
+		{"removed", os.Remove},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fast(t)
+			l := mustAcquire(t, lockConfig(t.TempDir(), config.LockSkip, 0, "p1"), "SDEAL")
+
+			select {
+			case <-l.Lost():
+				t.Fatal("lost before anything happened")
+			case <-time.After(5 * fileHeartbeat):
+			}
+
+			if err := tt.change(l.Resource); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case <-l.Lost():
+			case <-time.After(time.Second):
+				t.Fatal("Lost() not closed")
+			}
+		})
+	}
+}
+
+func TestReleaseLeavesTakenOverLock(t *testing.T) {
+	fast(t)
+	l, err := Acquire(context.Background(), lockConfig(t.TempDir(), config.LockSkip, 0, "p1"), "SDEAL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(l.Resource, []byte("other:9\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := l.Release(); err != nil {
+		t.Fatal(err)
+	}
+	if h := holderOf(t, l.Resource); h != "other:9" {
+		t.Errorf("lockfile holds %q, want the other run", h)
+	}
+}
@@ -0,0 +1,73 @@
+// Package lock keeps two instances from running the same block at once.
+// On SQL Server the lock is an sp_getapplock session lock, elsewhere a
+// lockfile in the log dir kept fresh by a heartbeat.
+package lock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go-import-file/internal/config"
+)
+
+// BusyError means another instance holds the lock.
+type BusyError struct {
+	Resource string
+	Holder   string
+}
+
+func (e *BusyError) Error() string {
+	return fmt.Sprintf("%s is held by %s", e.Resource, e.Holder)
+}
+
+// ErrLost means a held lock went away before it was released: the lock
+// session dropped or the lockfile was taken over.
+var ErrLost = errors.New("block lock lost")
+
+// Lock is a held block lock.
+type Lock struct {
+	Resource string
+	lost     chan struct{}
+	release  func() error
+}
+
+// Lost is closed when the lock is found gone while held; the run must stop,
+// another instance may already be importing the block.
+func (l *Lock) Lost() <-chan struct{} {
+	return l.lost
+}
+
+func (l *Lock) Release() error {
+	return l.release()
+}
+
+// Owner identifies this instance in locks and leases.
+func Owner() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
+
+// Acquire takes the lock of block ("" for all blocks). With lock.busy
+// wait it waits up to lock.wait_seconds for a busy lock, with skip it
+// returns a *BusyError at once.
+func Acquire(ctx context.Context, cfg *config.Config, block string) (*Lock, error) {
+	name := strings.ToUpper(block)
+	if name == "" {
+		name = "ALL"
+	}
+
+	wait := time.Duration(cfg.Lock.WaitSeconds) * time.Second
+	if cfg.Lock.Busy == config.LockSkip {
+		wait = 0
+	}
+
+	if cfg.DB.Driver == "sqlserver" {
+		return appLock(ctx, cfg, "go-import-file/"+name, wait)
+	}
+	return fileLock(ctx, filepath.Join(cfg.LogsDir, name+".lock"), cfg.Run.ProcessID, wait)
+}
@@ -9,6 +9,7 @@ import (
 )
 
 type DailyWorkerLogger struct {
+	dir    string
 	worker string
 	mu     sync.Mutex
 	date   string
@@ -16,8 +17,13 @@ type DailyWorkerLogger struct {
 	logger *log.Logger
 }
 
-func NewDailyWorkerLogger(worker string) (*DailyWorkerLogger, error) {
+func NewDailyWorkerLogger(dir, worker string) (*DailyWorkerLogger, error) {
+	if dir == "" {
+		dir = "logs"
+	}
+
 	l := &DailyWorkerLogger{
+		dir:    dir,
 		worker: worker,
 	}
 	if err := l.rotateIfNeeded(); err != nil {
@@ -33,7 +39,7 @@ func (l *DailyWorkerLogger) rotateIfNeeded() error {
 		return nil
 	}
 
-	if err := os.MkdirAll("logs", 0755); err != nil {
+	if err := os.MkdirAll(l.dir, 0755); err != nil {
 		return err
 	}
 
@@ -42,7 +48,7 @@ func (l *DailyWorkerLogger) rotateIfNeeded() error {
 	}
 
 	path := filepath.Join(
-		"logs",
+		l.dir,
 		l.worker+"-"+today+".log",
 	)
 
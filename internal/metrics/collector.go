@@ -4,6 +4,8 @@ import "log"
 
 func CollectFileMetrics(in <-chan FileMetric, done chan<- struct{}) {
 	for m := range in {
+		RecordFile(m)
+
 		log.Println("======================================")
 		log.Println("FILE METRICS")
 		log.Printf("File        : %s\n", m.FileName)
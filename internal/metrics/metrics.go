@@ -7,8 +7,21 @@ var (
 	TotalLines     int64
 	ProcessedLines int64
 	InsertedRows   int64
+
+	// ParseWorkersStarted and ParseWorkersRunning count parse workers, so
+	// the watchdog can tell when a step has read all of its files.
+	ParseWorkersStarted int64
+	ParseWorkersRunning int64
 )
 
 func IncProcessed(n int64) {
 	atomic.AddInt64(&ProcessedLines, n)
 }
+
+// BeginParse counts a parse worker as running until the returned func is
+// called.
+func BeginParse() (end func()) {
+	atomic.AddInt64(&ParseWorkersStarted, 1)
+	atomic.AddInt64(&ParseWorkersRunning, 1)
+	return func() { atomic.AddInt64(&ParseWorkersRunning, -1) }
+}
@@ -0,0 +1,213 @@
+package metrics
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	maxRejectSamples = 5
+	maxRejectRawLen  = 300
+)
+
+type RejectSample struct {
+	File   string
+	Line   int
+	Detail string // the full message, with the offending value
+	Raw    string
+}
+
+type RejectReason struct {
+	Reason  string
+	Count   int64
+	Samples []RejectSample
+}
+
+type TableStats struct {
+	Table     string
+	Rows      int64
+	Inserted  int64
+	Updated   int64
+	Unchanged int64
+	Retired   int64
+	Duration  time.Duration
+	Error     string
+}
+
+type StepStats struct {
+	Name     string
+	Duration time.Duration
+	Error    string
+}
+
+type FinalizeStats struct {
+	Name     string
+	Status   string
+	Inserted int64
+	Updated  int64
+	Duration time.Duration
+	Error    string
+}
+
+type BlockStats struct {
+	Block    string
+	Files    []FileMetric
+	Tables   []TableStats
+	Steps    []StepStats
+	Finalize []FinalizeStats
+	Rejects  []RejectReason
+}
+
+type RunSnapshot struct {
+	ProcessID string
+	StartedAt time.Time
+	Blocks    []BlockStats
+}
+
+type blockRecord struct {
+	stats   BlockStats
+	rejects map[string]*RejectReason
+}
+
+type runRecorder struct {
+	mu        sync.Mutex
+	processID string
+	startedAt time.Time
+	current   string
+	order     []string
+	blocks    map[string]*blockRecord
+}
+
+var run = &runRecorder{blocks: map[string]*blockRecord{}}
+
+// BeginRun resets the per-run statistics used by the run report.
+func BeginRun(processID string) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	run.processID = processID
+	run.startedAt = time.Now()
+	run.current = ""
+	run.order = nil
+	run.blocks = map[string]*blockRecord{}
+}
+
+// BeginBlock attributes everything recorded from now on to block.
+func BeginBlock(block string) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	run.current = block
+	run.block()
+}
+
+func (r *runRecorder) block() *blockRecord {
+	b, ok := r.blocks[r.current]
+	if !ok {
+		b = &blockRecord{
+			stats:   BlockStats{Block: r.current},
+			rejects: map[string]*RejectReason{},
+		}
+		r.blocks[r.current] = b
+		r.order = append(r.order, r.current)
+	}
+	return b
+}
+
+func RecordFile(m FileMetric) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	b := run.block()
+	b.stats.Files = append(b.stats.Files, m)
+}
+
+// RecordReject counts a rejected line under reason, a stable text without
+// field values; detail, the full message, is kept with the sample.
+func RecordReject(file string, line int, reason, detail, raw string) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	b := run.block()
+	rr, ok := b.rejects[reason]
+	if !ok {
+		rr = &RejectReason{Reason: reason}
+		b.rejects[reason] = rr
+	}
+
+	rr.Count++
+	if len(rr.Samples) < maxRejectSamples {
+		if len(raw) > maxRejectRawLen {
+			raw = raw[:maxRejectRawLen] + "..."
+		}
+		rr.Samples = append(rr.Samples, RejectSample{
+			File:   file,
+			Line:   line,
+			Detail: detail,
+			Raw:    raw,
+		})
+	}
+}
+
+func RecordTable(t TableStats) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	b := run.block()
+	b.stats.Tables = append(b.stats.Tables, t)
+}
+
+func RecordStep(s StepStats) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	b := run.block()
+	b.stats.Steps = append(b.stats.Steps, s)
+}
+
+func RecordFinalize(f FinalizeStats) {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	b := run.block()
+	b.stats.Finalize = append(b.stats.Finalize, f)
+}
+
+// Snapshot returns a copy of the current run statistics with reject
+// reasons sorted by count, most frequent first.
+func Snapshot() RunSnapshot {
+	run.mu.Lock()
+	defer run.mu.Unlock()
+
+	snap := RunSnapshot{
+		ProcessID: run.processID,
+		StartedAt: run.startedAt,
+	}
+
+	for _, name := range run.order {
+		b := run.blocks[name]
+
+		stats := b.stats
+		stats.Files = append([]FileMetric(nil), b.stats.Files...)
+		stats.Tables = append([]TableStats(nil), b.stats.Tables...)
+		stats.Steps = append([]StepStats(nil), b.stats.Steps...)
+		stats.Finalize = append([]FinalizeStats(nil), b.stats.Finalize...)
+
+		for _, rr := range b.rejects {
+			cp := *rr
+			cp.Samples = append([]RejectSample(nil), rr.Samples...)
+			stats.Rejects = append(stats.Rejects, cp)
+		}
+		sort.Slice(stats.Rejects, func(i, j int) bool {
+			if stats.Rejects[i].Count != stats.Rejects[j].Count {
+				return stats.Rejects[i].Count > stats.Rejects[j].Count
+			}
+			return stats.Rejects[i].Reason < stats.Rejects[j].Reason
+		})
+
+		snap.Blocks = append(snap.Blocks, stats)
+	}
+
+	return snap
+}
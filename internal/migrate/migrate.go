@@ -0,0 +1,273 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+
+	"go-import-file/internal/config"
+)
+
+// Migrations live in sql/<driver>/NNNN_name.sql and are applied in version
+// order. SQL Server scripts may be split into batches with GO lines.
+//
+//go:embed sql
+var files embed.FS
+
+type Migration struct {
+	Version int
+	Name    string
+	SQL     string
+}
+
+type Status struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Load returns the embedded migrations for driver, sorted by version.
+func Load(driver string) ([]Migration, error) {
+	dir := path.Join("sql", driver)
+
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migrations for driver %s", driver)
+	}
+
+	var out []Migration
+	seen := map[int]string{}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+
+		base := strings.TrimSuffix(e.Name(), ".sql")
+		num, name, _ := strings.Cut(base, "_")
+		version, err := strconv.Atoi(num)
+		if err != nil {
+			return nil, fmt.Errorf("migration %s: file name must start with a version number", e.Name())
+		}
+		if other, ok := seen[version]; ok {
+			return nil, fmt.Errorf("migration %s: version %d already used by %s", e.Name(), version, other)
+		}
+		seen[version] = e.Name()
+
+		b, err := fs.ReadFile(files, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, Migration{Version: version, Name: name, SQL: string(b)})
+	}
+
+	sort.Slice(out, func(i, j int) bool { return out[i].Version < out[j].Version })
+	return out, nil
+}
+
+/* =========================
+   MIGRATOR
+========================= */
+
+type Migrator struct {
+	db     *sql.DB
+	driver string
+	schema string // postgres only
+}
+
+// New applies migrations to the database of cfg. On PostgreSQL the tables
+// go into database.schema (dbo when empty), matching the sink.
+func New(cfg *config.Config, db *sql.DB) *Migrator {
+	m := &Migrator{db: db, driver: cfg.DB.Driver}
+	if m.driver == "postgres" {
+		m.schema = cfg.DB.Schema
+		if m.schema == "" {
+			m.schema = "dbo"
+		}
+	}
+	return m
+}
+
+func (m *Migrator) versionTable() string {
+	switch m.driver {
+	case "postgres":
+		return pq.QuoteIdentifier(m.schema) + ".schema_version"
+	case "sqlite":
+		return "schema_version"
+	}
+	return "dbo.schema_version"
+}
+
+func (m *Migrator) placeholder(n int) string {
+	switch m.driver {
+	case "postgres":
+		return "$" + strconv.Itoa(n)
+	case "sqlite":
+		return "?"
+	}
+	return "@p" + strconv.Itoa(n)
+}
+
+func (m *Migrator) ensureVersionTable(ctx context.Context) error {
+	var ddl []string
+	switch m.driver {
+	case "postgres":
+		ddl = []string{
+			"CREATE SCHEMA IF NOT EXISTS " + pq.QuoteIdentifier(m.schema),
+			`CREATE TABLE IF NOT EXISTS ` + m.versionTable() + ` (
+				version INTEGER NOT NULL PRIMARY KEY,
+				name VARCHAR(255) NOT NULL,
+				applied_at TIMESTAMP NOT NULL
+			)`,
+		}
+	case "sqlite":
+		ddl = []string{`CREATE TABLE IF NOT EXISTS schema_version (
+				version INTEGER NOT NULL PRIMARY KEY,
+				name VARCHAR(255) NOT NULL,
+				applied_at DATETIME NOT NULL
+			)`,
+		}
+	default:
+		ddl = []string{`
+			IF OBJECT_ID(N'dbo.schema_version', N'U') IS NULL
+			CREATE TABLE dbo.schema_version (
+				version INT NOT NULL PRIMARY KEY,
+				name NVARCHAR(255) NOT NULL,
+				applied_at DATETIME2 NOT NULL
+			)`,
+		}
+	}
+
+	for _, q := range ddl {
+		if _, err := m.db.ExecContext(ctx, q); err != nil {
+			return fmt.Errorf("create schema_version: %w", err)
+		}
+	}
+	return nil
+}
+
+// Status lists every embedded migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	migrations, err := Load(m.driver)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := m.ensureVersionTable(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := m.db.QueryContext(ctx, "SELECT version, applied_at FROM "+m.versionTable())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[int]time.Time{}
+	for rows.Next() {
+		var (
+			version int
+			at      time.Time
+		)
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, err
+		}
+		applied[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	out := make([]Status, len(migrations))
+	for i, mig := range migrations {
+		at, ok := applied[mig.Version]
+		out[i] = Status{Migration: mig, Applied: ok, AppliedAt: at}
+	}
+	return out, nil
+}
+
+// Up applies every pending migration, each in its own transaction, and
+// returns the ones it applied. It stops at the first failure.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, s := range statuses {
+		if s.Applied {
+			continue
+		}
+		if err := m.apply(ctx, s.Migration); err != nil {
+			return done, fmt.Errorf("migration %04d_%s: %w", s.Version, s.Name, err)
+		}
+		done = append(done, s.Migration)
+	}
+	return done, nil
+}
+
+func (m *Migrator) apply(ctx context.Context, mig Migration) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if m.driver == "postgres" {
+		if _, err := tx.ExecContext(ctx, "SET LOCAL search_path TO "+pq.QuoteIdentifier(m.schema)); err != nil {
+			return err
+		}
+	}
+
+	for _, batch := range batches(mig.SQL) {
+		if _, err := tx.ExecContext(ctx, batch); err != nil {
+			return err
+		}
+	}
+
+	insert := fmt.Sprintf(
+		"INSERT INTO %s (version, name, applied_at) VALUES (%s, %s, %s)",
+		m.versionTable(), m.placeholder(1), m.placeholder(2), m.placeholder(3),
+	)
+	if _, err := tx.ExecContext(ctx, insert, mig.Version, mig.Name, time.Now()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// batches splits a script on lines that only contain GO.
+func batches(script string) []string {
+	var (
+		out []string
+		cur strings.Builder
+	)
+	flush := func() {
+		if s := strings.TrimSpace(cur.String()); s != "" {
+			out = append(out, s)
+		}
+		cur.Reset()
+	}
+
+	for _, line := range strings.Split(script, "\n") {
+		if strings.EqualFold(strings.TrimSpace(line), "GO") {
+			flush()
+			continue
+		}
+		cur.WriteString(line)
+		cur.WriteString("\n")
+	}
+	flush()
+
+	return out
+}
@@ -0,0 +1,294 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-import-file/internal/config"
+	"go-import-file/internal/db"
+)
+
+// sqliteAt opens a new SQLite database with the migrations up to and
+// including version applied.
+func sqliteAt(t *testing.T, version int) (*Migrator, *sql.DB) {
+	t.Helper()
+
+	cfg := &config.Config{DB: config.DBConfig{Driver: "sqlite", Name: filepath.Join(t.TempDir(), "test.db")}}
+	conn, err := db.NewSQLite(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	m := New(cfg, conn)
+	if err := m.ensureVersionTable(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	migrations, err := Load("sqlite")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, mig := range migrations {
+		if mig.Version > version {
+			break
+		}
+		if err := m.apply(context.Background(), mig); err != nil {
+			t.Fatalf("migration %04d_%s: %v", mig.Version, mig.Name, err)
+		}
+	}
+	return m, conn
+}
+
+func exec(t *testing.T, conn *sql.DB, q string, args ...any) {
+	t.Helper()
+	if _, err := conn.Exec(q, args...); err != nil {
+		t.Fatalf("%s: %v", q, err)
+	}
+}
+
+// column returns the declared type of table.col.
+func column(t *testing.T, conn *sql.DB, table, col string) string {
+	t.Helper()
+	var typ string
+	if err := conn.QueryRow(`SELECT type FROM pragma_table_info(?) WHERE name = ?`, table, col).Scan(&typ); err != nil {
+		t.Fatalf("%s.%s: %v", table, col, err)
+	}
+	return typ
+}
+
+func version(t *testing.T, conn *sql.DB) int {
+	t.Helper()
+	var v int
+	if err := conn.QueryRow(`SELECT MAX(version) FROM schema_version`).Scan(&v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestSQLiteUpAppliesEveryMigration(t *testing.T) {
+	m, conn := sqliteAt(t, 0)
+
+	applied, err := m.Up(context.Background())
+	if err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	migrations, _ := Load("sqlite")
+	if len(applied) != len(migrations) {
+		t.Errorf("applied %d migrations, want %d", len(applied), len(migrations))
+	}
+
+	// The key index ON CONFLICT needs survives the rebuilt tables.
+	var n int
+	conn.QueryRow(`SELECT COUNT(*) FROM sqlite_schema WHERE type = 'index' AND name = 'ux_sap_web_inv_sfa'`).Scan(&n)
+	if n != 1 {
+		t.Error("ux_sap_web_inv_sfa is missing")
+	}
+
+	if applied, err := m.Up(context.Background()); err != nil || len(applied) != 0 {
+		t.Errorf("second Up applied %d migrations, err %v", len(applied), err)
+	}
+}
+
+func TestSQLiteTypedDates(t *testing.T) {
+	m, conn := sqliteAt(t, 5)
+
+	exec(t, conn, `INSERT INTO sap_web_inv_sfa (SLSNO, SFA_ORDER_DATE, ORDER_DATE, INVOICE_DATE) VALUES ('S1', '20260131', '', '00000000')`)
+	exec(t, conn, `INSERT INTO sap_web_inv_sfa (SLSNO, SFA_ORDER_DATE, ORDER_DATE, INVOICE_DATE) VALUES ('S2', '2026-01-31 00:00:00+07:00', NULL, ' 20260201 ')`)
+
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	if typ := column(t, conn, "sap_web_inv_sfa", "SFA_ORDER_DATE"); typ != "DATE" {
+		t.Errorf("SFA_ORDER_DATE is %s, want DATE", typ)
+	}
+
+	tests := []struct {
+		slsno, col string
+		want       sql.NullString
+	}{
+		{"S1", "SFA_ORDER_DATE", sql.NullString{String: "2026-01-31", Valid: true}},
+		{"S1", "ORDER_DATE", sql.NullString{}},
+		{"S1", "INVOICE_DATE", sql.NullString{}},
+		{"S2", "SFA_ORDER_DATE", sql.NullString{String: "2026-01-31 00:00:00+07:00", Valid: true}},
+		{"S2", "INVOICE_DATE", sql.NullString{String: "2026-02-01", Valid: true}},
+	}
+	for _, tt := range tests {
+		var got sql.NullString
+		if err := conn.QueryRow(`SELECT CAST(`+tt.col+` AS TEXT) FROM sap_web_inv_sfa WHERE SLSNO = ?`, tt.slsno).Scan(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%s %s = %v, want %v", tt.slsno, tt.col, got, tt.want)
+		}
+	}
+}
+
+func TestSQLiteTypedDatesStopOnBadValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+	}{
+		{"short", "2026013", "sap_web_inv_sfa.ORDER_DATE = '2026013' (2 rows)"},
+		{"no such day", "20260230", "sap_web_inv_sfa.ORDER_DATE = '20260230' (2 rows)"},
+		{"text", "soon", "sap_web_inv_sfa.ORDER_DATE = 'soon' (2 rows)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, conn := sqliteAt(t, 5)
+			exec(t, conn, `INSERT INTO sap_web_inv_sfa (SLSNO, ORDER_DATE) VALUES ('S1', ?), ('S2', ?), ('S3', '20260131')`, tt.value, tt.value)
+
+			_, err := m.Up(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to list %s", err, tt.want)
+			}
+
+			// Nothing changed: the migration is not recorded and the
+			// column still holds text.
+			if v := version(t, conn); v != 5 {
+				t.Errorf("version = %d, want 5", v)
+			}
+			if typ := column(t, conn, "sap_web_inv_sfa", "ORDER_DATE"); typ != "VARCHAR(255)" {
+				t.Errorf("ORDER_DATE is %s, want VARCHAR(255)", typ)
+			}
+		})
+	}
+}
+
+func TestSQLiteDecimalAmounts(t *testing.T) {
+	m, conn := sqliteAt(t, 6)
+
+	exec(t, conn, `INSERT INTO m_price_dummy (UNIQ_ID, PRICE_VALUE) VALUES ('a', ' 1234.5 '), ('b', ''), ('c', '-0.0001'), ('d', NULL)`)
+	exec(t, conn, `INSERT INTO sap_web_inv_sfa (SLSNO, PRICE, QTY) VALUES ('S1', 0.1, 12)`)
+	exec(t, conn, `INSERT INTO mkplprice_dummy (UNIQ_ID, PCODE) VALUES ('a', 12345.0), ('b', 'P-1')`)
+
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+
+	for _, c := range []struct{ table, col, want string }{
+		{"m_price_dummy", "PRICE_VALUE", "NUMERIC(19,4)"},
+		{"sap_web_inv_sfa", "PRICE", "NUMERIC(19,4)"},
+		{"mkplprice_dummy", "PCODE", "VARCHAR(255)"},
+	} {
+		if typ := column(t, conn, c.table, c.col); typ != c.want {
+			t.Errorf("%s.%s is %s, want %s", c.table, c.col, typ, c.want)
+		}
+	}
+
+	tests := []struct {
+		query string
+		want  sql.NullString
+	}{
+		{`SELECT CAST(PRICE_VALUE AS TEXT) FROM m_price_dummy WHERE UNIQ_ID = 'a'`, sql.NullString{String: "1234.5", Valid: true}},
+		{`SELECT CAST(PRICE_VALUE AS TEXT) FROM m_price_dummy WHERE UNIQ_ID = 'b'`, sql.NullString{}},
+		{`SELECT CAST(PRICE_VALUE AS TEXT) FROM m_price_dummy WHERE UNIQ_ID = 'c'`, sql.NullString{String: "-0.0001", Valid: true}},
+		{`SELECT CAST(PRICE_VALUE AS TEXT) FROM m_price_dummy WHERE UNIQ_ID = 'd'`, sql.NullString{}},
+		{`SELECT CAST(PRICE AS TEXT) FROM sap_web_inv_sfa WHERE SLSNO = 'S1'`, sql.NullString{String: "0.1", Valid: true}},
+		{`SELECT CAST(QTY AS TEXT) FROM sap_web_inv_sfa WHERE SLSNO = 'S1'`, sql.NullString{String: "12", Valid: true}},
+		{`SELECT PCODE FROM mkplprice_dummy WHERE UNIQ_ID = 'a'`, sql.NullString{String: "12345", Valid: true}},
+		{`SELECT PCODE FROM mkplprice_dummy WHERE UNIQ_ID = 'b'`, sql.NullString{String: "P-1", Valid: true}},
+	}
+	for _, tt := range tests {
+		var got sql.NullString
+		if err := conn.QueryRow(tt.query).Scan(&got); err != nil {
+			t.Fatalf("%s: %v", tt.query, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestSQLiteDecimalAmountsStopOnBadValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		insert string
+		want   string
+	}{
+		{"five decimals", `INSERT INTO m_price_dummy (PRICE_VALUE) VALUES ('1.23456')`, "m_price_dummy.PRICE_VALUE = '1.23456' (1 rows)"},
+		{"decimal comma", `INSERT INTO m_price_dummy (PRICE_VALUE) VALUES ('12,5')`, "m_price_dummy.PRICE_VALUE = '12,5' (1 rows)"},
+		{"not a number", `INSERT INTO fpiutang_temp (INVAMOUNT) VALUES ('n/a')`, "fpiutang_temp.INVAMOUNT = 'n/a' (1 rows)"},
+		{"too large", `INSERT INTO m_price_dummy (PRICE_VALUE) VALUES ('1000000000000000')`, "m_price_dummy.PRICE_VALUE = '1000000000000000' (1 rows)"},
+		{"real with five decimals", `INSERT INTO sap_web_inv_sfa (PRICE) VALUES (0.00001)`, "sap_web_inv_sfa.PRICE = '1.0e-05' (1 rows)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, conn := sqliteAt(t, 6)
+			exec(t, conn, tt.insert)
+
+			_, err := m.Up(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to list %s", err, tt.want)
+			}
+			if v := version(t, conn); v != 6 {
+				t.Errorf("version = %d, want 6", v)
+			}
+		})
+	}
+}
+
+func TestSQLiteConditionDates(t *testing.T) {
+	m, conn := sqliteAt(t, 7)
+	exec(t, conn, `INSERT INTO DP_Z00001 (COUNTER, VALIDFROM, VALIDTO) VALUES ('1', 20260131, 99991231), ('2', 0, NULL)`)
+
+	if _, err := m.Up(context.Background()); err != nil {
+		t.Fatalf("Up: %v", err)
+	}
+	if typ := column(t, conn, "DP_Z00001", "VALIDFROM"); typ != "DATE" {
+		t.Errorf("VALIDFROM is %s, want DATE", typ)
+	}
+
+	tests := []struct {
+		counter, col string
+		want         sql.NullString
+	}{
+		{"1", "VALIDFROM", sql.NullString{String: "2026-01-31", Valid: true}},
+		{"1", "VALIDTO", sql.NullString{String: "9999-12-31", Valid: true}},
+		{"2", "VALIDFROM", sql.NullString{}},
+		{"2", "VALIDTO", sql.NullString{}},
+	}
+	for _, tt := range tests {
+		var got sql.NullString
+		if err := conn.QueryRow(`SELECT CAST(`+tt.col+` AS TEXT) FROM DP_Z00001 WHERE COUNTER = ?`, tt.counter).Scan(&got); err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("%s %s = %v, want %v", tt.counter, tt.col, got, tt.want)
+		}
+	}
+}
+
+func TestSQLiteConditionDatesStopOnBadValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		value int
+		want  string
+	}{
+		{"short", 2026013, "DP_Z00001.VALIDFROM = '2026013' (1 rows)"},
+		{"no such day", 20260230, "DP_Z00001.VALIDFROM = '20260230' (1 rows)"},
+		{"negative", -1, "DP_Z00001.VALIDFROM = '-1' (1 rows)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, conn := sqliteAt(t, 7)
+			exec(t, conn, `INSERT INTO DP_Z00001 (VALIDFROM, VALIDTO) VALUES (?, 20260131)`, tt.value)
+
+			_, err := m.Up(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want it to list %s", err, tt.want)
+			}
+			if v := version(t, conn); v != 7 {
+				t.Errorf("version = %d, want 7", v)
+			}
+			if typ := column(t, conn, "DP_Z00001", "VALIDFROM"); typ != "INTEGER" {
+				t.Errorf("VALIDFROM is %s, want INTEGER", typ)
+			}
+		})
+	}
+}
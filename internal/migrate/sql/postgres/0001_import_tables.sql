@@ -0,0 +1,647 @@
+-- Landing tables filled by the import writers (internal/worker).
+-- ON CONFLICT needs a unique index on every upsert key.
+
+CREATE TABLE IF NOT EXISTS "m_price_dummy" (
+	"UNIQ_ID" VARCHAR(255),
+	"LINE_NO" INTEGER,
+	"PRICE_CODE" VARCHAR(255),
+	"BRANCH_ID" VARCHAR(255),
+	"PCODE" VARCHAR(255),
+	"PRICE_VALUE" VARCHAR(255),
+	"PRICE_UOM" VARCHAR(255),
+	"CBY" VARCHAR(255),
+	"CDATE" TIMESTAMP,
+	"MBY" VARCHAR(255),
+	"MDATE" TIMESTAMP,
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS "fgharga" (
+	"GHARGA" VARCHAR(255),
+	"KET" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fgharga" ON "fgharga" ("GHARGA");
+
+CREATE TABLE IF NOT EXISTS "fcustmst" (
+	"CUSTNO" VARCHAR(255),
+	"DATA01" VARCHAR(255),
+	"CUSTNAME" VARCHAR(255),
+	"CUSTADD1" VARCHAR(255),
+	"CUSTADD2" VARCHAR(255),
+	"CCITY" VARCHAR(255),
+	"CCONTACT" VARCHAR(255),
+	"CPHONE1" VARCHAR(255),
+	"CFAXNO" VARCHAR(255),
+	"CTERM" VARCHAR(255),
+	"CLIMIT" INTEGER,
+	"FLAGLIMIT" VARCHAR(255),
+	"GDISC" VARCHAR(255),
+	"GRUPOUT" VARCHAR(255),
+	"TYPEOUT" VARCHAR(255),
+	"GHARGA" VARCHAR(255),
+	"FLAGPAY" VARCHAR(255),
+	"FLAGOUT" VARCHAR(255),
+	"RPP" INTEGER,
+	"LSALES" INTEGER,
+	"LDATETRS" VARCHAR(255),
+	"LOKASI" VARCHAR(255),
+	"DISTRIK" VARCHAR(255),
+	"BEAT" VARCHAR(255),
+	"SUBBEAT" VARCHAR(255),
+	"KLASIF" VARCHAR(255),
+	"KINDUS" VARCHAR(255),
+	"KPASAR" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"LA" VARCHAR(255),
+	"LG" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fcustmst" ON "fcustmst" ("CUSTNO", "KODECABANG");
+
+CREATE TABLE IF NOT EXISTS "fmaster" (
+	"PRLIN" VARCHAR(225),
+	"BRAND" VARCHAR(225),
+	"PCODE" VARCHAR(225),
+	"DATA1" VARCHAR(225),
+	"PCODENAME" VARCHAR(225),
+	"UNIT1" VARCHAR(225),
+	"UNIT2" VARCHAR(225),
+	"UNIT3" VARCHAR(225),
+	"UNIT4" VARCHAR(225),
+	"UNIT5" VARCHAR(225),
+	"CONVUNIT2" INTEGER,
+	"CONVUNIT3" INTEGER,
+	"CONVUNIT4" INTEGER,
+	"CONVUNIT5" INTEGER,
+	"PPN" INTEGER,
+	"FLAG_AKTIF" VARCHAR(225),
+	"FLAG_GIFT" VARCHAR(225),
+	"SHORTNAME1" VARCHAR(225),
+	"UOM1_BUY" VARCHAR(225),
+	"UOM2_BUY" VARCHAR(225),
+	"UOM3_BUY" VARCHAR(225),
+	"UOM4_BUY" VARCHAR(225),
+	"UOM5_BUY" VARCHAR(225),
+	"UOM_BASE" VARCHAR(225),
+	"UOM_MAIN" VARCHAR(225),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fmaster" ON "fmaster" ("PCODE");
+
+CREATE TABLE IF NOT EXISTS "fgrupout" (
+	"GROUPOUT" VARCHAR(255),
+	"GROUPNAME" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fgrupout" ON "fgrupout" ("GROUPOUT");
+
+CREATE TABLE IF NOT EXISTS "findustri" (
+	"INDUSID" VARCHAR(255),
+	"INDUSNAME" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_findustri" ON "findustri" ("INDUSID");
+
+CREATE TABLE IF NOT EXISTS "fsalesman" (
+	"SLSNO" VARCHAR(255),
+	"SLSNAME" VARCHAR(255),
+	"ALAMAT1" VARCHAR(255),
+	"ALAMAT2" VARCHAR(255),
+	"KOTA" VARCHAR(255),
+	"PENDIDIKAN" VARCHAR(255),
+	"TGLLAHIR" VARCHAR(255),
+	"TGLMASUK" VARCHAR(255),
+	"TGLTRANS" VARCHAR(255),
+	"SLSPASS" VARCHAR(255),
+	"EC1" VARCHAR(255),
+	"ITEM" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"ATASAN_ID" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fsalesman" ON "fsalesman" ("SLSNO", "KODECABANG");
+
+CREATE TABLE IF NOT EXISTS "sap_web_inv_sfa" (
+	"SLSNO" VARCHAR(255),
+	"CUSTNO" VARCHAR(255),
+	"SFA_ORDER_NO" VARCHAR(255),
+	"SFA_ORDER_DATE" VARCHAR(255),
+	"ORDERNO" VARCHAR(255),
+	"ORDER_DATE" VARCHAR(255),
+	"INVOICE_NO" VARCHAR(255),
+	"INVOICE_DATE" VARCHAR(255),
+	"PCODE" VARCHAR(255),
+	"QTY" INTEGER,
+	"PRICE" DOUBLE PRECISION,
+	"DISKON" DOUBLE PRECISION,
+	"KODECABANG" VARCHAR(255),
+	"INV_TYPE" VARCHAR(255),
+	"REF_CN" VARCHAR(255),
+	"INVAMOUNT" DOUBLE PRECISION,
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_sap_web_inv_sfa" ON "sap_web_inv_sfa" ("SLSNO", "CUSTNO", "SFA_ORDER_NO", "ORDERNO", "INVOICE_NO", "PCODE", "KODECABANG", "INV_TYPE");
+
+CREATE TABLE IF NOT EXISTS "fpiutang_temp" (
+	"CUSTNO" VARCHAR(255),
+	"INVNO" VARCHAR(255),
+	"INVDATE" VARCHAR(255),
+	"DUEDATE" VARCHAR(255),
+	"INVAMOUNT" VARCHAR(255),
+	"AMOUNTPAID" VARCHAR(255),
+	"SLSNO" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"INV_TYPE" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fpiutang_temp" ON "fpiutang_temp" ("CUSTNO", "INVNO", "SLSNO", "KODECABANG");
+
+CREATE TABLE IF NOT EXISTS "fstockbarang" (
+	"KG" VARCHAR(255),
+	"PCODE" VARCHAR(255),
+	"STOCK" INTEGER,
+	"KODECABANG" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fstockbarang" ON "fstockbarang" ("KG", "PCODE", "KODECABANG");
+
+CREATE TABLE IF NOT EXISTS "forder_hd_status" (
+	"TGLORDER" VARCHAR(255),
+	"ORDERNO" VARCHAR(255),
+	"SLSNO" VARCHAR(255),
+	"CUSTNO" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"ORDERNO_TOPUP" VARCHAR(255),
+	"PCODE" VARCHAR(255),
+	"STATUS" VARCHAR(255),
+	"STATUS_DETAIL" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_forder_hd_status" ON "forder_hd_status" ("TGLORDER", "ORDERNO", "SLSNO", "CUSTNO", "KODECABANG", "ORDERNO_TOPUP", "PCODE");
+
+CREATE TABLE IF NOT EXISTS "gm_cust_wilayah" (
+	"wc_district_id" VARCHAR(255),
+	"wc_wilayah_id" VARCHAR(255),
+	"wc_wilayah_desc" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_gm_cust_wilayah" ON "gm_cust_wilayah" ("wc_district_id", "wc_wilayah_id");
+
+CREATE TABLE IF NOT EXISTS "fcredit_limit" (
+	"CUSTNO" VARCHAR(255),
+	"CUSTNAME" VARCHAR(255),
+	"CREDIT_LIMIT" INTEGER,
+	"SISA_CREDIT_LIMIT" INTEGER,
+	"KODECABANG" VARCHAR(255),
+	"UPDATEBY" VARCHAR(255),
+	"UPDATEDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fcredit_limit" ON "fcredit_limit" ("CUSTNO", "KODECABANG");
+
+CREATE TABLE IF NOT EXISTS "fmst_custinv_d" (
+	"BID" VARCHAR(255),
+	"BNAME" VARCHAR(255),
+	"MUID" VARCHAR(255),
+	"MUNAME" VARCHAR(255),
+	"CUSTNO" VARCHAR(255),
+	"CUSTNAME" VARCHAR(255),
+	"INVNO" VARCHAR(255),
+	"INVDATE" VARCHAR(255),
+	"DUEDATE" VARCHAR(255),
+	"INV_AMOUNT" DOUBLE PRECISION,
+	"INV_OUTSTANDING" DOUBLE PRECISION,
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fmst_custinv_d" ON "fmst_custinv_d" ("BID", "MUID", "CUSTNO", "INVNO");
+
+CREATE TABLE IF NOT EXISTS "fmst_custinv_h" (
+	"BID" VARCHAR(225),
+	"BNAME" VARCHAR(225),
+	"MUID" VARCHAR(225),
+	"MUNAME" VARCHAR(225),
+	"CUSTNO" VARCHAR(225),
+	"CUSTNAME" VARCHAR(225),
+	"INV_TOTAL" VARCHAR(225),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fmst_custinv_h" ON "fmst_custinv_h" ("BID", "MUID", "CUSTNO");
+
+CREATE TABLE IF NOT EXISTS "ftypeout" (
+	"TYPE" VARCHAR(255),
+	"TYPENAME" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_ftypeout" ON "ftypeout" ("TYPE");
+
+CREATE TABLE IF NOT EXISTS "fdistrik" (
+	"KODECABANG" VARCHAR(255),
+	"DISTRIK" VARCHAR(255),
+	"DISTRIKNAME" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fdistrik" ON "fdistrik" ("DISTRIK", "KODECABANG");
+
+CREATE TABLE IF NOT EXISTS "fkategori" (
+	"KODE" VARCHAR(255),
+	"KET" VARCHAR(255),
+	"KODEDISTRIBUTOR" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fkategori" ON "fkategori" ("KODE", "KODEDISTRIBUTOR");
+
+CREATE TABLE IF NOT EXISTS "mkplprice_dummy" (
+	"UNIQ_ID" VARCHAR(255),
+	"LINE_NO" INTEGER,
+	"CUST_CODE" VARCHAR(255),
+	"BRANCH_ID" VARCHAR(255),
+	"PCODE" DOUBLE PRECISION,
+	"PRICE_VALUE" VARCHAR(255),
+	"PRICE_UOM" VARCHAR(255),
+	"CBY" VARCHAR(255),
+	"CDATE" TIMESTAMP,
+	"MBY" VARCHAR(255),
+	"MDATE" TIMESTAMP,
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS "gm_cust_market" (
+	"psr_pasar_id" VARCHAR(255),
+	"psr_long_desc" VARCHAR(255),
+	"psr_short_desc" VARCHAR(255),
+	"kodecabang" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_gm_cust_market" ON "gm_cust_market" ("psr_pasar_id", "kodecabang");
+
+CREATE TABLE IF NOT EXISTS "FMST_PAYTO" (
+	"CUSTNO" VARCHAR(255),
+	"CUSTNO_BIL" VARCHAR(255),
+	"DESC_CUSTNO_BIL" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_FMST_PAYTO" ON "FMST_PAYTO" ("KODECABANG", "CUSTNO");
+
+CREATE TABLE IF NOT EXISTS "fprovinsi" (
+	"PROVINSI_ID" VARCHAR(255),
+	"PROVINSI_NAME" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fprovinsi" ON "fprovinsi" ("PROVINSI_ID");
+
+CREATE TABLE IF NOT EXISTS "frute" (
+	"REGION" VARCHAR(255),
+	"CABANG" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"SLSNO" VARCHAR(255),
+	"NORUTE" VARCHAR(255),
+	"CUSTNO" VARCHAR(255),
+	"H1" VARCHAR(255),
+	"H2" VARCHAR(255),
+	"H3" VARCHAR(255),
+	"H4" VARCHAR(255),
+	"H5" VARCHAR(255),
+	"H6" VARCHAR(255),
+	"H7" VARCHAR(255),
+	"M1" VARCHAR(255),
+	"M2" VARCHAR(255),
+	"M3" VARCHAR(255),
+	"M4" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_frute" ON "frute" ("REGION", "CABANG", "KODECABANG", "SLSNO", "NORUTE", "CUSTNO");
+
+CREATE TABLE IF NOT EXISTS "fbrand" (
+	"BRAND" VARCHAR(255),
+	"BRANDNAME" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fbrand" ON "fbrand" ("BRAND", "KODECABANG");
+
+CREATE TABLE IF NOT EXISTS "fshippto" (
+	"CUSTNO" VARCHAR(255),
+	"CUSTNO_SHIP" VARCHAR(255),
+	"DESC_CUSTNO_SHIP" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fshippto" ON "fshippto" ("CUSTNO", "KODECABANG");
+
+CREATE TABLE IF NOT EXISTS "fprlin" (
+	"PRLIN" VARCHAR(255),
+	"PRLINAME" VARCHAR(255),
+	"KOMPFLAG" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fprlin" ON "fprlin" ("PRLIN");
+
+CREATE TABLE IF NOT EXISTS "gm_cust_rayon" (
+	"rc_district_id" VARCHAR(255),
+	"rc_wilayah_id" VARCHAR(255),
+	"rc_rayon_id" VARCHAR(255),
+	"rc_rayon_desc" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_gm_cust_rayon" ON "gm_cust_rayon" ("rc_district_id", "rc_wilayah_id", "rc_rayon_id");
+
+CREATE TABLE IF NOT EXISTS "fsubbrand" (
+	"KODE" VARCHAR(255),
+	"BRAND" VARCHAR(255),
+	"KET" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_fsubbrand" ON "fsubbrand" ("KODE", "BRAND");
+
+CREATE TABLE IF NOT EXISTS "ftop" (
+	"TOP" VARCHAR(255),
+	"TOP_DESC" VARCHAR(255),
+	"TOP_DAYS" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_ftop" ON "ftop" ("TOP");
+
+CREATE TABLE IF NOT EXISTS "DP_ZDHDR" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"CONDITIONTYPE" VARCHAR(255),
+	"KEYCOMBINATION" VARCHAR(255),
+	"KEYCOMB" VARCHAR(255),
+	"SALESORGANIZATION" VARCHAR(255),
+	"DISTRIBUTIONCHANNEL" VARCHAR(255),
+	"SALESOFFICE" VARCHAR(255),
+	"DIVISION" VARCHAR(255),
+	"PAYMENTTERM" VARCHAR(255),
+	"CUSTOMER" VARCHAR(255),
+	"MATERIAL" VARCHAR(255),
+	"ATTRIBUT2" VARCHAR(255),
+	"VALIDUNTIL" TIMESTAMP,
+	"VALIDFROM" TIMESTAMP,
+	"CONDITIONRECORDNO" VARCHAR(255),
+	"SCALE" VARCHAR(255),
+	"FILENAME" VARCHAR(255),
+	"LINENUMBER" INTEGER,
+	"CDATE" TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS "DP_ZDITM" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"CONDITIONTYPE" VARCHAR(255),
+	"KEYCOMBINATION" VARCHAR(255),
+	"KEYCOMB" VARCHAR(255),
+	"SALESORGANIZATION" VARCHAR(255),
+	"DISTRIBUTIONCHANNEL" VARCHAR(255),
+	"SALESOFFICE" VARCHAR(255),
+	"DIVISION" VARCHAR(255),
+	"SOLDTOPARTY" VARCHAR(255),
+	"PRICINGREFMATL" VARCHAR(255),
+	"PAYMENTTERMS" VARCHAR(255),
+	"INDUSTRYCODE3" VARCHAR(255),
+	"INDUSTRYCODE4" VARCHAR(255),
+	"INDUSTRYCODE5" VARCHAR(255),
+	"ATTRIBUTE1" VARCHAR(255),
+	"ATTRIBUTE2" VARCHAR(255),
+	"MATERIAL" VARCHAR(255),
+	"SALESUNIT" VARCHAR(255),
+	"VALIDFROM" TIMESTAMP,
+	"VALIDUNTIL" TIMESTAMP,
+	"CONDITIONRECORDNO" VARCHAR(255),
+	"SCALE" VARCHAR(255),
+	"FILENAME" VARCHAR(255),
+	"LINENUMBER" INTEGER,
+	"CDATE" TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS "DP_ZDDET" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"CONDITIONRECORDNO" VARCHAR(255),
+	"AMOUNT" DOUBLE PRECISION,
+	"UNIT" VARCHAR(255),
+	"PER" DOUBLE PRECISION,
+	"UOM" VARCHAR(255),
+	"SCALE" VARCHAR(255),
+	"FILENAME" VARCHAR(255),
+	"LINENUMBER" INTEGER,
+	"CDATE" TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS "DP_ZPMIX" (
+	"PROCESS_ID" VARCHAR(50),
+	"BLOCKID" VARCHAR(3),
+	"BLOCKNAME" VARCHAR(50),
+	"CTYP" VARCHAR(20),
+	"KEYCOMBINATION" VARCHAR(20),
+	"SORG" VARCHAR(20),
+	"DCHL" VARCHAR(20),
+	"SOFF" VARCHAR(20),
+	"DV" VARCHAR(20),
+	"CUSTOMER" VARCHAR(20),
+	"INDCODE2" VARCHAR(20),
+	"INDCODE3" VARCHAR(20),
+	"INDCODE4" VARCHAR(20),
+	"INDCODE5" VARCHAR(20),
+	"PL" VARCHAR(20),
+	"PAYT" VARCHAR(20),
+	"MATERIAL" VARCHAR(20),
+	"VALIDFROM" DATE,
+	"VALIDUNTIL" DATE,
+	"PROMOID" VARCHAR(20),
+	"LINEITEM" INTEGER,
+	"FILENAME" VARCHAR(200),
+	"LINENUMBER" BIGINT,
+	"CDATE" TIMESTAMP,
+	"MUSTBUY" VARCHAR(5),
+	"EXCLUDE" VARCHAR(5),
+	"SPLIT" VARCHAR(5),
+	"AMOUNTX" VARCHAR(1),
+	"RANGEX" VARCHAR(5),
+	"WITHMATERIAL" VARCHAR(5),
+	"KELIPATAN" VARCHAR(5),
+	"V_KELIPATAN" INTEGER,
+	"ATTR_PRD_LV2" VARCHAR(20),
+	"ATTR_PRD_LV3" VARCHAR(20),
+	"FL_CUST_EXC" VARCHAR(20),
+	"CUST_EXC" VARCHAR(20),
+	"FL_HD" VARCHAR(100),
+	"PERBANDINGAN" VARCHAR(20),
+	"V_PERBANDINGAN1" INTEGER,
+	"V_PERBANDINGAN2" INTEGER
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_DP_ZPMIX" ON "DP_ZPMIX" ("BLOCKID", "PROMOID", "LINEITEM", "CTYP", "KEYCOMBINATION", "SORG", "DCHL", "SOFF", "DV", "CUSTOMER", "PL", "PAYT", "MATERIAL", "INDCODE2", "INDCODE3", "INDCODE4", "INDCODE5", "CUST_EXC");
+
+CREATE TABLE IF NOT EXISTS "DP_FG_CHECK" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"PROMOID" VARCHAR(255),
+	"DDATE" DATE,
+	"CDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_DP_FG_CHECK" ON "DP_FG_CHECK" ("BLOCKID", "PROMOID", "DDATE");
+
+CREATE TABLE IF NOT EXISTS "DP_ZSCREG" (
+	"PROCESS_ID" VARCHAR(50),
+	"BLOCKID" VARCHAR(3),
+	"BLOCKNAME" VARCHAR(50),
+	"CONDITIONRECORDNO" VARCHAR(20),
+	"NO" INTEGER,
+	"LSNO" INTEGER,
+	"DISCREGHDRQTY" NUMERIC(19,4),
+	"AMOUNT" NUMERIC(19,4),
+	"UNIT" VARCHAR(25),
+	"FILENAME" VARCHAR(200),
+	"LINENUMBER" BIGINT,
+	"CDATE" TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_DP_ZSCREG" ON "DP_ZSCREG" ("BLOCKID", "CONDITIONRECORDNO", "DISCREGHDRQTY");
+
+CREATE TABLE IF NOT EXISTS "DP_ZSCMIX" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"PROMOID" VARCHAR(255),
+	"LINEITEM" INTEGER,
+	"SCALEQTY" DOUBLE PRECISION,
+	"BUN" VARCHAR(255),
+	"AMOUNT" DOUBLE PRECISION,
+	"UNIT" VARCHAR(255),
+	"PER" DOUBLE PRECISION,
+	"UOM" VARCHAR(255),
+	"FILENAME" VARCHAR(255),
+	"LINENUMBER" INTEGER,
+	"CDATE" TIMESTAMP,
+	"SCALEQTYTO" DOUBLE PRECISION,
+	"AMOUNTSCL" DOUBLE PRECISION,
+	"AMOUNTSCLTO" DOUBLE PRECISION,
+	"UNITSCL" VARCHAR(255),
+	"MATNRKENA" VARCHAR(255)
+);
+
+CREATE TABLE IF NOT EXISTS "DP_Z00001" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"STEP" VARCHAR(255),
+	"COUNTER" VARCHAR(255),
+	"CONDITIONTYPE" VARCHAR(255),
+	"DESCRIPTION" VARCHAR(255),
+	"VALIDFROM" INTEGER,
+	"VALIDTO" INTEGER,
+	"CONDGRP" VARCHAR(255),
+	"DRULE" VARCHAR(255),
+	"FILENAME" VARCHAR(255),
+	"LINENUMBER" INTEGER,
+	"CDATE" TIMESTAMP,
+	"DISCTYPE" VARCHAR(255)
+);
+
+CREATE TABLE IF NOT EXISTS "FG_ZDHDR" (
+	"PROCESS_ID" VARCHAR(50),
+	"BLOCKID" VARCHAR(3),
+	"BLOCKNAME" VARCHAR(50),
+	"CONDITIONTYPE" VARCHAR(20),
+	"KEYCOMBINATION" VARCHAR(20),
+	"KEYCOMB" VARCHAR(180),
+	"SALESORGANIZATION" VARCHAR(20),
+	"DISTRIBUTIONCHANNEL" VARCHAR(20),
+	"DIVISION" VARCHAR(20),
+	"SALESOFFICE" VARCHAR(20),
+	"PRICELISTTYPE" VARCHAR(20),
+	"ATTRIBUTE1" VARCHAR(20),
+	"INDUSTRYCODE3" VARCHAR(20),
+	"INDUSTRYCODE4" VARCHAR(20),
+	"INDUSTRYCODE5" VARCHAR(20),
+	"SOLDTOPARTY" VARCHAR(20),
+	"MATERIAL" VARCHAR(20),
+	"VALIDUNTIL" DATE,
+	"VALIDFROM" DATE,
+	"CONDITIONRECORDNO" VARCHAR(20),
+	"PROMOID" VARCHAR(20),
+	"PROMOITEM" VARCHAR(20),
+	"SCALE" VARCHAR(3),
+	"FILENAME" VARCHAR(100),
+	"LINENUMBER" BIGINT,
+	"CDATE" TIMESTAMP,
+	"MUSTBUY" VARCHAR(5),
+	"KELIPATAN" VARCHAR(5),
+	"F_KELIPATAN" INTEGER,
+	"WITHQTY" VARCHAR(20),
+	"QTY" INTEGER,
+	"UOM" DOUBLE PRECISION,
+	"ZTERM" VARCHAR(5),
+	"KATR2" VARCHAR(20),
+	"KATR3" VARCHAR(20),
+	"PERBANDINGAN" VARCHAR(20),
+	"F_PERBANDINGAN1" INTEGER,
+	"F_PERBANDINGAN2" INTEGER,
+	"AMOUNTX" VARCHAR(1)
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "ux_FG_ZDHDR" ON "FG_ZDHDR" ("BLOCKID", "PROMOID", "PROMOITEM", "CONDITIONRECORDNO", "CONDITIONTYPE", "KEYCOMBINATION", "SALESORGANIZATION", "DISTRIBUTIONCHANNEL", "DIVISION", "SALESOFFICE", "PRICELISTTYPE", "ATTRIBUTE1", "INDUSTRYCODE3", "INDUSTRYCODE4", "INDUSTRYCODE5", "SOLDTOPARTY", "MATERIAL", "ZTERM", "KATR2", "KATR3");
+
+CREATE TABLE IF NOT EXISTS "FG_ZFRDET" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"CONDITIONRECORDNO" VARCHAR(255),
+	"MINIMUMQTY" DOUBLE PRECISION,
+	"FREEGOODSQTY" DOUBLE PRECISION,
+	"UOMFREEGOODS" VARCHAR(255),
+	"FREEGOODSAGRREDQTY" DOUBLE PRECISION,
+	"UOMFREEGOODSAGRRED" VARCHAR(255),
+	"ADDITIONALMATERIAL" VARCHAR(255),
+	"FILENAME" VARCHAR(255),
+	"LINENUMBER" INTEGER,
+	"CDATE" TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS "FG_ZFRMIX" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"PROMOID" VARCHAR(255),
+	"PROMOITEM" VARCHAR(255),
+	"SCALEQTY" DOUBLE PRECISION,
+	"SCALEQTYUOM" VARCHAR(255),
+	"MATERIAL" VARCHAR(255),
+	"QTY" DOUBLE PRECISION,
+	"QTYUOM" VARCHAR(255),
+	"FILENAME" VARCHAR(255),
+	"LINENUMBER" INTEGER,
+	"CDATE" TIMESTAMP,
+	"AMOUNTSCLF" DOUBLE PRECISION,
+	"CURRENCY" VARCHAR(255)
+);
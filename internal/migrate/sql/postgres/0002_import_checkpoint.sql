@@ -0,0 +1,10 @@
+-- Rows committed per process, table and source file by batched loads.
+
+CREATE TABLE IF NOT EXISTS import_checkpoint (
+	process_id VARCHAR(36) NOT NULL,
+	table_name VARCHAR(128) NOT NULL,
+	file_name VARCHAR(255) NOT NULL,
+	rows_committed BIGINT NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (process_id, table_name, file_name)
+);
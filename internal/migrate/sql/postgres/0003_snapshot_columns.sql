@@ -0,0 +1,10 @@
+-- Markers for snapshot upserts (config snapshots): IS_ACTIVE for mode
+-- deactivate, DELETED_AT for mode flag.
+
+ALTER TABLE "fcustmst"
+	ADD COLUMN IF NOT EXISTS "IS_ACTIVE" SMALLINT NOT NULL DEFAULT 1,
+	ADD COLUMN IF NOT EXISTS "DELETED_AT" TIMESTAMP;
+
+ALTER TABLE "fmaster"
+	ADD COLUMN IF NOT EXISTS "IS_ACTIVE" SMALLINT NOT NULL DEFAULT 1,
+	ADD COLUMN IF NOT EXISTS "DELETED_AT" TIMESTAMP;
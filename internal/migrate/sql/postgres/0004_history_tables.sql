@@ -0,0 +1,77 @@
+-- Slowly changing dimension (type 2) history of the master tables, filled
+-- by upserts of the blocks listed under history. A version is open while
+-- VALID_TO is NULL.
+
+CREATE TABLE IF NOT EXISTS "fcustmst_history" (
+	"CUSTNO" VARCHAR(255),
+	"DATA01" VARCHAR(255),
+	"CUSTNAME" VARCHAR(255),
+	"CUSTADD1" VARCHAR(255),
+	"CUSTADD2" VARCHAR(255),
+	"CCITY" VARCHAR(255),
+	"CCONTACT" VARCHAR(255),
+	"CPHONE1" VARCHAR(255),
+	"CFAXNO" VARCHAR(255),
+	"CTERM" VARCHAR(255),
+	"CLIMIT" INTEGER,
+	"FLAGLIMIT" VARCHAR(255),
+	"GDISC" VARCHAR(255),
+	"GRUPOUT" VARCHAR(255),
+	"TYPEOUT" VARCHAR(255),
+	"GHARGA" VARCHAR(255),
+	"FLAGPAY" VARCHAR(255),
+	"FLAGOUT" VARCHAR(255),
+	"RPP" INTEGER,
+	"LSALES" INTEGER,
+	"LDATETRS" VARCHAR(255),
+	"LOKASI" VARCHAR(255),
+	"DISTRIK" VARCHAR(255),
+	"BEAT" VARCHAR(255),
+	"SUBBEAT" VARCHAR(255),
+	"KLASIF" VARCHAR(255),
+	"KINDUS" VARCHAR(255),
+	"KPASAR" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"LA" VARCHAR(255),
+	"LG" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP,
+	"VALID_FROM" TIMESTAMP NOT NULL,
+	"VALID_TO" TIMESTAMP,
+	"PROCESS_ID" VARCHAR(36)
+);
+CREATE INDEX IF NOT EXISTS "ix_fcustmst_history_key" ON "fcustmst_history" ("CUSTNO", "KODECABANG", "VALID_TO");
+
+CREATE TABLE IF NOT EXISTS "fmaster_history" (
+	"PRLIN" VARCHAR(225),
+	"BRAND" VARCHAR(225),
+	"PCODE" VARCHAR(225),
+	"DATA1" VARCHAR(225),
+	"PCODENAME" VARCHAR(225),
+	"UNIT1" VARCHAR(225),
+	"UNIT2" VARCHAR(225),
+	"UNIT3" VARCHAR(225),
+	"UNIT4" VARCHAR(225),
+	"UNIT5" VARCHAR(225),
+	"CONVUNIT2" INTEGER,
+	"CONVUNIT3" INTEGER,
+	"CONVUNIT4" INTEGER,
+	"CONVUNIT5" INTEGER,
+	"PPN" INTEGER,
+	"FLAG_AKTIF" VARCHAR(225),
+	"FLAG_GIFT" VARCHAR(225),
+	"SHORTNAME1" VARCHAR(225),
+	"UOM1_BUY" VARCHAR(225),
+	"UOM2_BUY" VARCHAR(225),
+	"UOM3_BUY" VARCHAR(225),
+	"UOM4_BUY" VARCHAR(225),
+	"UOM5_BUY" VARCHAR(225),
+	"UOM_BASE" VARCHAR(225),
+	"UOM_MAIN" VARCHAR(225),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" TIMESTAMP,
+	"VALID_FROM" TIMESTAMP NOT NULL,
+	"VALID_TO" TIMESTAMP,
+	"PROCESS_ID" VARCHAR(36)
+);
+CREATE INDEX IF NOT EXISTS "ix_fmaster_history_key" ON "fmaster_history" ("PCODE", "VALID_TO");
@@ -0,0 +1,15 @@
+-- Prices per unit level, converted from PRICE_UOM by the parser.
+
+ALTER TABLE "m_price_dummy"
+	ADD COLUMN IF NOT EXISTS "SELPRICE1" NUMERIC(19,4),
+	ADD COLUMN IF NOT EXISTS "SELPRICE2" NUMERIC(19,4),
+	ADD COLUMN IF NOT EXISTS "SELPRICE3" NUMERIC(19,4),
+	ADD COLUMN IF NOT EXISTS "SELPRICE4" NUMERIC(19,4),
+	ADD COLUMN IF NOT EXISTS "SELPRICE5" NUMERIC(19,4);
+
+ALTER TABLE "mkplprice_dummy"
+	ADD COLUMN IF NOT EXISTS "SELLPRICE1" NUMERIC(19,4),
+	ADD COLUMN IF NOT EXISTS "SELLPRICE2" NUMERIC(19,4),
+	ADD COLUMN IF NOT EXISTS "SELLPRICE3" NUMERIC(19,4),
+	ADD COLUMN IF NOT EXISTS "SELLPRICE4" NUMERIC(19,4),
+	ADD COLUMN IF NOT EXISTS "SELLPRICE5" NUMERIC(19,4);
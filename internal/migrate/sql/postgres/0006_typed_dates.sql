@@ -0,0 +1,58 @@
+-- File dates are stored as DATE instead of the text they were read as.
+-- Only the empty-date sentinels '' and '00000000' become NULL. Any other
+-- value that is not YYYYMMDD or YYYY-MM-DD stops the migration before a
+-- column is changed, with the values and how many rows hold them; correct
+-- or clear those rows and run it again.
+
+CREATE OR REPLACE FUNCTION pg_temp.try_date(v text) RETURNS date
+LANGUAGE plpgsql AS $$
+BEGIN
+	IF v !~ '^[0-9]{4}-?[0-9]{2}-?[0-9]{2}$' THEN
+		RETURN NULL;
+	END IF;
+	RETURN v::date;
+EXCEPTION WHEN others THEN
+	RETURN NULL;
+END $$;
+
+DO $$
+DECLARE
+	cols text[] := ARRAY[
+		['sap_web_inv_sfa', 'SFA_ORDER_DATE'],
+		['sap_web_inv_sfa', 'ORDER_DATE'],
+		['sap_web_inv_sfa', 'INVOICE_DATE'],
+		['fpiutang_temp', 'INVDATE'],
+		['fpiutang_temp', 'DUEDATE'],
+		['forder_hd_status', 'TGLORDER'],
+		['fmst_custinv_d', 'INVDATE'],
+		['fmst_custinv_d', 'DUEDATE']
+	];
+	c text[];
+	r record;
+	bad text := '';
+BEGIN
+	FOREACH c SLICE 1 IN ARRAY cols LOOP
+		FOR r IN EXECUTE format(
+			'SELECT %2$I AS v, count(*) AS n FROM %1$I
+			WHERE %2$I IS NOT NULL
+				AND trim(%2$I) NOT IN ('''', ''00000000'')
+				AND pg_temp.try_date(trim(%2$I)) IS NULL
+			GROUP BY %2$I ORDER BY %2$I', c[1], c[2])
+		LOOP
+			bad := bad || format('; %s.%s = %L (%s rows)', c[1], c[2], left(r.v, 100), r.n);
+		END LOOP;
+	END LOOP;
+
+	IF bad <> '' THEN
+		RAISE EXCEPTION 'values that are not a date, correct or clear them first: %', substr(bad, 3);
+	END IF;
+
+	FOREACH c SLICE 1 IN ARRAY cols LOOP
+		EXECUTE format(
+			'ALTER TABLE %1$I ALTER COLUMN %2$I TYPE DATE USING
+				CASE WHEN trim(%2$I) IN ('''', ''00000000'') THEN NULL ELSE pg_temp.try_date(trim(%2$I)) END',
+			c[1], c[2]);
+	END LOOP;
+END $$;
+
+DROP FUNCTION pg_temp.try_date(text);
@@ -0,0 +1,92 @@
+-- Amounts, prices and quantities are stored as NUMERIC(19,4) instead of
+-- DOUBLE PRECISION, INTEGER or text. Nothing is rounded and only blank text
+-- becomes NULL: a value that is not a number, is too large or has more than
+-- 4 decimals stops the migration before a column is changed, with the
+-- values and how many rows hold them. Correct or clear those rows and run
+-- it again.
+
+CREATE OR REPLACE FUNCTION pg_temp.try_decimal(v text) RETURNS numeric
+LANGUAGE plpgsql AS $$
+BEGIN
+	IF v !~ '^\s*[-+]?([0-9]+\.?[0-9]*|\.[0-9]+)([eE][-+]?[0-9]+)?\s*$' THEN
+		RETURN NULL;
+	END IF;
+	RETURN v::numeric;
+EXCEPTION WHEN others THEN
+	RETURN NULL;
+END $$;
+
+DO $$
+DECLARE
+	cols text[] := ARRAY[
+		['m_price_dummy', 'PRICE_VALUE'],
+		['mkplprice_dummy', 'PRICE_VALUE'],
+		['fcustmst', 'CLIMIT'],
+		['fcustmst_history', 'CLIMIT'],
+		['sap_web_inv_sfa', 'QTY'],
+		['sap_web_inv_sfa', 'PRICE'],
+		['sap_web_inv_sfa', 'DISKON'],
+		['sap_web_inv_sfa', 'INVAMOUNT'],
+		['fstockbarang', 'STOCK'],
+		['fpiutang_temp', 'INVAMOUNT'],
+		['fpiutang_temp', 'AMOUNTPAID'],
+		['fcredit_limit', 'CREDIT_LIMIT'],
+		['fcredit_limit', 'SISA_CREDIT_LIMIT'],
+		['fmst_custinv_d', 'INV_AMOUNT'],
+		['fmst_custinv_d', 'INV_OUTSTANDING'],
+		['DP_ZDDET', 'AMOUNT'],
+		['DP_ZDDET', 'PER'],
+		['DP_ZSCMIX', 'SCALEQTY'],
+		['DP_ZSCMIX', 'AMOUNT'],
+		['DP_ZSCMIX', 'PER'],
+		['DP_ZSCMIX', 'SCALEQTYTO'],
+		['DP_ZSCMIX', 'AMOUNTSCL'],
+		['DP_ZSCMIX', 'AMOUNTSCLTO'],
+		['FG_ZDHDR', 'QTY'],
+		['FG_ZDHDR', 'UOM'],
+		['FG_ZFRDET', 'MINIMUMQTY'],
+		['FG_ZFRDET', 'FREEGOODSQTY'],
+		['FG_ZFRDET', 'FREEGOODSAGRREDQTY'],
+		['FG_ZFRMIX', 'SCALEQTY'],
+		['FG_ZFRMIX', 'QTY'],
+		['FG_ZFRMIX', 'AMOUNTSCLF']
+	];
+	c text[];
+	r record;
+	bad text := '';
+BEGIN
+	-- A value converts when it is a number below 10^15 whose digits after
+	-- the fourth decimal are all zero. DOUBLE PRECISION values are read as
+	-- the shortest text that identifies them, so 0.1 is 0.1.
+	FOREACH c SLICE 1 IN ARRAY cols LOOP
+		FOR r IN EXECUTE format(
+			'SELECT v, count(*) AS n FROM (
+				SELECT %2$I::text AS v, pg_temp.try_decimal(%2$I::text) AS d FROM %1$I
+			) t
+			WHERE v IS NOT NULL
+				AND trim(v) <> ''''
+				AND (d IS NULL OR d <> trunc(d, 4) OR abs(d) >= 1e15)
+			GROUP BY v ORDER BY v', c[1], c[2])
+		LOOP
+			bad := bad || format('; %s.%s = %L (%s rows)', c[1], c[2], left(r.v, 100), r.n);
+		END LOOP;
+	END LOOP;
+
+	IF bad <> '' THEN
+		RAISE EXCEPTION 'values that do not fit NUMERIC(19,4) exactly, correct or clear them first: %', substr(bad, 3);
+	END IF;
+
+	FOREACH c SLICE 1 IN ARRAY cols LOOP
+		EXECUTE format(
+			'ALTER TABLE %1$I ALTER COLUMN %2$I TYPE NUMERIC(19,4) USING
+				CASE WHEN trim(%2$I::text) = '''' THEN NULL ELSE pg_temp.try_decimal(%2$I::text) END',
+			c[1], c[2]);
+	END LOOP;
+END $$;
+
+DROP FUNCTION pg_temp.try_decimal(text);
+
+-- PCODE of the MKPLPRICE rows was declared DOUBLE PRECISION and written out
+-- of order.
+ALTER TABLE "mkplprice_dummy"
+	ALTER COLUMN "PCODE" TYPE VARCHAR(255);
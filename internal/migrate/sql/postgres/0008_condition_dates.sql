@@ -0,0 +1,44 @@
+-- The validity dates of DP_Z00001 (block 126) are stored as DATE instead
+-- of the YYYYMMDD number they were read as. 0, which the handler stored
+-- for an empty or unreadable date, becomes NULL. Any other value that is
+-- not a date stops the migration before a column is changed, with the
+-- values and how many rows hold them; correct or clear those rows and run
+-- it again.
+
+CREATE OR REPLACE FUNCTION pg_temp.try_date(v text) RETURNS date
+LANGUAGE plpgsql AS $$
+BEGIN
+	IF v !~ '^[0-9]{8}$' THEN
+		RETURN NULL;
+	END IF;
+	RETURN v::date;
+EXCEPTION WHEN others THEN
+	RETURN NULL;
+END $$;
+
+DO $$
+DECLARE
+	r record;
+	bad text := '';
+BEGIN
+	FOR r IN
+		SELECT col, val, count(*) AS n
+		FROM "DP_Z00001"
+		CROSS JOIN LATERAL (VALUES ('VALIDFROM', "VALIDFROM"), ('VALIDTO', "VALIDTO")) v (col, val)
+		WHERE val <> 0 AND pg_temp.try_date(val::text) IS NULL
+		GROUP BY col, val
+		ORDER BY col, val
+	LOOP
+		bad := bad || format('; DP_Z00001.%s = %L (%s rows)', r.col, r.val::text, r.n);
+	END LOOP;
+
+	IF bad <> '' THEN
+		RAISE EXCEPTION 'values that are not a date, correct or clear them first: %', substr(bad, 3);
+	END IF;
+END $$;
+
+ALTER TABLE "DP_Z00001"
+	ALTER COLUMN "VALIDFROM" TYPE DATE USING pg_temp.try_date(NULLIF("VALIDFROM", 0)::text),
+	ALTER COLUMN "VALIDTO" TYPE DATE USING pg_temp.try_date(NULLIF("VALIDTO", 0)::text);
+
+DROP FUNCTION pg_temp.try_date(text);
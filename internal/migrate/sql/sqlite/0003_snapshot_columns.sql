@@ -0,0 +1,8 @@
+-- Markers for snapshot upserts (config snapshots): IS_ACTIVE for mode
+-- deactivate, DELETED_AT for mode flag.
+
+ALTER TABLE "fcustmst" ADD COLUMN "IS_ACTIVE" INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE "fcustmst" ADD COLUMN "DELETED_AT" DATETIME;
+
+ALTER TABLE "fmaster" ADD COLUMN "IS_ACTIVE" INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE "fmaster" ADD COLUMN "DELETED_AT" DATETIME;
@@ -0,0 +1,13 @@
+-- Prices per unit level, converted from PRICE_UOM by the parser.
+
+ALTER TABLE "m_price_dummy" ADD COLUMN "SELPRICE1" NUMERIC(19,4);
+ALTER TABLE "m_price_dummy" ADD COLUMN "SELPRICE2" NUMERIC(19,4);
+ALTER TABLE "m_price_dummy" ADD COLUMN "SELPRICE3" NUMERIC(19,4);
+ALTER TABLE "m_price_dummy" ADD COLUMN "SELPRICE4" NUMERIC(19,4);
+ALTER TABLE "m_price_dummy" ADD COLUMN "SELPRICE5" NUMERIC(19,4);
+
+ALTER TABLE "mkplprice_dummy" ADD COLUMN "SELLPRICE1" NUMERIC(19,4);
+ALTER TABLE "mkplprice_dummy" ADD COLUMN "SELLPRICE2" NUMERIC(19,4);
+ALTER TABLE "mkplprice_dummy" ADD COLUMN "SELLPRICE3" NUMERIC(19,4);
+ALTER TABLE "mkplprice_dummy" ADD COLUMN "SELLPRICE4" NUMERIC(19,4);
+ALTER TABLE "mkplprice_dummy" ADD COLUMN "SELLPRICE5" NUMERIC(19,4);
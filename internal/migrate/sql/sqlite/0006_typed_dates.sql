@@ -0,0 +1,242 @@
+-- File dates are stored as DATE instead of the text they were read as.
+-- Only the empty-date sentinels '' and '00000000' become NULL and YYYYMMDD
+-- becomes YYYY-MM-DD. Any other value that is not a date stops the
+-- migration before a table is changed, with the values and how many rows
+-- hold them; correct or clear those rows and run it again. SQLite cannot
+-- change a column's type, so each table is rebuilt with the same columns
+-- and indexes.
+
+-- RAISE takes an expression, which lets the check report the values.
+CREATE TEMP TABLE migration_error (msg TEXT);
+CREATE TEMP TRIGGER migration_error BEFORE INSERT ON migration_error
+BEGIN
+	SELECT RAISE(ABORT, NEW.msg);
+END;
+
+WITH vals (name, v) AS (
+	SELECT 'sap_web_inv_sfa.SFA_ORDER_DATE', trim("SFA_ORDER_DATE") FROM "sap_web_inv_sfa"
+	UNION ALL
+	SELECT 'sap_web_inv_sfa.ORDER_DATE', trim("ORDER_DATE") FROM "sap_web_inv_sfa"
+	UNION ALL
+	SELECT 'sap_web_inv_sfa.INVOICE_DATE', trim("INVOICE_DATE") FROM "sap_web_inv_sfa"
+	UNION ALL
+	SELECT 'fpiutang_temp.INVDATE', trim("INVDATE") FROM "fpiutang_temp"
+	UNION ALL
+	SELECT 'fpiutang_temp.DUEDATE', trim("DUEDATE") FROM "fpiutang_temp"
+	UNION ALL
+	SELECT 'forder_hd_status.TGLORDER', trim("TGLORDER") FROM "forder_hd_status"
+	UNION ALL
+	SELECT 'fmst_custinv_d.INVDATE', trim("INVDATE") FROM "fmst_custinv_d"
+	UNION ALL
+	SELECT 'fmst_custinv_d.DUEDATE', trim("DUEDATE") FROM "fmst_custinv_d"
+), parsed (name, v, d) AS (
+	SELECT name, v,
+		CASE
+			WHEN v GLOB '[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]' THEN substr(v, 1, 4) || '-' || substr(v, 5, 2) || '-' || substr(v, 7, 2)
+			WHEN v GLOB '[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]*' THEN substr(v, 1, 10)
+		END
+	FROM vals
+	WHERE v NOT IN ('', '00000000')
+), bad (name, v, n) AS (
+	SELECT name, v, count(*) FROM parsed
+	WHERE d IS NULL OR date(d) IS NOT d
+	GROUP BY name, v
+)
+INSERT INTO migration_error (msg)
+SELECT 'values that are not a date, correct or clear them first: '
+	|| group_concat(name || ' = ''' || substr(v, 1, 100) || ''' (' || n || ' rows)', '; ')
+FROM bad
+HAVING count(*) > 0;
+
+DROP TRIGGER migration_error;
+DROP TABLE migration_error;
+
+UPDATE "sap_web_inv_sfa" SET
+	"SFA_ORDER_DATE" = CASE
+		WHEN trim("SFA_ORDER_DATE") IN ('', '00000000') THEN NULL
+		WHEN trim("SFA_ORDER_DATE") GLOB '[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]' THEN substr(trim("SFA_ORDER_DATE"), 1, 4) || '-' || substr(trim("SFA_ORDER_DATE"), 5, 2) || '-' || substr(trim("SFA_ORDER_DATE"), 7, 2)
+		ELSE "SFA_ORDER_DATE"
+	END,
+	"ORDER_DATE" = CASE
+		WHEN trim("ORDER_DATE") IN ('', '00000000') THEN NULL
+		WHEN trim("ORDER_DATE") GLOB '[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]' THEN substr(trim("ORDER_DATE"), 1, 4) || '-' || substr(trim("ORDER_DATE"), 5, 2) || '-' || substr(trim("ORDER_DATE"), 7, 2)
+		ELSE "ORDER_DATE"
+	END,
+	"INVOICE_DATE" = CASE
+		WHEN trim("INVOICE_DATE") IN ('', '00000000') THEN NULL
+		WHEN trim("INVOICE_DATE") GLOB '[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]' THEN substr(trim("INVOICE_DATE"), 1, 4) || '-' || substr(trim("INVOICE_DATE"), 5, 2) || '-' || substr(trim("INVOICE_DATE"), 7, 2)
+		ELSE "INVOICE_DATE"
+	END;
+
+CREATE TABLE "sap_web_inv_sfa__new" (
+	"SLSNO" VARCHAR(255),
+	"CUSTNO" VARCHAR(255),
+	"SFA_ORDER_NO" VARCHAR(255),
+	"SFA_ORDER_DATE" DATE,
+	"ORDERNO" VARCHAR(255),
+	"ORDER_DATE" DATE,
+	"INVOICE_NO" VARCHAR(255),
+	"INVOICE_DATE" DATE,
+	"PCODE" VARCHAR(255),
+	"QTY" INTEGER,
+	"PRICE" REAL,
+	"DISKON" REAL,
+	"KODECABANG" VARCHAR(255),
+	"INV_TYPE" VARCHAR(255),
+	"REF_CN" VARCHAR(255),
+	"INVAMOUNT" REAL,
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" DATETIME
+);
+INSERT INTO "sap_web_inv_sfa__new" ("SLSNO", "CUSTNO", "SFA_ORDER_NO", "SFA_ORDER_DATE", "ORDERNO", "ORDER_DATE", "INVOICE_NO", "INVOICE_DATE", "PCODE", "QTY", "PRICE", "DISKON", "KODECABANG", "INV_TYPE", "REF_CN", "INVAMOUNT", "CORE_FILENAME", "CORE_PROCESSDATE")
+SELECT
+	"SLSNO",
+	"CUSTNO",
+	"SFA_ORDER_NO",
+	"SFA_ORDER_DATE",
+	"ORDERNO",
+	"ORDER_DATE",
+	"INVOICE_NO",
+	"INVOICE_DATE",
+	"PCODE",
+	"QTY",
+	"PRICE",
+	"DISKON",
+	"KODECABANG",
+	"INV_TYPE",
+	"REF_CN",
+	"INVAMOUNT",
+	"CORE_FILENAME",
+	"CORE_PROCESSDATE"
+FROM "sap_web_inv_sfa";
+DROP TABLE "sap_web_inv_sfa";
+ALTER TABLE "sap_web_inv_sfa__new" RENAME TO "sap_web_inv_sfa";
+CREATE UNIQUE INDEX "ux_sap_web_inv_sfa" ON "sap_web_inv_sfa" ("SLSNO", "CUSTNO", "SFA_ORDER_NO", "ORDERNO", "INVOICE_NO", "PCODE", "KODECABANG", "INV_TYPE");
+
+UPDATE "fpiutang_temp" SET
+	"INVDATE" = CASE
+		WHEN trim("INVDATE") IN ('', '00000000') THEN NULL
+		WHEN trim("INVDATE") GLOB '[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]' THEN substr(trim("INVDATE"), 1, 4) || '-' || substr(trim("INVDATE"), 5, 2) || '-' || substr(trim("INVDATE"), 7, 2)
+		ELSE "INVDATE"
+	END,
+	"DUEDATE" = CASE
+		WHEN trim("DUEDATE") IN ('', '00000000') THEN NULL
+		WHEN trim("DUEDATE") GLOB '[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]' THEN substr(trim("DUEDATE"), 1, 4) || '-' || substr(trim("DUEDATE"), 5, 2) || '-' || substr(trim("DUEDATE"), 7, 2)
+		ELSE "DUEDATE"
+	END;
+
+CREATE TABLE "fpiutang_temp__new" (
+	"CUSTNO" VARCHAR(255),
+	"INVNO" VARCHAR(255),
+	"INVDATE" DATE,
+	"DUEDATE" DATE,
+	"INVAMOUNT" VARCHAR(255),
+	"AMOUNTPAID" VARCHAR(255),
+	"SLSNO" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"INV_TYPE" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" DATETIME
+);
+INSERT INTO "fpiutang_temp__new" ("CUSTNO", "INVNO", "INVDATE", "DUEDATE", "INVAMOUNT", "AMOUNTPAID", "SLSNO", "KODECABANG", "INV_TYPE", "CORE_FILENAME", "CORE_PROCESSDATE")
+SELECT
+	"CUSTNO",
+	"INVNO",
+	"INVDATE",
+	"DUEDATE",
+	"INVAMOUNT",
+	"AMOUNTPAID",
+	"SLSNO",
+	"KODECABANG",
+	"INV_TYPE",
+	"CORE_FILENAME",
+	"CORE_PROCESSDATE"
+FROM "fpiutang_temp";
+DROP TABLE "fpiutang_temp";
+ALTER TABLE "fpiutang_temp__new" RENAME TO "fpiutang_temp";
+CREATE UNIQUE INDEX "ux_fpiutang_temp" ON "fpiutang_temp" ("CUSTNO", "INVNO", "SLSNO", "KODECABANG");
+
+UPDATE "forder_hd_status" SET
+	"TGLORDER" = CASE
+		WHEN trim("TGLORDER") IN ('', '00000000') THEN NULL
+		WHEN trim("TGLORDER") GLOB '[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]' THEN substr(trim("TGLORDER"), 1, 4) || '-' || substr(trim("TGLORDER"), 5, 2) || '-' || substr(trim("TGLORDER"), 7, 2)
+		ELSE "TGLORDER"
+	END;
+
+CREATE TABLE "forder_hd_status__new" (
+	"TGLORDER" DATE,
+	"ORDERNO" VARCHAR(255),
+	"SLSNO" VARCHAR(255),
+	"CUSTNO" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"ORDERNO_TOPUP" VARCHAR(255),
+	"PCODE" VARCHAR(255),
+	"STATUS" VARCHAR(255),
+	"STATUS_DETAIL" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" DATETIME
+);
+INSERT INTO "forder_hd_status__new" ("TGLORDER", "ORDERNO", "SLSNO", "CUSTNO", "KODECABANG", "ORDERNO_TOPUP", "PCODE", "STATUS", "STATUS_DETAIL", "CORE_FILENAME", "CORE_PROCESSDATE")
+SELECT
+	"TGLORDER",
+	"ORDERNO",
+	"SLSNO",
+	"CUSTNO",
+	"KODECABANG",
+	"ORDERNO_TOPUP",
+	"PCODE",
+	"STATUS",
+	"STATUS_DETAIL",
+	"CORE_FILENAME",
+	"CORE_PROCESSDATE"
+FROM "forder_hd_status";
+DROP TABLE "forder_hd_status";
+ALTER TABLE "forder_hd_status__new" RENAME TO "forder_hd_status";
+CREATE UNIQUE INDEX "ux_forder_hd_status" ON "forder_hd_status" ("TGLORDER", "ORDERNO", "SLSNO", "CUSTNO", "KODECABANG", "ORDERNO_TOPUP", "PCODE");
+
+UPDATE "fmst_custinv_d" SET
+	"INVDATE" = CASE
+		WHEN trim("INVDATE") IN ('', '00000000') THEN NULL
+		WHEN trim("INVDATE") GLOB '[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]' THEN substr(trim("INVDATE"), 1, 4) || '-' || substr(trim("INVDATE"), 5, 2) || '-' || substr(trim("INVDATE"), 7, 2)
+		ELSE "INVDATE"
+	END,
+	"DUEDATE" = CASE
+		WHEN trim("DUEDATE") IN ('', '00000000') THEN NULL
+		WHEN trim("DUEDATE") GLOB '[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]' THEN substr(trim("DUEDATE"), 1, 4) || '-' || substr(trim("DUEDATE"), 5, 2) || '-' || substr(trim("DUEDATE"), 7, 2)
+		ELSE "DUEDATE"
+	END;
+
+CREATE TABLE "fmst_custinv_d__new" (
+	"BID" VARCHAR(255),
+	"BNAME" VARCHAR(255),
+	"MUID" VARCHAR(255),
+	"MUNAME" VARCHAR(255),
+	"CUSTNO" VARCHAR(255),
+	"CUSTNAME" VARCHAR(255),
+	"INVNO" VARCHAR(255),
+	"INVDATE" DATE,
+	"DUEDATE" DATE,
+	"INV_AMOUNT" REAL,
+	"INV_OUTSTANDING" REAL,
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" DATETIME
+);
+INSERT INTO "fmst_custinv_d__new" ("BID", "BNAME", "MUID", "MUNAME", "CUSTNO", "CUSTNAME", "INVNO", "INVDATE", "DUEDATE", "INV_AMOUNT", "INV_OUTSTANDING", "CORE_FILENAME", "CORE_PROCESSDATE")
+SELECT
+	"BID",
+	"BNAME",
+	"MUID",
+	"MUNAME",
+	"CUSTNO",
+	"CUSTNAME",
+	"INVNO",
+	"INVDATE",
+	"DUEDATE",
+	"INV_AMOUNT",
+	"INV_OUTSTANDING",
+	"CORE_FILENAME",
+	"CORE_PROCESSDATE"
+FROM "fmst_custinv_d";
+DROP TABLE "fmst_custinv_d";
+ALTER TABLE "fmst_custinv_d__new" RENAME TO "fmst_custinv_d";
+CREATE UNIQUE INDEX "ux_fmst_custinv_d" ON "fmst_custinv_d" ("BID", "MUID", "CUSTNO", "INVNO");
@@ -0,0 +1,746 @@
+-- Amounts, prices and quantities are stored as NUMERIC(19,4) instead of
+-- REAL, INTEGER or text. Nothing is rounded and only blank text becomes
+-- NULL: a value that is not a number, is too large or has more than 4
+-- decimals stops the migration before a table is changed, with the values
+-- and how many rows hold them. Correct or clear those rows and run it
+-- again. SQLite cannot change a column's type, so each table is rebuilt
+-- with the same columns and indexes.
+
+-- RAISE takes an expression, which lets the check report the values.
+CREATE TEMP TABLE migration_error (msg TEXT);
+CREATE TEMP TRIGGER migration_error BEFORE INSERT ON migration_error
+BEGIN
+	SELECT RAISE(ABORT, NEW.msg);
+END;
+
+-- Every value is checked as text; a REAL reads as its shortest form, so
+-- 0.1 is 0.1 and 1e-05 is not a number with 4 decimals.
+WITH vals (name, v) AS (
+	SELECT 'm_price_dummy.PRICE_VALUE', trim(CAST("PRICE_VALUE" AS TEXT)) FROM "m_price_dummy"
+	UNION ALL
+	SELECT 'mkplprice_dummy.PRICE_VALUE', trim(CAST("PRICE_VALUE" AS TEXT)) FROM "mkplprice_dummy"
+	UNION ALL
+	SELECT 'fcustmst.CLIMIT', trim(CAST("CLIMIT" AS TEXT)) FROM "fcustmst"
+	UNION ALL
+	SELECT 'fcustmst_history.CLIMIT', trim(CAST("CLIMIT" AS TEXT)) FROM "fcustmst_history"
+	UNION ALL
+	SELECT 'sap_web_inv_sfa.QTY', trim(CAST("QTY" AS TEXT)) FROM "sap_web_inv_sfa"
+	UNION ALL
+	SELECT 'sap_web_inv_sfa.PRICE', trim(CAST("PRICE" AS TEXT)) FROM "sap_web_inv_sfa"
+	UNION ALL
+	SELECT 'sap_web_inv_sfa.DISKON', trim(CAST("DISKON" AS TEXT)) FROM "sap_web_inv_sfa"
+	UNION ALL
+	SELECT 'sap_web_inv_sfa.INVAMOUNT', trim(CAST("INVAMOUNT" AS TEXT)) FROM "sap_web_inv_sfa"
+	UNION ALL
+	SELECT 'fstockbarang.STOCK', trim(CAST("STOCK" AS TEXT)) FROM "fstockbarang"
+	UNION ALL
+	SELECT 'fpiutang_temp.INVAMOUNT', trim(CAST("INVAMOUNT" AS TEXT)) FROM "fpiutang_temp"
+	UNION ALL
+	SELECT 'fpiutang_temp.AMOUNTPAID', trim(CAST("AMOUNTPAID" AS TEXT)) FROM "fpiutang_temp"
+	UNION ALL
+	SELECT 'fcredit_limit.CREDIT_LIMIT', trim(CAST("CREDIT_LIMIT" AS TEXT)) FROM "fcredit_limit"
+	UNION ALL
+	SELECT 'fcredit_limit.SISA_CREDIT_LIMIT', trim(CAST("SISA_CREDIT_LIMIT" AS TEXT)) FROM "fcredit_limit"
+	UNION ALL
+	SELECT 'fmst_custinv_d.INV_AMOUNT', trim(CAST("INV_AMOUNT" AS TEXT)) FROM "fmst_custinv_d"
+	UNION ALL
+	SELECT 'fmst_custinv_d.INV_OUTSTANDING', trim(CAST("INV_OUTSTANDING" AS TEXT)) FROM "fmst_custinv_d"
+	UNION ALL
+	SELECT 'DP_ZDDET.AMOUNT', trim(CAST("AMOUNT" AS TEXT)) FROM "DP_ZDDET"
+	UNION ALL
+	SELECT 'DP_ZDDET.PER', trim(CAST("PER" AS TEXT)) FROM "DP_ZDDET"
+	UNION ALL
+	SELECT 'DP_ZSCMIX.SCALEQTY', trim(CAST("SCALEQTY" AS TEXT)) FROM "DP_ZSCMIX"
+	UNION ALL
+	SELECT 'DP_ZSCMIX.AMOUNT', trim(CAST("AMOUNT" AS TEXT)) FROM "DP_ZSCMIX"
+	UNION ALL
+	SELECT 'DP_ZSCMIX.PER', trim(CAST("PER" AS TEXT)) FROM "DP_ZSCMIX"
+	UNION ALL
+	SELECT 'DP_ZSCMIX.SCALEQTYTO', trim(CAST("SCALEQTYTO" AS TEXT)) FROM "DP_ZSCMIX"
+	UNION ALL
+	SELECT 'DP_ZSCMIX.AMOUNTSCL', trim(CAST("AMOUNTSCL" AS TEXT)) FROM "DP_ZSCMIX"
+	UNION ALL
+	SELECT 'DP_ZSCMIX.AMOUNTSCLTO', trim(CAST("AMOUNTSCLTO" AS TEXT)) FROM "DP_ZSCMIX"
+	UNION ALL
+	SELECT 'FG_ZDHDR.QTY', trim(CAST("QTY" AS TEXT)) FROM "FG_ZDHDR"
+	UNION ALL
+	SELECT 'FG_ZDHDR.UOM', trim(CAST("UOM" AS TEXT)) FROM "FG_ZDHDR"
+	UNION ALL
+	SELECT 'FG_ZFRDET.MINIMUMQTY', trim(CAST("MINIMUMQTY" AS TEXT)) FROM "FG_ZFRDET"
+	UNION ALL
+	SELECT 'FG_ZFRDET.FREEGOODSQTY', trim(CAST("FREEGOODSQTY" AS TEXT)) FROM "FG_ZFRDET"
+	UNION ALL
+	SELECT 'FG_ZFRDET.FREEGOODSAGRREDQTY', trim(CAST("FREEGOODSAGRREDQTY" AS TEXT)) FROM "FG_ZFRDET"
+	UNION ALL
+	SELECT 'FG_ZFRMIX.SCALEQTY', trim(CAST("SCALEQTY" AS TEXT)) FROM "FG_ZFRMIX"
+	UNION ALL
+	SELECT 'FG_ZFRMIX.QTY', trim(CAST("QTY" AS TEXT)) FROM "FG_ZFRMIX"
+	UNION ALL
+	SELECT 'FG_ZFRMIX.AMOUNTSCLF', trim(CAST("AMOUNTSCLF" AS TEXT)) FROM "FG_ZFRMIX"
+), unsigned (name, v, u) AS (
+	SELECT name, v, CASE WHEN substr(v, 1, 1) IN ('-', '+') THEN substr(v, 2) ELSE v END
+	FROM vals
+	WHERE v <> ''
+), split (name, v, u, whole, frac) AS (
+	SELECT name, v, u,
+		CASE WHEN instr(u, '.') > 0 THEN substr(u, 1, instr(u, '.') - 1) ELSE u END,
+		CASE WHEN instr(u, '.') > 0 THEN substr(u, instr(u, '.') + 1) ELSE '' END
+	FROM unsigned
+), bad (name, v, n) AS (
+	SELECT name, v, count(*) FROM split
+	WHERE u IN ('', '.')
+		OR u GLOB '*[^0-9.]*'
+		OR frac GLOB '*.*'
+		OR length(ltrim(whole, '0')) > 15
+		OR rtrim(substr(frac, 5), '0') <> ''
+	GROUP BY name, v
+)
+INSERT INTO migration_error (msg)
+SELECT 'values that do not fit NUMERIC(19,4) exactly, correct or clear them first: '
+	|| group_concat(name || ' = ''' || substr(v, 1, 100) || ''' (' || n || ' rows)', '; ')
+FROM bad
+HAVING count(*) > 0;
+
+DROP TRIGGER migration_error;
+DROP TABLE migration_error;
+
+CREATE TABLE "m_price_dummy__new" (
+	"UNIQ_ID" VARCHAR(255),
+	"LINE_NO" INTEGER,
+	"PRICE_CODE" VARCHAR(255),
+	"BRANCH_ID" VARCHAR(255),
+	"PCODE" VARCHAR(255),
+	"PRICE_VALUE" NUMERIC(19,4),
+	"PRICE_UOM" VARCHAR(255),
+	"CBY" VARCHAR(255),
+	"CDATE" DATETIME,
+	"MBY" VARCHAR(255),
+	"MDATE" DATETIME,
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" DATETIME,
+	"SELPRICE1" NUMERIC(19,4),
+	"SELPRICE2" NUMERIC(19,4),
+	"SELPRICE3" NUMERIC(19,4),
+	"SELPRICE4" NUMERIC(19,4),
+	"SELPRICE5" NUMERIC(19,4)
+);
+INSERT INTO "m_price_dummy__new" ("UNIQ_ID", "LINE_NO", "PRICE_CODE", "BRANCH_ID", "PCODE", "PRICE_VALUE", "PRICE_UOM", "CBY", "CDATE", "MBY", "MDATE", "CORE_FILENAME", "CORE_PROCESSDATE", "SELPRICE1", "SELPRICE2", "SELPRICE3", "SELPRICE4", "SELPRICE5")
+SELECT
+	"UNIQ_ID",
+	"LINE_NO",
+	"PRICE_CODE",
+	"BRANCH_ID",
+	"PCODE",
+	CASE WHEN typeof("PRICE_VALUE") <> 'text' THEN "PRICE_VALUE" WHEN trim("PRICE_VALUE") = '' THEN NULL ELSE CAST(trim("PRICE_VALUE") AS NUMERIC) END,
+	"PRICE_UOM",
+	"CBY",
+	"CDATE",
+	"MBY",
+	"MDATE",
+	"CORE_FILENAME",
+	"CORE_PROCESSDATE",
+	"SELPRICE1",
+	"SELPRICE2",
+	"SELPRICE3",
+	"SELPRICE4",
+	"SELPRICE5"
+FROM "m_price_dummy";
+DROP TABLE "m_price_dummy";
+ALTER TABLE "m_price_dummy__new" RENAME TO "m_price_dummy";
+
+-- PCODE of the MKPLPRICE rows was declared REAL and written out of order.
+CREATE TABLE "mkplprice_dummy__new" (
+	"UNIQ_ID" VARCHAR(255),
+	"LINE_NO" INTEGER,
+	"CUST_CODE" VARCHAR(255),
+	"BRANCH_ID" VARCHAR(255),
+	"PCODE" VARCHAR(255),
+	"PRICE_VALUE" NUMERIC(19,4),
+	"PRICE_UOM" VARCHAR(255),
+	"CBY" VARCHAR(255),
+	"CDATE" DATETIME,
+	"MBY" VARCHAR(255),
+	"MDATE" DATETIME,
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" DATETIME,
+	"SELLPRICE1" NUMERIC(19,4),
+	"SELLPRICE2" NUMERIC(19,4),
+	"SELLPRICE3" NUMERIC(19,4),
+	"SELLPRICE4" NUMERIC(19,4),
+	"SELLPRICE5" NUMERIC(19,4)
+);
+INSERT INTO "mkplprice_dummy__new" ("UNIQ_ID", "LINE_NO", "CUST_CODE", "BRANCH_ID", "PCODE", "PRICE_VALUE", "PRICE_UOM", "CBY", "CDATE", "MBY", "MDATE", "CORE_FILENAME", "CORE_PROCESSDATE", "SELLPRICE1", "SELLPRICE2", "SELLPRICE3", "SELLPRICE4", "SELLPRICE5")
+SELECT
+	"UNIQ_ID",
+	"LINE_NO",
+	"CUST_CODE",
+	"BRANCH_ID",
+	CASE WHEN typeof("PCODE") = 'real' AND "PCODE" = CAST("PCODE" AS INTEGER) THEN CAST(CAST("PCODE" AS INTEGER) AS TEXT) ELSE "PCODE" END,
+	CASE WHEN typeof("PRICE_VALUE") <> 'text' THEN "PRICE_VALUE" WHEN trim("PRICE_VALUE") = '' THEN NULL ELSE CAST(trim("PRICE_VALUE") AS NUMERIC) END,
+	"PRICE_UOM",
+	"CBY",
+	"CDATE",
+	"MBY",
+	"MDATE",
+	"CORE_FILENAME",
+	"CORE_PROCESSDATE",
+	"SELLPRICE1",
+	"SELLPRICE2",
+	"SELLPRICE3",
+	"SELLPRICE4",
+	"SELLPRICE5"
+FROM "mkplprice_dummy";
+DROP TABLE "mkplprice_dummy";
+ALTER TABLE "mkplprice_dummy__new" RENAME TO "mkplprice_dummy";
+
+CREATE TABLE "fcustmst__new" (
+	"CUSTNO" VARCHAR(255),
+	"DATA01" VARCHAR(255),
+	"CUSTNAME" VARCHAR(255),
+	"CUSTADD1" VARCHAR(255),
+	"CUSTADD2" VARCHAR(255),
+	"CCITY" VARCHAR(255),
+	"CCONTACT" VARCHAR(255),
+	"CPHONE1" VARCHAR(255),
+	"CFAXNO" VARCHAR(255),
+	"CTERM" VARCHAR(255),
+	"CLIMIT" NUMERIC(19,4),
+	"FLAGLIMIT" VARCHAR(255),
+	"GDISC" VARCHAR(255),
+	"GRUPOUT" VARCHAR(255),
+	"TYPEOUT" VARCHAR(255),
+	"GHARGA" VARCHAR(255),
+	"FLAGPAY" VARCHAR(255),
+	"FLAGOUT" VARCHAR(255),
+	"RPP" INTEGER,
+	"LSALES" INTEGER,
+	"LDATETRS" VARCHAR(255),
+	"LOKASI" VARCHAR(255),
+	"DISTRIK" VARCHAR(255),
+	"BEAT" VARCHAR(255),
+	"SUBBEAT" VARCHAR(255),
+	"KLASIF" VARCHAR(255),
+	"KINDUS" VARCHAR(255),
+	"KPASAR" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"LA" VARCHAR(255),
+	"LG" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" DATETIME,
+	"IS_ACTIVE" INTEGER NOT NULL DEFAULT 1,
+	"DELETED_AT" DATETIME
+);
+INSERT INTO "fcustmst__new" ("CUSTNO", "DATA01", "CUSTNAME", "CUSTADD1", "CUSTADD2", "CCITY", "CCONTACT", "CPHONE1", "CFAXNO", "CTERM", "CLIMIT", "FLAGLIMIT", "GDISC", "GRUPOUT", "TYPEOUT", "GHARGA", "FLAGPAY", "FLAGOUT", "RPP", "LSALES", "LDATETRS", "LOKASI", "DISTRIK", "BEAT", "SUBBEAT", "KLASIF", "KINDUS", "KPASAR", "KODECABANG", "LA", "LG", "CORE_FILENAME", "CORE_PROCESSDATE", "IS_ACTIVE", "DELETED_AT")
+SELECT
+	"CUSTNO",
+	"DATA01",
+	"CUSTNAME",
+	"CUSTADD1",
+	"CUSTADD2",
+	"CCITY",
+	"CCONTACT",
+	"CPHONE1",
+	"CFAXNO",
+	"CTERM",
+	CASE WHEN typeof("CLIMIT") <> 'text' THEN "CLIMIT" WHEN trim("CLIMIT") = '' THEN NULL ELSE CAST(trim("CLIMIT") AS NUMERIC) END,
+	"FLAGLIMIT",
+	"GDISC",
+	"GRUPOUT",
+	"TYPEOUT",
+	"GHARGA",
+	"FLAGPAY",
+	"FLAGOUT",
+	"RPP",
+	"LSALES",
+	"LDATETRS",
+	"LOKASI",
+	"DISTRIK",
+	"BEAT",
+	"SUBBEAT",
+	"KLASIF",
+	"KINDUS",
+	"KPASAR",
+	"KODECABANG",
+	"LA",
+	"LG",
+	"CORE_FILENAME",
+	"CORE_PROCESSDATE",
+	"IS_ACTIVE",
+	"DELETED_AT"
+FROM "fcustmst";
+DROP TABLE "fcustmst";
+ALTER TABLE "fcustmst__new" RENAME TO "fcustmst";
+CREATE UNIQUE INDEX "ux_fcustmst" ON "fcustmst" ("CUSTNO", "KODECABANG");
+
+CREATE TABLE "fcustmst_history__new" (
+	"CUSTNO" VARCHAR(255),
+	"DATA01" VARCHAR(255),
+	"CUSTNAME" VARCHAR(255),
+	"CUSTADD1" VARCHAR(255),
+	"CUSTADD2" VARCHAR(255),
+	"CCITY" VARCHAR(255),
+	"CCONTACT" VARCHAR(255),
+	"CPHONE1" VARCHAR(255),
+	"CFAXNO" VARCHAR(255),
+	"CTERM" VARCHAR(255),
+	"CLIMIT" NUMERIC(19,4),
+	"FLAGLIMIT" VARCHAR(255),
+	"GDISC" VARCHAR(255),
+	"GRUPOUT" VARCHAR(255),
+	"TYPEOUT" VARCHAR(255),
+	"GHARGA" VARCHAR(255),
+	"FLAGPAY" VARCHAR(255),
+	"FLAGOUT" VARCHAR(255),
+	"RPP" INTEGER,
+	"LSALES" INTEGER,
+	"LDATETRS" VARCHAR(255),
+	"LOKASI" VARCHAR(255),
+	"DISTRIK" VARCHAR(255),
+	"BEAT" VARCHAR(255),
+	"SUBBEAT" VARCHAR(255),
+	"KLASIF" VARCHAR(255),
+	"KINDUS" VARCHAR(255),
+	"KPASAR" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"LA" VARCHAR(255),
+	"LG" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" DATETIME,
+	"VALID_FROM" DATETIME NOT NULL,
+	"VALID_TO" DATETIME,
+	"PROCESS_ID" VARCHAR(36)
+);
+INSERT INTO "fcustmst_history__new" ("CUSTNO", "DATA01", "CUSTNAME", "CUSTADD1", "CUSTADD2", "CCITY", "CCONTACT", "CPHONE1", "CFAXNO", "CTERM", "CLIMIT", "FLAGLIMIT", "GDISC", "GRUPOUT", "TYPEOUT", "GHARGA", "FLAGPAY", "FLAGOUT", "RPP", "LSALES", "LDATETRS", "LOKASI", "DISTRIK", "BEAT", "SUBBEAT", "KLASIF", "KINDUS", "KPASAR", "KODECABANG", "LA", "LG", "CORE_FILENAME", "CORE_PROCESSDATE", "VALID_FROM", "VALID_TO", "PROCESS_ID")
+SELECT
+	"CUSTNO",
+	"DATA01",
+	"CUSTNAME",
+	"CUSTADD1",
+	"CUSTADD2",
+	"CCITY",
+	"CCONTACT",
+	"CPHONE1",
+	"CFAXNO",
+	"CTERM",
+	CASE WHEN typeof("CLIMIT") <> 'text' THEN "CLIMIT" WHEN trim("CLIMIT") = '' THEN NULL ELSE CAST(trim("CLIMIT") AS NUMERIC) END,
+	"FLAGLIMIT",
+	"GDISC",
+	"GRUPOUT",
+	"TYPEOUT",
+	"GHARGA",
+	"FLAGPAY",
+	"FLAGOUT",
+	"RPP",
+	"LSALES",
+	"LDATETRS",
+	"LOKASI",
+	"DISTRIK",
+	"BEAT",
+	"SUBBEAT",
+	"KLASIF",
+	"KINDUS",
+	"KPASAR",
+	"KODECABANG",
+	"LA",
+	"LG",
+	"CORE_FILENAME",
+	"CORE_PROCESSDATE",
+	"VALID_FROM",
+	"VALID_TO",
+	"PROCESS_ID"
+FROM "fcustmst_history";
+DROP TABLE "fcustmst_history";
+ALTER TABLE "fcustmst_history__new" RENAME TO "fcustmst_history";
+CREATE INDEX "ix_fcustmst_history_key" ON "fcustmst_history" ("CUSTNO", "KODECABANG", "VALID_TO");
+
+CREATE TABLE "sap_web_inv_sfa__new" (
+	"SLSNO" VARCHAR(255),
+	"CUSTNO" VARCHAR(255),
+	"SFA_ORDER_NO" VARCHAR(255),
+	"SFA_ORDER_DATE" DATE,
+	"ORDERNO" VARCHAR(255),
+	"ORDER_DATE" DATE,
+	"INVOICE_NO" VARCHAR(255),
+	"INVOICE_DATE" DATE,
+	"PCODE" VARCHAR(255),
+	"QTY" NUMERIC(19,4),
+	"PRICE" NUMERIC(19,4),
+	"DISKON" NUMERIC(19,4),
+	"KODECABANG" VARCHAR(255),
+	"INV_TYPE" VARCHAR(255),
+	"REF_CN" VARCHAR(255),
+	"INVAMOUNT" NUMERIC(19,4),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" DATETIME
+);
+INSERT INTO "sap_web_inv_sfa__new" ("SLSNO", "CUSTNO", "SFA_ORDER_NO", "SFA_ORDER_DATE", "ORDERNO", "ORDER_DATE", "INVOICE_NO", "INVOICE_DATE", "PCODE", "QTY", "PRICE", "DISKON", "KODECABANG", "INV_TYPE", "REF_CN", "INVAMOUNT", "CORE_FILENAME", "CORE_PROCESSDATE")
+SELECT
+	"SLSNO",
+	"CUSTNO",
+	"SFA_ORDER_NO",
+	"SFA_ORDER_DATE",
+	"ORDERNO",
+	"ORDER_DATE",
+	"INVOICE_NO",
+	"INVOICE_DATE",
+	"PCODE",
+	CASE WHEN typeof("QTY") <> 'text' THEN "QTY" WHEN trim("QTY") = '' THEN NULL ELSE CAST(trim("QTY") AS NUMERIC) END,
+	CASE WHEN typeof("PRICE") <> 'text' THEN "PRICE" WHEN trim("PRICE") = '' THEN NULL ELSE CAST(trim("PRICE") AS NUMERIC) END,
+	CASE WHEN typeof("DISKON") <> 'text' THEN "DISKON" WHEN trim("DISKON") = '' THEN NULL ELSE CAST(trim("DISKON") AS NUMERIC) END,
+	"KODECABANG",
+	"INV_TYPE",
+	"REF_CN",
+	CASE WHEN typeof("INVAMOUNT") <> 'text' THEN "INVAMOUNT" WHEN trim("INVAMOUNT") = '' THEN NULL ELSE CAST(trim("INVAMOUNT") AS NUMERIC) END,
+	"CORE_FILENAME",
+	"CORE_PROCESSDATE"
+FROM "sap_web_inv_sfa";
+DROP TABLE "sap_web_inv_sfa";
+ALTER TABLE "sap_web_inv_sfa__new" RENAME TO "sap_web_inv_sfa";
+CREATE UNIQUE INDEX "ux_sap_web_inv_sfa" ON "sap_web_inv_sfa" ("SLSNO", "CUSTNO", "SFA_ORDER_NO", "ORDERNO", "INVOICE_NO", "PCODE", "KODECABANG", "INV_TYPE");
+
+CREATE TABLE "fstockbarang__new" (
+	"KG" VARCHAR(255),
+	"PCODE" VARCHAR(255),
+	"STOCK" NUMERIC(19,4),
+	"KODECABANG" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" DATETIME
+);
+INSERT INTO "fstockbarang__new" ("KG", "PCODE", "STOCK", "KODECABANG", "CORE_FILENAME", "CORE_PROCESSDATE")
+SELECT
+	"KG",
+	"PCODE",
+	CASE WHEN typeof("STOCK") <> 'text' THEN "STOCK" WHEN trim("STOCK") = '' THEN NULL ELSE CAST(trim("STOCK") AS NUMERIC) END,
+	"KODECABANG",
+	"CORE_FILENAME",
+	"CORE_PROCESSDATE"
+FROM "fstockbarang";
+DROP TABLE "fstockbarang";
+ALTER TABLE "fstockbarang__new" RENAME TO "fstockbarang";
+CREATE UNIQUE INDEX "ux_fstockbarang" ON "fstockbarang" ("KG", "PCODE", "KODECABANG");
+
+CREATE TABLE "fpiutang_temp__new" (
+	"CUSTNO" VARCHAR(255),
+	"INVNO" VARCHAR(255),
+	"INVDATE" DATE,
+	"DUEDATE" DATE,
+	"INVAMOUNT" NUMERIC(19,4),
+	"AMOUNTPAID" NUMERIC(19,4),
+	"SLSNO" VARCHAR(255),
+	"KODECABANG" VARCHAR(255),
+	"INV_TYPE" VARCHAR(255),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" DATETIME
+);
+INSERT INTO "fpiutang_temp__new" ("CUSTNO", "INVNO", "INVDATE", "DUEDATE", "INVAMOUNT", "AMOUNTPAID", "SLSNO", "KODECABANG", "INV_TYPE", "CORE_FILENAME", "CORE_PROCESSDATE")
+SELECT
+	"CUSTNO",
+	"INVNO",
+	"INVDATE",
+	"DUEDATE",
+	CASE WHEN typeof("INVAMOUNT") <> 'text' THEN "INVAMOUNT" WHEN trim("INVAMOUNT") = '' THEN NULL ELSE CAST(trim("INVAMOUNT") AS NUMERIC) END,
+	CASE WHEN typeof("AMOUNTPAID") <> 'text' THEN "AMOUNTPAID" WHEN trim("AMOUNTPAID") = '' THEN NULL ELSE CAST(trim("AMOUNTPAID") AS NUMERIC) END,
+	"SLSNO",
+	"KODECABANG",
+	"INV_TYPE",
+	"CORE_FILENAME",
+	"CORE_PROCESSDATE"
+FROM "fpiutang_temp";
+DROP TABLE "fpiutang_temp";
+ALTER TABLE "fpiutang_temp__new" RENAME TO "fpiutang_temp";
+CREATE UNIQUE INDEX "ux_fpiutang_temp" ON "fpiutang_temp" ("CUSTNO", "INVNO", "SLSNO", "KODECABANG");
+
+CREATE TABLE "fcredit_limit__new" (
+	"CUSTNO" VARCHAR(255),
+	"CUSTNAME" VARCHAR(255),
+	"CREDIT_LIMIT" NUMERIC(19,4),
+	"SISA_CREDIT_LIMIT" NUMERIC(19,4),
+	"KODECABANG" VARCHAR(255),
+	"UPDATEBY" VARCHAR(255),
+	"UPDATEDATE" DATETIME
+);
+INSERT INTO "fcredit_limit__new" ("CUSTNO", "CUSTNAME", "CREDIT_LIMIT", "SISA_CREDIT_LIMIT", "KODECABANG", "UPDATEBY", "UPDATEDATE")
+SELECT
+	"CUSTNO",
+	"CUSTNAME",
+	CASE WHEN typeof("CREDIT_LIMIT") <> 'text' THEN "CREDIT_LIMIT" WHEN trim("CREDIT_LIMIT") = '' THEN NULL ELSE CAST(trim("CREDIT_LIMIT") AS NUMERIC) END,
+	CASE WHEN typeof("SISA_CREDIT_LIMIT") <> 'text' THEN "SISA_CREDIT_LIMIT" WHEN trim("SISA_CREDIT_LIMIT") = '' THEN NULL ELSE CAST(trim("SISA_CREDIT_LIMIT") AS NUMERIC) END,
+	"KODECABANG",
+	"UPDATEBY",
+	"UPDATEDATE"
+FROM "fcredit_limit";
+DROP TABLE "fcredit_limit";
+ALTER TABLE "fcredit_limit__new" RENAME TO "fcredit_limit";
+CREATE UNIQUE INDEX "ux_fcredit_limit" ON "fcredit_limit" ("CUSTNO", "KODECABANG");
+
+CREATE TABLE "fmst_custinv_d__new" (
+	"BID" VARCHAR(255),
+	"BNAME" VARCHAR(255),
+	"MUID" VARCHAR(255),
+	"MUNAME" VARCHAR(255),
+	"CUSTNO" VARCHAR(255),
+	"CUSTNAME" VARCHAR(255),
+	"INVNO" VARCHAR(255),
+	"INVDATE" DATE,
+	"DUEDATE" DATE,
+	"INV_AMOUNT" NUMERIC(19,4),
+	"INV_OUTSTANDING" NUMERIC(19,4),
+	"CORE_FILENAME" VARCHAR(255),
+	"CORE_PROCESSDATE" DATETIME
+);
+INSERT INTO "fmst_custinv_d__new" ("BID", "BNAME", "MUID", "MUNAME", "CUSTNO", "CUSTNAME", "INVNO", "INVDATE", "DUEDATE", "INV_AMOUNT", "INV_OUTSTANDING", "CORE_FILENAME", "CORE_PROCESSDATE")
+SELECT
+	"BID",
+	"BNAME",
+	"MUID",
+	"MUNAME",
+	"CUSTNO",
+	"CUSTNAME",
+	"INVNO",
+	"INVDATE",
+	"DUEDATE",
+	CASE WHEN typeof("INV_AMOUNT") <> 'text' THEN "INV_AMOUNT" WHEN trim("INV_AMOUNT") = '' THEN NULL ELSE CAST(trim("INV_AMOUNT") AS NUMERIC) END,
+	CASE WHEN typeof("INV_OUTSTANDING") <> 'text' THEN "INV_OUTSTANDING" WHEN trim("INV_OUTSTANDING") = '' THEN NULL ELSE CAST(trim("INV_OUTSTANDING") AS NUMERIC) END,
+	"CORE_FILENAME",
+	"CORE_PROCESSDATE"
+FROM "fmst_custinv_d";
+DROP TABLE "fmst_custinv_d";
+ALTER TABLE "fmst_custinv_d__new" RENAME TO "fmst_custinv_d";
+CREATE UNIQUE INDEX "ux_fmst_custinv_d" ON "fmst_custinv_d" ("BID", "MUID", "CUSTNO", "INVNO");
+
+CREATE TABLE "DP_ZDDET__new" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"CONDITIONRECORDNO" VARCHAR(255),
+	"AMOUNT" NUMERIC(19,4),
+	"UNIT" VARCHAR(255),
+	"PER" NUMERIC(19,4),
+	"UOM" VARCHAR(255),
+	"SCALE" VARCHAR(255),
+	"FILENAME" VARCHAR(255),
+	"LINENUMBER" INTEGER,
+	"CDATE" DATETIME
+);
+INSERT INTO "DP_ZDDET__new" ("PROCESS_ID", "BLOCKID", "BLOCKNAME", "CONDITIONRECORDNO", "AMOUNT", "UNIT", "PER", "UOM", "SCALE", "FILENAME", "LINENUMBER", "CDATE")
+SELECT
+	"PROCESS_ID",
+	"BLOCKID",
+	"BLOCKNAME",
+	"CONDITIONRECORDNO",
+	CASE WHEN typeof("AMOUNT") <> 'text' THEN "AMOUNT" WHEN trim("AMOUNT") = '' THEN NULL ELSE CAST(trim("AMOUNT") AS NUMERIC) END,
+	"UNIT",
+	CASE WHEN typeof("PER") <> 'text' THEN "PER" WHEN trim("PER") = '' THEN NULL ELSE CAST(trim("PER") AS NUMERIC) END,
+	"UOM",
+	"SCALE",
+	"FILENAME",
+	"LINENUMBER",
+	"CDATE"
+FROM "DP_ZDDET";
+DROP TABLE "DP_ZDDET";
+ALTER TABLE "DP_ZDDET__new" RENAME TO "DP_ZDDET";
+
+CREATE TABLE "DP_ZSCMIX__new" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"PROMOID" VARCHAR(255),
+	"LINEITEM" INTEGER,
+	"SCALEQTY" NUMERIC(19,4),
+	"BUN" VARCHAR(255),
+	"AMOUNT" NUMERIC(19,4),
+	"UNIT" VARCHAR(255),
+	"PER" NUMERIC(19,4),
+	"UOM" VARCHAR(255),
+	"FILENAME" VARCHAR(255),
+	"LINENUMBER" INTEGER,
+	"CDATE" DATETIME,
+	"SCALEQTYTO" NUMERIC(19,4),
+	"AMOUNTSCL" NUMERIC(19,4),
+	"AMOUNTSCLTO" NUMERIC(19,4),
+	"UNITSCL" VARCHAR(255),
+	"MATNRKENA" VARCHAR(255)
+);
+INSERT INTO "DP_ZSCMIX__new" ("PROCESS_ID", "BLOCKID", "BLOCKNAME", "PROMOID", "LINEITEM", "SCALEQTY", "BUN", "AMOUNT", "UNIT", "PER", "UOM", "FILENAME", "LINENUMBER", "CDATE", "SCALEQTYTO", "AMOUNTSCL", "AMOUNTSCLTO", "UNITSCL", "MATNRKENA")
+SELECT
+	"PROCESS_ID",
+	"BLOCKID",
+	"BLOCKNAME",
+	"PROMOID",
+	"LINEITEM",
+	CASE WHEN typeof("SCALEQTY") <> 'text' THEN "SCALEQTY" WHEN trim("SCALEQTY") = '' THEN NULL ELSE CAST(trim("SCALEQTY") AS NUMERIC) END,
+	"BUN",
+	CASE WHEN typeof("AMOUNT") <> 'text' THEN "AMOUNT" WHEN trim("AMOUNT") = '' THEN NULL ELSE CAST(trim("AMOUNT") AS NUMERIC) END,
+	"UNIT",
+	CASE WHEN typeof("PER") <> 'text' THEN "PER" WHEN trim("PER") = '' THEN NULL ELSE CAST(trim("PER") AS NUMERIC) END,
+	"UOM",
+	"FILENAME",
+	"LINENUMBER",
+	"CDATE",
+	CASE WHEN typeof("SCALEQTYTO") <> 'text' THEN "SCALEQTYTO" WHEN trim("SCALEQTYTO") = '' THEN NULL ELSE CAST(trim("SCALEQTYTO") AS NUMERIC) END,
+	CASE WHEN typeof("AMOUNTSCL") <> 'text' THEN "AMOUNTSCL" WHEN trim("AMOUNTSCL") = '' THEN NULL ELSE CAST(trim("AMOUNTSCL") AS NUMERIC) END,
+	CASE WHEN typeof("AMOUNTSCLTO") <> 'text' THEN "AMOUNTSCLTO" WHEN trim("AMOUNTSCLTO") = '' THEN NULL ELSE CAST(trim("AMOUNTSCLTO") AS NUMERIC) END,
+	"UNITSCL",
+	"MATNRKENA"
+FROM "DP_ZSCMIX";
+DROP TABLE "DP_ZSCMIX";
+ALTER TABLE "DP_ZSCMIX__new" RENAME TO "DP_ZSCMIX";
+
+CREATE TABLE "FG_ZDHDR__new" (
+	"PROCESS_ID" VARCHAR(50),
+	"BLOCKID" VARCHAR(3),
+	"BLOCKNAME" VARCHAR(50),
+	"CONDITIONTYPE" VARCHAR(20),
+	"KEYCOMBINATION" VARCHAR(20),
+	"KEYCOMB" VARCHAR(180),
+	"SALESORGANIZATION" VARCHAR(20),
+	"DISTRIBUTIONCHANNEL" VARCHAR(20),
+	"DIVISION" VARCHAR(20),
+	"SALESOFFICE" VARCHAR(20),
+	"PRICELISTTYPE" VARCHAR(20),
+	"ATTRIBUTE1" VARCHAR(20),
+	"INDUSTRYCODE3" VARCHAR(20),
+	"INDUSTRYCODE4" VARCHAR(20),
+	"INDUSTRYCODE5" VARCHAR(20),
+	"SOLDTOPARTY" VARCHAR(20),
+	"MATERIAL" VARCHAR(20),
+	"VALIDUNTIL" DATE,
+	"VALIDFROM" DATE,
+	"CONDITIONRECORDNO" VARCHAR(20),
+	"PROMOID" VARCHAR(20),
+	"PROMOITEM" VARCHAR(20),
+	"SCALE" VARCHAR(3),
+	"FILENAME" VARCHAR(100),
+	"LINENUMBER" INTEGER,
+	"CDATE" DATETIME,
+	"MUSTBUY" VARCHAR(5),
+	"KELIPATAN" VARCHAR(5),
+	"F_KELIPATAN" INTEGER,
+	"WITHQTY" VARCHAR(20),
+	"QTY" NUMERIC(19,4),
+	"UOM" NUMERIC(19,4),
+	"ZTERM" VARCHAR(5),
+	"KATR2" VARCHAR(20),
+	"KATR3" VARCHAR(20),
+	"PERBANDINGAN" VARCHAR(20),
+	"F_PERBANDINGAN1" INTEGER,
+	"F_PERBANDINGAN2" INTEGER,
+	"AMOUNTX" VARCHAR(1)
+);
+INSERT INTO "FG_ZDHDR__new" ("PROCESS_ID", "BLOCKID", "BLOCKNAME", "CONDITIONTYPE", "KEYCOMBINATION", "KEYCOMB", "SALESORGANIZATION", "DISTRIBUTIONCHANNEL", "DIVISION", "SALESOFFICE", "PRICELISTTYPE", "ATTRIBUTE1", "INDUSTRYCODE3", "INDUSTRYCODE4", "INDUSTRYCODE5", "SOLDTOPARTY", "MATERIAL", "VALIDUNTIL", "VALIDFROM", "CONDITIONRECORDNO", "PROMOID", "PROMOITEM", "SCALE", "FILENAME", "LINENUMBER", "CDATE", "MUSTBUY", "KELIPATAN", "F_KELIPATAN", "WITHQTY", "QTY", "UOM", "ZTERM", "KATR2", "KATR3", "PERBANDINGAN", "F_PERBANDINGAN1", "F_PERBANDINGAN2", "AMOUNTX")
+SELECT
+	"PROCESS_ID",
+	"BLOCKID",
+	"BLOCKNAME",
+	"CONDITIONTYPE",
+	"KEYCOMBINATION",
+	"KEYCOMB",
+	"SALESORGANIZATION",
+	"DISTRIBUTIONCHANNEL",
+	"DIVISION",
+	"SALESOFFICE",
+	"PRICELISTTYPE",
+	"ATTRIBUTE1",
+	"INDUSTRYCODE3",
+	"INDUSTRYCODE4",
+	"INDUSTRYCODE5",
+	"SOLDTOPARTY",
+	"MATERIAL",
+	"VALIDUNTIL",
+	"VALIDFROM",
+	"CONDITIONRECORDNO",
+	"PROMOID",
+	"PROMOITEM",
+	"SCALE",
+	"FILENAME",
+	"LINENUMBER",
+	"CDATE",
+	"MUSTBUY",
+	"KELIPATAN",
+	"F_KELIPATAN",
+	"WITHQTY",
+	CASE WHEN typeof("QTY") <> 'text' THEN "QTY" WHEN trim("QTY") = '' THEN NULL ELSE CAST(trim("QTY") AS NUMERIC) END,
+	CASE WHEN typeof("UOM") <> 'text' THEN "UOM" WHEN trim("UOM") = '' THEN NULL ELSE CAST(trim("UOM") AS NUMERIC) END,
+	"ZTERM",
+	"KATR2",
+	"KATR3",
+	"PERBANDINGAN",
+	"F_PERBANDINGAN1",
+	"F_PERBANDINGAN2",
+	"AMOUNTX"
+FROM "FG_ZDHDR";
+DROP TABLE "FG_ZDHDR";
+ALTER TABLE "FG_ZDHDR__new" RENAME TO "FG_ZDHDR";
+CREATE UNIQUE INDEX "ux_FG_ZDHDR" ON "FG_ZDHDR" ("BLOCKID", "PROMOID", "PROMOITEM", "CONDITIONRECORDNO", "CONDITIONTYPE", "KEYCOMBINATION", "SALESORGANIZATION", "DISTRIBUTIONCHANNEL", "DIVISION", "SALESOFFICE", "PRICELISTTYPE", "ATTRIBUTE1", "INDUSTRYCODE3", "INDUSTRYCODE4", "INDUSTRYCODE5", "SOLDTOPARTY", "MATERIAL", "ZTERM", "KATR2", "KATR3");
+
+CREATE TABLE "FG_ZFRDET__new" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"CONDITIONRECORDNO" VARCHAR(255),
+	"MINIMUMQTY" NUMERIC(19,4),
+	"FREEGOODSQTY" NUMERIC(19,4),
+	"UOMFREEGOODS" VARCHAR(255),
+	"FREEGOODSAGRREDQTY" NUMERIC(19,4),
+	"UOMFREEGOODSAGRRED" VARCHAR(255),
+	"ADDITIONALMATERIAL" VARCHAR(255),
+	"FILENAME" VARCHAR(255),
+	"LINENUMBER" INTEGER,
+	"CDATE" DATETIME
+);
+INSERT INTO "FG_ZFRDET__new" ("PROCESS_ID", "BLOCKID", "BLOCKNAME", "CONDITIONRECORDNO", "MINIMUMQTY", "FREEGOODSQTY", "UOMFREEGOODS", "FREEGOODSAGRREDQTY", "UOMFREEGOODSAGRRED", "ADDITIONALMATERIAL", "FILENAME", "LINENUMBER", "CDATE")
+SELECT
+	"PROCESS_ID",
+	"BLOCKID",
+	"BLOCKNAME",
+	"CONDITIONRECORDNO",
+	CASE WHEN typeof("MINIMUMQTY") <> 'text' THEN "MINIMUMQTY" WHEN trim("MINIMUMQTY") = '' THEN NULL ELSE CAST(trim("MINIMUMQTY") AS NUMERIC) END,
+	CASE WHEN typeof("FREEGOODSQTY") <> 'text' THEN "FREEGOODSQTY" WHEN trim("FREEGOODSQTY") = '' THEN NULL ELSE CAST(trim("FREEGOODSQTY") AS NUMERIC) END,
+	"UOMFREEGOODS",
+	CASE WHEN typeof("FREEGOODSAGRREDQTY") <> 'text' THEN "FREEGOODSAGRREDQTY" WHEN trim("FREEGOODSAGRREDQTY") = '' THEN NULL ELSE CAST(trim("FREEGOODSAGRREDQTY") AS NUMERIC) END,
+	"UOMFREEGOODSAGRRED",
+	"ADDITIONALMATERIAL",
+	"FILENAME",
+	"LINENUMBER",
+	"CDATE"
+FROM "FG_ZFRDET";
+DROP TABLE "FG_ZFRDET";
+ALTER TABLE "FG_ZFRDET__new" RENAME TO "FG_ZFRDET";
+
+CREATE TABLE "FG_ZFRMIX__new" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"PROMOID" VARCHAR(255),
+	"PROMOITEM" VARCHAR(255),
+	"SCALEQTY" NUMERIC(19,4),
+	"SCALEQTYUOM" VARCHAR(255),
+	"MATERIAL" VARCHAR(255),
+	"QTY" NUMERIC(19,4),
+	"QTYUOM" VARCHAR(255),
+	"FILENAME" VARCHAR(255),
+	"LINENUMBER" INTEGER,
+	"CDATE" DATETIME,
+	"AMOUNTSCLF" NUMERIC(19,4),
+	"CURRENCY" VARCHAR(255)
+);
+INSERT INTO "FG_ZFRMIX__new" ("PROCESS_ID", "BLOCKID", "BLOCKNAME", "PROMOID", "PROMOITEM", "SCALEQTY", "SCALEQTYUOM", "MATERIAL", "QTY", "QTYUOM", "FILENAME", "LINENUMBER", "CDATE", "AMOUNTSCLF", "CURRENCY")
+SELECT
+	"PROCESS_ID",
+	"BLOCKID",
+	"BLOCKNAME",
+	"PROMOID",
+	"PROMOITEM",
+	CASE WHEN typeof("SCALEQTY") <> 'text' THEN "SCALEQTY" WHEN trim("SCALEQTY") = '' THEN NULL ELSE CAST(trim("SCALEQTY") AS NUMERIC) END,
+	"SCALEQTYUOM",
+	"MATERIAL",
+	CASE WHEN typeof("QTY") <> 'text' THEN "QTY" WHEN trim("QTY") = '' THEN NULL ELSE CAST(trim("QTY") AS NUMERIC) END,
+	"QTYUOM",
+	"FILENAME",
+	"LINENUMBER",
+	"CDATE",
+	CASE WHEN typeof("AMOUNTSCLF") <> 'text' THEN "AMOUNTSCLF" WHEN trim("AMOUNTSCLF") = '' THEN NULL ELSE CAST(trim("AMOUNTSCLF") AS NUMERIC) END,
+	"CURRENCY"
+FROM "FG_ZFRMIX";
+DROP TABLE "FG_ZFRMIX";
+ALTER TABLE "FG_ZFRMIX__new" RENAME TO "FG_ZFRMIX";
@@ -0,0 +1,73 @@
+-- The validity dates of DP_Z00001 (block 126) are stored as DATE instead
+-- of the YYYYMMDD number they were read as. 0, which the handler stored
+-- for an empty or unreadable date, becomes NULL. Any other value that is
+-- not a date stops the migration before the table is changed, with the
+-- values and how many rows hold them; correct or clear those rows and run
+-- it again. SQLite cannot change a column's type, so the table is rebuilt
+-- with the same columns.
+
+-- RAISE takes an expression, which lets the check report the values.
+CREATE TEMP TABLE migration_error (msg TEXT);
+CREATE TEMP TRIGGER migration_error BEFORE INSERT ON migration_error
+BEGIN
+	SELECT RAISE(ABORT, NEW.msg);
+END;
+
+WITH vals (name, v) AS (
+	SELECT 'DP_Z00001.VALIDFROM', CAST("VALIDFROM" AS TEXT) FROM "DP_Z00001"
+	UNION ALL
+	SELECT 'DP_Z00001.VALIDTO', CAST("VALIDTO" AS TEXT) FROM "DP_Z00001"
+), bad (name, v, n) AS (
+	SELECT name, v, count(*) FROM vals
+	WHERE v <> '0' AND (
+		v NOT GLOB '[0-9][0-9][0-9][0-9][0-9][0-9][0-9][0-9]'
+		OR date(substr(v, 1, 4) || '-' || substr(v, 5, 2) || '-' || substr(v, 7, 2)) IS NOT substr(v, 1, 4) || '-' || substr(v, 5, 2) || '-' || substr(v, 7, 2)
+	)
+	GROUP BY name, v
+)
+INSERT INTO migration_error (msg)
+SELECT 'values that are not a date, correct or clear them first: '
+	|| group_concat(name || ' = ''' || substr(v, 1, 100) || ''' (' || n || ' rows)', '; ')
+FROM bad
+HAVING count(*) > 0;
+
+DROP TRIGGER migration_error;
+DROP TABLE migration_error;
+
+CREATE TABLE "DP_Z00001__new" (
+	"PROCESS_ID" VARCHAR(255),
+	"BLOCKID" VARCHAR(255),
+	"BLOCKNAME" VARCHAR(255),
+	"STEP" VARCHAR(255),
+	"COUNTER" VARCHAR(255),
+	"CONDITIONTYPE" VARCHAR(255),
+	"DESCRIPTION" VARCHAR(255),
+	"VALIDFROM" DATE,
+	"VALIDTO" DATE,
+	"CONDGRP" VARCHAR(255),
+	"DRULE" VARCHAR(255),
+	"FILENAME" VARCHAR(255),
+	"LINENUMBER" INTEGER,
+	"CDATE" DATETIME,
+	"DISCTYPE" VARCHAR(255)
+);
+INSERT INTO "DP_Z00001__new" ("PROCESS_ID", "BLOCKID", "BLOCKNAME", "STEP", "COUNTER", "CONDITIONTYPE", "DESCRIPTION", "VALIDFROM", "VALIDTO", "CONDGRP", "DRULE", "FILENAME", "LINENUMBER", "CDATE", "DISCTYPE")
+SELECT
+	"PROCESS_ID",
+	"BLOCKID",
+	"BLOCKNAME",
+	"STEP",
+	"COUNTER",
+	"CONDITIONTYPE",
+	"DESCRIPTION",
+	CASE WHEN "VALIDFROM" IS NULL OR "VALIDFROM" = 0 THEN NULL ELSE substr(CAST("VALIDFROM" AS TEXT), 1, 4) || '-' || substr(CAST("VALIDFROM" AS TEXT), 5, 2) || '-' || substr(CAST("VALIDFROM" AS TEXT), 7, 2) END,
+	CASE WHEN "VALIDTO" IS NULL OR "VALIDTO" = 0 THEN NULL ELSE substr(CAST("VALIDTO" AS TEXT), 1, 4) || '-' || substr(CAST("VALIDTO" AS TEXT), 5, 2) || '-' || substr(CAST("VALIDTO" AS TEXT), 7, 2) END,
+	"CONDGRP",
+	"DRULE",
+	"FILENAME",
+	"LINENUMBER",
+	"CDATE",
+	"DISCTYPE"
+FROM "DP_Z00001";
+DROP TABLE "DP_Z00001";
+ALTER TABLE "DP_Z00001__new" RENAME TO "DP_Z00001";
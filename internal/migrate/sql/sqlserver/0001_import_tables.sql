@@ -0,0 +1,760 @@
+-- Landing tables filled by the import writers (internal/worker). Each
+-- table that is upserted gets a unique index on the key the writer merges
+-- on (TableSpec.Keys), also when the table already exists; a table that
+-- holds duplicate keys stops the migration until they are removed.
+
+IF OBJECT_ID(N'dbo.m_price_dummy', N'U') IS NULL
+CREATE TABLE dbo.m_price_dummy (
+	[UNIQ_ID] NVARCHAR(255) NULL,
+	[LINE_NO] INT NULL,
+	[PRICE_CODE] NVARCHAR(255) NULL,
+	[BRANCH_ID] NVARCHAR(255) NULL,
+	[PCODE] NVARCHAR(255) NULL,
+	[PRICE_VALUE] NVARCHAR(255) NULL,
+	[PRICE_UOM] NVARCHAR(255) NULL,
+	[CBY] NVARCHAR(255) NULL,
+	[CDATE] DATETIME NULL,
+	[MBY] NVARCHAR(255) NULL,
+	[MDATE] DATETIME NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'dbo.fgharga', N'U') IS NULL
+CREATE TABLE dbo.fgharga (
+	[GHARGA] NVARCHAR(255) NULL,
+	[KET] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fgharga') AND name = N'ux_fgharga')
+CREATE UNIQUE INDEX [ux_fgharga] ON dbo.fgharga ([GHARGA]);
+GO
+
+IF OBJECT_ID(N'dbo.fcustmst', N'U') IS NULL
+CREATE TABLE dbo.fcustmst (
+	[CUSTNO] NVARCHAR(255) NULL,
+	[DATA01] NVARCHAR(255) NULL,
+	[CUSTNAME] NVARCHAR(255) NULL,
+	[CUSTADD1] NVARCHAR(255) NULL,
+	[CUSTADD2] NVARCHAR(255) NULL,
+	[CCITY] NVARCHAR(255) NULL,
+	[CCONTACT] NVARCHAR(255) NULL,
+	[CPHONE1] NVARCHAR(255) NULL,
+	[CFAXNO] NVARCHAR(255) NULL,
+	[CTERM] NVARCHAR(255) NULL,
+	[CLIMIT] INT NULL,
+	[FLAGLIMIT] NVARCHAR(255) NULL,
+	[GDISC] NVARCHAR(255) NULL,
+	[GRUPOUT] NVARCHAR(255) NULL,
+	[TYPEOUT] NVARCHAR(255) NULL,
+	[GHARGA] NVARCHAR(255) NULL,
+	[FLAGPAY] NVARCHAR(255) NULL,
+	[FLAGOUT] NVARCHAR(255) NULL,
+	[RPP] INT NULL,
+	[LSALES] INT NULL,
+	[LDATETRS] NVARCHAR(255) NULL,
+	[LOKASI] NVARCHAR(255) NULL,
+	[DISTRIK] NVARCHAR(255) NULL,
+	[BEAT] NVARCHAR(255) NULL,
+	[SUBBEAT] NVARCHAR(255) NULL,
+	[KLASIF] NVARCHAR(255) NULL,
+	[KINDUS] NVARCHAR(255) NULL,
+	[KPASAR] NVARCHAR(255) NULL,
+	[KODECABANG] NVARCHAR(255) NULL,
+	[LA] NVARCHAR(255) NULL,
+	[LG] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fcustmst') AND name = N'ux_fcustmst')
+CREATE UNIQUE INDEX [ux_fcustmst] ON dbo.fcustmst ([CUSTNO], [KODECABANG]);
+GO
+
+IF OBJECT_ID(N'dbo.fmaster', N'U') IS NULL
+CREATE TABLE dbo.fmaster (
+	[PRLIN] NVARCHAR(225) NULL,
+	[BRAND] NVARCHAR(225) NULL,
+	[PCODE] NVARCHAR(225) NULL,
+	[DATA1] NVARCHAR(225) NULL,
+	[PCODENAME] NVARCHAR(225) NULL,
+	[UNIT1] NVARCHAR(225) NULL,
+	[UNIT2] NVARCHAR(225) NULL,
+	[UNIT3] NVARCHAR(225) NULL,
+	[UNIT4] NVARCHAR(225) NULL,
+	[UNIT5] NVARCHAR(225) NULL,
+	[CONVUNIT2] INT NULL,
+	[CONVUNIT3] INT NULL,
+	[CONVUNIT4] INT NULL,
+	[CONVUNIT5] INT NULL,
+	[PPN] INT NULL,
+	[FLAG_AKTIF] NVARCHAR(225) NULL,
+	[FLAG_GIFT] NVARCHAR(225) NULL,
+	[SHORTNAME1] NVARCHAR(225) NULL,
+	[UOM1_BUY] NVARCHAR(225) NULL,
+	[UOM2_BUY] NVARCHAR(225) NULL,
+	[UOM3_BUY] NVARCHAR(225) NULL,
+	[UOM4_BUY] NVARCHAR(225) NULL,
+	[UOM5_BUY] NVARCHAR(225) NULL,
+	[UOM_BASE] NVARCHAR(225) NULL,
+	[UOM_MAIN] NVARCHAR(225) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fmaster') AND name = N'ux_fmaster')
+CREATE UNIQUE INDEX [ux_fmaster] ON dbo.fmaster ([PCODE]);
+GO
+
+IF OBJECT_ID(N'dbo.fgrupout', N'U') IS NULL
+CREATE TABLE dbo.fgrupout (
+	[GROUPOUT] NVARCHAR(255) NULL,
+	[GROUPNAME] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fgrupout') AND name = N'ux_fgrupout')
+CREATE UNIQUE INDEX [ux_fgrupout] ON dbo.fgrupout ([GROUPOUT]);
+GO
+
+IF OBJECT_ID(N'dbo.findustri', N'U') IS NULL
+CREATE TABLE dbo.findustri (
+	[INDUSID] NVARCHAR(255) NULL,
+	[INDUSNAME] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.findustri') AND name = N'ux_findustri')
+CREATE UNIQUE INDEX [ux_findustri] ON dbo.findustri ([INDUSID]);
+GO
+
+IF OBJECT_ID(N'dbo.fsalesman', N'U') IS NULL
+CREATE TABLE dbo.fsalesman (
+	[SLSNO] NVARCHAR(255) NULL,
+	[SLSNAME] NVARCHAR(255) NULL,
+	[ALAMAT1] NVARCHAR(255) NULL,
+	[ALAMAT2] NVARCHAR(255) NULL,
+	[KOTA] NVARCHAR(255) NULL,
+	[PENDIDIKAN] NVARCHAR(255) NULL,
+	[TGLLAHIR] NVARCHAR(255) NULL,
+	[TGLMASUK] NVARCHAR(255) NULL,
+	[TGLTRANS] NVARCHAR(255) NULL,
+	[SLSPASS] NVARCHAR(255) NULL,
+	[EC1] NVARCHAR(255) NULL,
+	[ITEM] NVARCHAR(255) NULL,
+	[KODECABANG] NVARCHAR(255) NULL,
+	[ATASAN_ID] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fsalesman') AND name = N'ux_fsalesman')
+CREATE UNIQUE INDEX [ux_fsalesman] ON dbo.fsalesman ([SLSNO], [KODECABANG]);
+GO
+
+IF OBJECT_ID(N'dbo.sap_web_inv_sfa', N'U') IS NULL
+CREATE TABLE dbo.sap_web_inv_sfa (
+	[SLSNO] NVARCHAR(255) NULL,
+	[CUSTNO] NVARCHAR(255) NULL,
+	[SFA_ORDER_NO] NVARCHAR(255) NULL,
+	[SFA_ORDER_DATE] NVARCHAR(255) NULL,
+	[ORDERNO] NVARCHAR(255) NULL,
+	[ORDER_DATE] NVARCHAR(255) NULL,
+	[INVOICE_NO] NVARCHAR(255) NULL,
+	[INVOICE_DATE] NVARCHAR(255) NULL,
+	[PCODE] NVARCHAR(255) NULL,
+	[QTY] INT NULL,
+	[PRICE] FLOAT NULL,
+	[DISKON] FLOAT NULL,
+	[KODECABANG] NVARCHAR(255) NULL,
+	[INV_TYPE] NVARCHAR(255) NULL,
+	[REF_CN] NVARCHAR(255) NULL,
+	[INVAMOUNT] FLOAT NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.sap_web_inv_sfa') AND name = N'ux_sap_web_inv_sfa')
+CREATE UNIQUE INDEX [ux_sap_web_inv_sfa] ON dbo.sap_web_inv_sfa ([SLSNO], [CUSTNO], [SFA_ORDER_NO], [ORDERNO], [INVOICE_NO], [PCODE], [KODECABANG], [INV_TYPE]);
+GO
+
+IF OBJECT_ID(N'dbo.fpiutang_temp', N'U') IS NULL
+CREATE TABLE dbo.fpiutang_temp (
+	[CUSTNO] NVARCHAR(255) NULL,
+	[INVNO] NVARCHAR(255) NULL,
+	[INVDATE] NVARCHAR(255) NULL,
+	[DUEDATE] NVARCHAR(255) NULL,
+	[INVAMOUNT] NVARCHAR(255) NULL,
+	[AMOUNTPAID] NVARCHAR(255) NULL,
+	[SLSNO] NVARCHAR(255) NULL,
+	[KODECABANG] NVARCHAR(255) NULL,
+	[INV_TYPE] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fpiutang_temp') AND name = N'ux_fpiutang_temp')
+CREATE UNIQUE INDEX [ux_fpiutang_temp] ON dbo.fpiutang_temp ([CUSTNO], [INVNO], [SLSNO], [KODECABANG]);
+GO
+
+IF OBJECT_ID(N'dbo.fstockbarang', N'U') IS NULL
+CREATE TABLE dbo.fstockbarang (
+	[KG] NVARCHAR(255) NULL,
+	[PCODE] NVARCHAR(255) NULL,
+	[STOCK] INT NULL,
+	[KODECABANG] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fstockbarang') AND name = N'ux_fstockbarang')
+CREATE UNIQUE INDEX [ux_fstockbarang] ON dbo.fstockbarang ([KG], [PCODE], [KODECABANG]);
+GO
+
+IF OBJECT_ID(N'dbo.forder_hd_status', N'U') IS NULL
+CREATE TABLE dbo.forder_hd_status (
+	[TGLORDER] NVARCHAR(255) NULL,
+	[ORDERNO] NVARCHAR(255) NULL,
+	[SLSNO] NVARCHAR(255) NULL,
+	[CUSTNO] NVARCHAR(255) NULL,
+	[KODECABANG] NVARCHAR(255) NULL,
+	[ORDERNO_TOPUP] NVARCHAR(255) NULL,
+	[PCODE] NVARCHAR(255) NULL,
+	[STATUS] NVARCHAR(255) NULL,
+	[STATUS_DETAIL] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.forder_hd_status') AND name = N'ux_forder_hd_status')
+CREATE UNIQUE INDEX [ux_forder_hd_status] ON dbo.forder_hd_status ([TGLORDER], [ORDERNO], [SLSNO], [CUSTNO], [KODECABANG], [ORDERNO_TOPUP], [PCODE]);
+GO
+
+IF OBJECT_ID(N'dbo.gm_cust_wilayah', N'U') IS NULL
+CREATE TABLE dbo.gm_cust_wilayah (
+	[wc_district_id] NVARCHAR(255) NULL,
+	[wc_wilayah_id] NVARCHAR(255) NULL,
+	[wc_wilayah_desc] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.gm_cust_wilayah') AND name = N'ux_gm_cust_wilayah')
+CREATE UNIQUE INDEX [ux_gm_cust_wilayah] ON dbo.gm_cust_wilayah ([wc_district_id], [wc_wilayah_id]);
+GO
+
+IF OBJECT_ID(N'dbo.fcredit_limit', N'U') IS NULL
+CREATE TABLE dbo.fcredit_limit (
+	[CUSTNO] NVARCHAR(255) NULL,
+	[CUSTNAME] NVARCHAR(255) NULL,
+	[CREDIT_LIMIT] INT NULL,
+	[SISA_CREDIT_LIMIT] INT NULL,
+	[KODECABANG] NVARCHAR(255) NULL,
+	[UPDATEBY] NVARCHAR(255) NULL,
+	[UPDATEDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fcredit_limit') AND name = N'ux_fcredit_limit')
+CREATE UNIQUE INDEX [ux_fcredit_limit] ON dbo.fcredit_limit ([CUSTNO], [KODECABANG]);
+GO
+
+IF OBJECT_ID(N'dbo.fmst_custinv_d', N'U') IS NULL
+CREATE TABLE dbo.fmst_custinv_d (
+	[BID] NVARCHAR(255) NULL,
+	[BNAME] NVARCHAR(255) NULL,
+	[MUID] NVARCHAR(255) NULL,
+	[MUNAME] NVARCHAR(255) NULL,
+	[CUSTNO] NVARCHAR(255) NULL,
+	[CUSTNAME] NVARCHAR(255) NULL,
+	[INVNO] NVARCHAR(255) NULL,
+	[INVDATE] NVARCHAR(255) NULL,
+	[DUEDATE] NVARCHAR(255) NULL,
+	[INV_AMOUNT] FLOAT NULL,
+	[INV_OUTSTANDING] FLOAT NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fmst_custinv_d') AND name = N'ux_fmst_custinv_d')
+CREATE UNIQUE INDEX [ux_fmst_custinv_d] ON dbo.fmst_custinv_d ([BID], [MUID], [CUSTNO], [INVNO]);
+GO
+
+IF OBJECT_ID(N'dbo.fmst_custinv_h', N'U') IS NULL
+CREATE TABLE dbo.fmst_custinv_h (
+	[BID] NVARCHAR(225) NULL,
+	[BNAME] NVARCHAR(225) NULL,
+	[MUID] NVARCHAR(225) NULL,
+	[MUNAME] NVARCHAR(225) NULL,
+	[CUSTNO] NVARCHAR(225) NULL,
+	[CUSTNAME] NVARCHAR(225) NULL,
+	[INV_TOTAL] NVARCHAR(225) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fmst_custinv_h') AND name = N'ux_fmst_custinv_h')
+CREATE UNIQUE INDEX [ux_fmst_custinv_h] ON dbo.fmst_custinv_h ([BID], [MUID], [CUSTNO]);
+GO
+
+IF OBJECT_ID(N'dbo.ftypeout', N'U') IS NULL
+CREATE TABLE dbo.ftypeout (
+	[TYPE] NVARCHAR(255) NULL,
+	[TYPENAME] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.ftypeout') AND name = N'ux_ftypeout')
+CREATE UNIQUE INDEX [ux_ftypeout] ON dbo.ftypeout ([TYPE]);
+GO
+
+IF OBJECT_ID(N'dbo.fdistrik', N'U') IS NULL
+CREATE TABLE dbo.fdistrik (
+	[KODECABANG] NVARCHAR(255) NULL,
+	[DISTRIK] NVARCHAR(255) NULL,
+	[DISTRIKNAME] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fdistrik') AND name = N'ux_fdistrik')
+CREATE UNIQUE INDEX [ux_fdistrik] ON dbo.fdistrik ([DISTRIK], [KODECABANG]);
+GO
+
+IF OBJECT_ID(N'dbo.fkategori', N'U') IS NULL
+CREATE TABLE dbo.fkategori (
+	[KODE] NVARCHAR(255) NULL,
+	[KET] NVARCHAR(255) NULL,
+	[KODEDISTRIBUTOR] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fkategori') AND name = N'ux_fkategori')
+CREATE UNIQUE INDEX [ux_fkategori] ON dbo.fkategori ([KODE], [KODEDISTRIBUTOR]);
+GO
+
+IF OBJECT_ID(N'dbo.mkplprice_dummy', N'U') IS NULL
+CREATE TABLE dbo.mkplprice_dummy (
+	[UNIQ_ID] NVARCHAR(255) NULL,
+	[LINE_NO] INT NULL,
+	[CUST_CODE] NVARCHAR(255) NULL,
+	[BRANCH_ID] NVARCHAR(255) NULL,
+	[PCODE] FLOAT NULL,
+	[PRICE_VALUE] NVARCHAR(255) NULL,
+	[PRICE_UOM] NVARCHAR(255) NULL,
+	[CBY] NVARCHAR(255) NULL,
+	[CDATE] DATETIME NULL,
+	[MBY] NVARCHAR(255) NULL,
+	[MDATE] DATETIME NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'dbo.gm_cust_market', N'U') IS NULL
+CREATE TABLE dbo.gm_cust_market (
+	[psr_pasar_id] NVARCHAR(255) NULL,
+	[psr_long_desc] NVARCHAR(255) NULL,
+	[psr_short_desc] NVARCHAR(255) NULL,
+	[kodecabang] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.gm_cust_market') AND name = N'ux_gm_cust_market')
+CREATE UNIQUE INDEX [ux_gm_cust_market] ON dbo.gm_cust_market ([psr_pasar_id], [kodecabang]);
+GO
+
+IF OBJECT_ID(N'dbo.FMST_PAYTO', N'U') IS NULL
+CREATE TABLE dbo.FMST_PAYTO (
+	[CUSTNO] NVARCHAR(255) NULL,
+	[CUSTNO_BIL] NVARCHAR(255) NULL,
+	[DESC_CUSTNO_BIL] NVARCHAR(255) NULL,
+	[KODECABANG] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.FMST_PAYTO') AND name = N'ux_FMST_PAYTO')
+CREATE UNIQUE INDEX [ux_FMST_PAYTO] ON dbo.FMST_PAYTO ([KODECABANG], [CUSTNO]);
+GO
+
+IF OBJECT_ID(N'dbo.fprovinsi', N'U') IS NULL
+CREATE TABLE dbo.fprovinsi (
+	[PROVINSI_ID] NVARCHAR(255) NULL,
+	[PROVINSI_NAME] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fprovinsi') AND name = N'ux_fprovinsi')
+CREATE UNIQUE INDEX [ux_fprovinsi] ON dbo.fprovinsi ([PROVINSI_ID]);
+GO
+
+IF OBJECT_ID(N'dbo.frute', N'U') IS NULL
+CREATE TABLE dbo.frute (
+	[REGION] NVARCHAR(255) NULL,
+	[CABANG] NVARCHAR(255) NULL,
+	[KODECABANG] NVARCHAR(255) NULL,
+	[SLSNO] NVARCHAR(255) NULL,
+	[NORUTE] NVARCHAR(255) NULL,
+	[CUSTNO] NVARCHAR(255) NULL,
+	[H1] NVARCHAR(255) NULL,
+	[H2] NVARCHAR(255) NULL,
+	[H3] NVARCHAR(255) NULL,
+	[H4] NVARCHAR(255) NULL,
+	[H5] NVARCHAR(255) NULL,
+	[H6] NVARCHAR(255) NULL,
+	[H7] NVARCHAR(255) NULL,
+	[M1] NVARCHAR(255) NULL,
+	[M2] NVARCHAR(255) NULL,
+	[M3] NVARCHAR(255) NULL,
+	[M4] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.frute') AND name = N'ux_frute')
+CREATE UNIQUE INDEX [ux_frute] ON dbo.frute ([REGION], [CABANG], [KODECABANG], [SLSNO], [NORUTE], [CUSTNO]);
+GO
+
+IF OBJECT_ID(N'dbo.fbrand', N'U') IS NULL
+CREATE TABLE dbo.fbrand (
+	[BRAND] NVARCHAR(255) NULL,
+	[BRANDNAME] NVARCHAR(255) NULL,
+	[KODECABANG] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fbrand') AND name = N'ux_fbrand')
+CREATE UNIQUE INDEX [ux_fbrand] ON dbo.fbrand ([BRAND], [KODECABANG]);
+GO
+
+IF OBJECT_ID(N'dbo.fshippto', N'U') IS NULL
+CREATE TABLE dbo.fshippto (
+	[CUSTNO] NVARCHAR(255) NULL,
+	[CUSTNO_SHIP] NVARCHAR(255) NULL,
+	[DESC_CUSTNO_SHIP] NVARCHAR(255) NULL,
+	[KODECABANG] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fshippto') AND name = N'ux_fshippto')
+CREATE UNIQUE INDEX [ux_fshippto] ON dbo.fshippto ([CUSTNO], [KODECABANG]);
+GO
+
+IF OBJECT_ID(N'dbo.fprlin', N'U') IS NULL
+CREATE TABLE dbo.fprlin (
+	[PRLIN] NVARCHAR(255) NULL,
+	[PRLINAME] NVARCHAR(255) NULL,
+	[KOMPFLAG] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fprlin') AND name = N'ux_fprlin')
+CREATE UNIQUE INDEX [ux_fprlin] ON dbo.fprlin ([PRLIN]);
+GO
+
+IF OBJECT_ID(N'dbo.gm_cust_rayon', N'U') IS NULL
+CREATE TABLE dbo.gm_cust_rayon (
+	[rc_district_id] NVARCHAR(255) NULL,
+	[rc_wilayah_id] NVARCHAR(255) NULL,
+	[rc_rayon_id] NVARCHAR(255) NULL,
+	[rc_rayon_desc] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.gm_cust_rayon') AND name = N'ux_gm_cust_rayon')
+CREATE UNIQUE INDEX [ux_gm_cust_rayon] ON dbo.gm_cust_rayon ([rc_district_id], [rc_wilayah_id], [rc_rayon_id]);
+GO
+
+IF OBJECT_ID(N'dbo.fsubbrand', N'U') IS NULL
+CREATE TABLE dbo.fsubbrand (
+	[KODE] NVARCHAR(255) NULL,
+	[BRAND] NVARCHAR(255) NULL,
+	[KET] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.fsubbrand') AND name = N'ux_fsubbrand')
+CREATE UNIQUE INDEX [ux_fsubbrand] ON dbo.fsubbrand ([KODE], [BRAND]);
+GO
+
+IF OBJECT_ID(N'dbo.ftop', N'U') IS NULL
+CREATE TABLE dbo.ftop (
+	[TOP] NVARCHAR(255) NULL,
+	[TOP_DESC] NVARCHAR(255) NULL,
+	[TOP_DAYS] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.ftop') AND name = N'ux_ftop')
+CREATE UNIQUE INDEX [ux_ftop] ON dbo.ftop ([TOP]);
+GO
+
+IF OBJECT_ID(N'dbo.DP_ZDHDR', N'U') IS NULL
+CREATE TABLE dbo.DP_ZDHDR (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[CONDITIONTYPE] NVARCHAR(255) NULL,
+	[KEYCOMBINATION] NVARCHAR(255) NULL,
+	[KEYCOMB] NVARCHAR(255) NULL,
+	[SALESORGANIZATION] NVARCHAR(255) NULL,
+	[DISTRIBUTIONCHANNEL] NVARCHAR(255) NULL,
+	[SALESOFFICE] NVARCHAR(255) NULL,
+	[DIVISION] NVARCHAR(255) NULL,
+	[PAYMENTTERM] NVARCHAR(255) NULL,
+	[CUSTOMER] NVARCHAR(255) NULL,
+	[MATERIAL] NVARCHAR(255) NULL,
+	[ATTRIBUT2] NVARCHAR(255) NULL,
+	[VALIDUNTIL] DATETIME NULL,
+	[VALIDFROM] DATETIME NULL,
+	[CONDITIONRECORDNO] NVARCHAR(255) NULL,
+	[SCALE] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'dbo.DP_ZDITM', N'U') IS NULL
+CREATE TABLE dbo.DP_ZDITM (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[CONDITIONTYPE] NVARCHAR(255) NULL,
+	[KEYCOMBINATION] NVARCHAR(255) NULL,
+	[KEYCOMB] NVARCHAR(255) NULL,
+	[SALESORGANIZATION] NVARCHAR(255) NULL,
+	[DISTRIBUTIONCHANNEL] NVARCHAR(255) NULL,
+	[SALESOFFICE] NVARCHAR(255) NULL,
+	[DIVISION] NVARCHAR(255) NULL,
+	[SOLDTOPARTY] NVARCHAR(255) NULL,
+	[PRICINGREFMATL] NVARCHAR(255) NULL,
+	[PAYMENTTERMS] NVARCHAR(255) NULL,
+	[INDUSTRYCODE3] NVARCHAR(255) NULL,
+	[INDUSTRYCODE4] NVARCHAR(255) NULL,
+	[INDUSTRYCODE5] NVARCHAR(255) NULL,
+	[ATTRIBUTE1] NVARCHAR(255) NULL,
+	[ATTRIBUTE2] NVARCHAR(255) NULL,
+	[MATERIAL] NVARCHAR(255) NULL,
+	[SALESUNIT] NVARCHAR(255) NULL,
+	[VALIDFROM] DATETIME NULL,
+	[VALIDUNTIL] DATETIME NULL,
+	[CONDITIONRECORDNO] NVARCHAR(255) NULL,
+	[SCALE] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'dbo.DP_ZDDET', N'U') IS NULL
+CREATE TABLE dbo.DP_ZDDET (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[CONDITIONRECORDNO] NVARCHAR(255) NULL,
+	[AMOUNT] FLOAT NULL,
+	[UNIT] NVARCHAR(255) NULL,
+	[PER] FLOAT NULL,
+	[UOM] NVARCHAR(255) NULL,
+	[SCALE] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'dbo.DP_ZPMIX', N'U') IS NULL
+CREATE TABLE dbo.DP_ZPMIX (
+	[PROCESS_ID] NVARCHAR(50) NULL,
+	[BLOCKID] NVARCHAR(3) NULL,
+	[BLOCKNAME] NVARCHAR(50) NULL,
+	[CTYP] NVARCHAR(20) NULL,
+	[KEYCOMBINATION] NVARCHAR(20) NULL,
+	[SORG] NVARCHAR(20) NULL,
+	[DCHL] NVARCHAR(20) NULL,
+	[SOFF] NVARCHAR(20) NULL,
+	[DV] NVARCHAR(20) NULL,
+	[CUSTOMER] NVARCHAR(20) NULL,
+	[INDCODE2] NVARCHAR(20) NULL,
+	[INDCODE3] NVARCHAR(20) NULL,
+	[INDCODE4] NVARCHAR(20) NULL,
+	[INDCODE5] NVARCHAR(20) NULL,
+	[PL] NVARCHAR(20) NULL,
+	[PAYT] NVARCHAR(20) NULL,
+	[MATERIAL] NVARCHAR(20) NULL,
+	[VALIDFROM] DATE NULL,
+	[VALIDUNTIL] DATE NULL,
+	[PROMOID] NVARCHAR(20) NULL,
+	[LINEITEM] INT NULL,
+	[FILENAME] NVARCHAR(200) NULL,
+	[LINENUMBER] BIGINT NULL,
+	[CDATE] DATETIME NULL,
+	[MUSTBUY] NVARCHAR(5) NULL,
+	[EXCLUDE] NVARCHAR(5) NULL,
+	[SPLIT] NVARCHAR(5) NULL,
+	[AMOUNTX] NVARCHAR(1) NULL,
+	[RANGEX] NVARCHAR(5) NULL,
+	[WITHMATERIAL] NVARCHAR(5) NULL,
+	[KELIPATAN] NVARCHAR(5) NULL,
+	[V_KELIPATAN] INT NULL,
+	[ATTR_PRD_LV2] NVARCHAR(20) NULL,
+	[ATTR_PRD_LV3] NVARCHAR(20) NULL,
+	[FL_CUST_EXC] NVARCHAR(20) NULL,
+	[CUST_EXC] NVARCHAR(20) NULL,
+	[FL_HD] NVARCHAR(100) NULL,
+	[PERBANDINGAN] NVARCHAR(20) NULL,
+	[V_PERBANDINGAN1] INT NULL,
+	[V_PERBANDINGAN2] INT NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.DP_ZPMIX') AND name = N'ux_DP_ZPMIX')
+CREATE UNIQUE INDEX [ux_DP_ZPMIX] ON dbo.DP_ZPMIX ([BLOCKID], [PROMOID], [LINEITEM], [CTYP], [KEYCOMBINATION], [SORG], [DCHL], [SOFF], [DV], [CUSTOMER], [PL], [PAYT], [MATERIAL], [INDCODE2], [INDCODE3], [INDCODE4], [INDCODE5], [CUST_EXC]);
+GO
+
+IF OBJECT_ID(N'dbo.DP_FG_CHECK', N'U') IS NULL
+CREATE TABLE dbo.DP_FG_CHECK (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[PROMOID] NVARCHAR(255) NULL,
+	[DDATE] DATE NULL,
+	[CDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.DP_FG_CHECK') AND name = N'ux_DP_FG_CHECK')
+CREATE UNIQUE INDEX [ux_DP_FG_CHECK] ON dbo.DP_FG_CHECK ([BLOCKID], [PROMOID], [DDATE]);
+GO
+
+IF OBJECT_ID(N'dbo.DP_ZSCREG', N'U') IS NULL
+CREATE TABLE dbo.DP_ZSCREG (
+	[PROCESS_ID] NVARCHAR(50) NULL,
+	[BLOCKID] NVARCHAR(3) NULL,
+	[BLOCKNAME] NVARCHAR(50) NULL,
+	[CONDITIONRECORDNO] NVARCHAR(20) NULL,
+	[NO] INT NULL,
+	[LSNO] INT NULL,
+	[DISCREGHDRQTY] DECIMAL(19,4) NULL,
+	[AMOUNT] DECIMAL(19,4) NULL,
+	[UNIT] NVARCHAR(25) NULL,
+	[FILENAME] NVARCHAR(200) NULL,
+	[LINENUMBER] BIGINT NULL,
+	[CDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.DP_ZSCREG') AND name = N'ux_DP_ZSCREG')
+CREATE UNIQUE INDEX [ux_DP_ZSCREG] ON dbo.DP_ZSCREG ([BLOCKID], [CONDITIONRECORDNO], [DISCREGHDRQTY]);
+GO
+
+IF OBJECT_ID(N'dbo.DP_ZSCMIX', N'U') IS NULL
+CREATE TABLE dbo.DP_ZSCMIX (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[PROMOID] NVARCHAR(255) NULL,
+	[LINEITEM] INT NULL,
+	[SCALEQTY] FLOAT NULL,
+	[BUN] NVARCHAR(255) NULL,
+	[AMOUNT] FLOAT NULL,
+	[UNIT] NVARCHAR(255) NULL,
+	[PER] FLOAT NULL,
+	[UOM] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL,
+	[SCALEQTYTO] FLOAT NULL,
+	[AMOUNTSCL] FLOAT NULL,
+	[AMOUNTSCLTO] FLOAT NULL,
+	[UNITSCL] NVARCHAR(255) NULL,
+	[MATNRKENA] NVARCHAR(255) NULL
+);
+GO
+
+IF OBJECT_ID(N'dbo.DP_Z00001', N'U') IS NULL
+CREATE TABLE dbo.DP_Z00001 (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[STEP] NVARCHAR(255) NULL,
+	[COUNTER] NVARCHAR(255) NULL,
+	[CONDITIONTYPE] NVARCHAR(255) NULL,
+	[DESCRIPTION] NVARCHAR(255) NULL,
+	[VALIDFROM] INT NULL,
+	[VALIDTO] INT NULL,
+	[CONDGRP] NVARCHAR(255) NULL,
+	[DRULE] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL,
+	[DISCTYPE] NVARCHAR(255) NULL
+);
+GO
+
+IF OBJECT_ID(N'dbo.FG_ZDHDR', N'U') IS NULL
+CREATE TABLE dbo.FG_ZDHDR (
+	[PROCESS_ID] NVARCHAR(50) NULL,
+	[BLOCKID] NVARCHAR(3) NULL,
+	[BLOCKNAME] NVARCHAR(50) NULL,
+	[CONDITIONTYPE] NVARCHAR(20) NULL,
+	[KEYCOMBINATION] NVARCHAR(20) NULL,
+	[KEYCOMB] NVARCHAR(180) NULL,
+	[SALESORGANIZATION] NVARCHAR(20) NULL,
+	[DISTRIBUTIONCHANNEL] NVARCHAR(20) NULL,
+	[DIVISION] NVARCHAR(20) NULL,
+	[SALESOFFICE] NVARCHAR(20) NULL,
+	[PRICELISTTYPE] NVARCHAR(20) NULL,
+	[ATTRIBUTE1] NVARCHAR(20) NULL,
+	[INDUSTRYCODE3] NVARCHAR(20) NULL,
+	[INDUSTRYCODE4] NVARCHAR(20) NULL,
+	[INDUSTRYCODE5] NVARCHAR(20) NULL,
+	[SOLDTOPARTY] NVARCHAR(20) NULL,
+	[MATERIAL] NVARCHAR(20) NULL,
+	[VALIDUNTIL] DATE NULL,
+	[VALIDFROM] DATE NULL,
+	[CONDITIONRECORDNO] NVARCHAR(20) NULL,
+	[PROMOID] NVARCHAR(20) NULL,
+	[PROMOITEM] NVARCHAR(20) NULL,
+	[SCALE] NVARCHAR(3) NULL,
+	[FILENAME] NVARCHAR(100) NULL,
+	[LINENUMBER] BIGINT NULL,
+	[CDATE] DATETIME NULL,
+	[MUSTBUY] NVARCHAR(5) NULL,
+	[KELIPATAN] NVARCHAR(5) NULL,
+	[F_KELIPATAN] INT NULL,
+	[WITHQTY] NVARCHAR(20) NULL,
+	[QTY] INT NULL,
+	[UOM] FLOAT NULL,
+	[ZTERM] NVARCHAR(5) NULL,
+	[KATR2] NVARCHAR(20) NULL,
+	[KATR3] NVARCHAR(20) NULL,
+	[PERBANDINGAN] NVARCHAR(20) NULL,
+	[F_PERBANDINGAN1] INT NULL,
+	[F_PERBANDINGAN2] INT NULL,
+	[AMOUNTX] NVARCHAR(1) NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.FG_ZDHDR') AND name = N'ux_FG_ZDHDR')
+CREATE UNIQUE INDEX [ux_FG_ZDHDR] ON dbo.FG_ZDHDR ([BLOCKID], [PROMOID], [PROMOITEM], [CONDITIONRECORDNO], [CONDITIONTYPE], [KEYCOMBINATION], [SALESORGANIZATION], [DISTRIBUTIONCHANNEL], [DIVISION], [SALESOFFICE], [PRICELISTTYPE], [ATTRIBUTE1], [INDUSTRYCODE3], [INDUSTRYCODE4], [INDUSTRYCODE5], [SOLDTOPARTY], [MATERIAL], [ZTERM], [KATR2], [KATR3]);
+GO
+
+IF OBJECT_ID(N'dbo.FG_ZFRDET', N'U') IS NULL
+CREATE TABLE dbo.FG_ZFRDET (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[CONDITIONRECORDNO] NVARCHAR(255) NULL,
+	[MINIMUMQTY] FLOAT NULL,
+	[FREEGOODSQTY] FLOAT NULL,
+	[UOMFREEGOODS] NVARCHAR(255) NULL,
+	[FREEGOODSAGRREDQTY] FLOAT NULL,
+	[UOMFREEGOODSAGRRED] NVARCHAR(255) NULL,
+	[ADDITIONALMATERIAL] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'dbo.FG_ZFRMIX', N'U') IS NULL
+CREATE TABLE dbo.FG_ZFRMIX (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[PROMOID] NVARCHAR(255) NULL,
+	[PROMOITEM] NVARCHAR(255) NULL,
+	[SCALEQTY] FLOAT NULL,
+	[SCALEQTYUOM] NVARCHAR(255) NULL,
+	[MATERIAL] NVARCHAR(255) NULL,
+	[QTY] FLOAT NULL,
+	[QTYUOM] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL,
+	[AMOUNTSCLF] FLOAT NULL,
+	[CURRENCY] NVARCHAR(255) NULL
+);
+GO
@@ -0,0 +1,55 @@
+-- Tables written by the SQL Server finalize steps.
+
+IF OBJECT_ID(N'dbo.fprice', N'U') IS NULL
+CREATE TABLE dbo.fprice (
+	KODECABANG NVARCHAR(50) NOT NULL,
+	GHARGA NVARCHAR(50) NOT NULL,
+	PCODE NVARCHAR(50) NOT NULL,
+	MG3 NVARCHAR(50) NOT NULL,
+	SELPRICE1 DECIMAL(19,4) NULL,
+	SELPRICE2 DECIMAL(19,4) NULL,
+	SELPRICE3 DECIMAL(19,4) NULL,
+	SELPRICE4 DECIMAL(19,4) NULL,
+	SELPRICE5 DECIMAL(19,4) NULL,
+	BUYPRICE1 DECIMAL(19,4) NULL,
+	BUYPRICE2 DECIMAL(19,4) NULL,
+	BUYPRICE3 DECIMAL(19,4) NULL,
+	BUYPRICE4 DECIMAL(19,4) NULL,
+	BUYPRICE5 DECIMAL(19,4) NULL,
+	CREATED_DATE DATETIME NULL,
+	CREATED_BY NVARCHAR(50) NULL,
+	UPDATED_DATE DATETIME NULL,
+	UPDATED_BY NVARCHAR(50) NULL,
+	CONSTRAINT PK_fprice PRIMARY KEY (KODECABANG, GHARGA, PCODE, MG3)
+);
+GO
+
+IF OBJECT_ID(N'dbo.fkpl_price', N'U') IS NULL
+CREATE TABLE dbo.fkpl_price (
+	KODECABANG NVARCHAR(50) NOT NULL,
+	CUSTNO NVARCHAR(50) NOT NULL,
+	PCODE NVARCHAR(50) NOT NULL,
+	SELLPRICE1 DECIMAL(19,4) NULL,
+	SELLPRICE2 DECIMAL(19,4) NULL,
+	SELLPRICE3 DECIMAL(19,4) NULL,
+	SELLPRICE4 DECIMAL(19,4) NULL,
+	SELLPRICE5 DECIMAL(19,4) NULL,
+	CREATED_DATE DATETIME NULL,
+	CREATED_BY NVARCHAR(50) NULL,
+	UPDATED_DATE DATETIME NULL,
+	UPDATED_BY NVARCHAR(50) NULL,
+	CONSTRAINT PK_fkpl_price PRIMARY KEY (KODECABANG, CUSTNO, PCODE)
+);
+GO
+
+IF OBJECT_ID(N'dbo.import_finalize_log', N'U') IS NULL
+CREATE TABLE dbo.import_finalize_log (
+	process_id varchar(36) NOT NULL,
+	block_code varchar(50) NOT NULL,
+	status varchar(20) NOT NULL,
+	started_at datetime2 NOT NULL,
+	finished_at datetime2 NULL,
+	error_message nvarchar(4000) NULL,
+	CONSTRAINT PK_import_finalize_log PRIMARY KEY (process_id, block_code)
+);
+GO
@@ -0,0 +1,12 @@
+-- Rows committed per process, table and source file by batched loads.
+
+IF OBJECT_ID(N'dbo.import_checkpoint', N'U') IS NULL
+CREATE TABLE dbo.import_checkpoint (
+	process_id varchar(36) NOT NULL,
+	table_name varchar(128) NOT NULL,
+	file_name nvarchar(255) NOT NULL,
+	rows_committed bigint NOT NULL,
+	updated_at datetime2 NOT NULL,
+	CONSTRAINT PK_import_checkpoint PRIMARY KEY (process_id, table_name, file_name)
+);
+GO
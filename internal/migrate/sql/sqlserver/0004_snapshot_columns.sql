@@ -0,0 +1,14 @@
+-- Markers for snapshot upserts (config snapshots): IS_ACTIVE for mode
+-- deactivate, DELETED_AT for mode flag.
+
+IF COL_LENGTH(N'dbo.fcustmst', N'IS_ACTIVE') IS NULL
+ALTER TABLE dbo.fcustmst ADD
+	[IS_ACTIVE] BIT NOT NULL CONSTRAINT DF_fcustmst_IS_ACTIVE DEFAULT 1,
+	[DELETED_AT] DATETIME NULL;
+GO
+
+IF COL_LENGTH(N'dbo.fmaster', N'IS_ACTIVE') IS NULL
+ALTER TABLE dbo.fmaster ADD
+	[IS_ACTIVE] BIT NOT NULL CONSTRAINT DF_fmaster_IS_ACTIVE DEFAULT 1,
+	[DELETED_AT] DATETIME NULL;
+GO
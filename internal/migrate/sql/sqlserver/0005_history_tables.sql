@@ -0,0 +1,87 @@
+-- Slowly changing dimension (type 2) history of the master tables, filled
+-- by upserts of the blocks listed under history. A version is open while
+-- VALID_TO is NULL.
+
+IF OBJECT_ID(N'dbo.fcustmst_history', N'U') IS NULL
+CREATE TABLE dbo.fcustmst_history (
+	[CUSTNO] NVARCHAR(255) NULL,
+	[DATA01] NVARCHAR(255) NULL,
+	[CUSTNAME] NVARCHAR(255) NULL,
+	[CUSTADD1] NVARCHAR(255) NULL,
+	[CUSTADD2] NVARCHAR(255) NULL,
+	[CCITY] NVARCHAR(255) NULL,
+	[CCONTACT] NVARCHAR(255) NULL,
+	[CPHONE1] NVARCHAR(255) NULL,
+	[CFAXNO] NVARCHAR(255) NULL,
+	[CTERM] NVARCHAR(255) NULL,
+	[CLIMIT] INT NULL,
+	[FLAGLIMIT] NVARCHAR(255) NULL,
+	[GDISC] NVARCHAR(255) NULL,
+	[GRUPOUT] NVARCHAR(255) NULL,
+	[TYPEOUT] NVARCHAR(255) NULL,
+	[GHARGA] NVARCHAR(255) NULL,
+	[FLAGPAY] NVARCHAR(255) NULL,
+	[FLAGOUT] NVARCHAR(255) NULL,
+	[RPP] INT NULL,
+	[LSALES] INT NULL,
+	[LDATETRS] NVARCHAR(255) NULL,
+	[LOKASI] NVARCHAR(255) NULL,
+	[DISTRIK] NVARCHAR(255) NULL,
+	[BEAT] NVARCHAR(255) NULL,
+	[SUBBEAT] NVARCHAR(255) NULL,
+	[KLASIF] NVARCHAR(255) NULL,
+	[KINDUS] NVARCHAR(255) NULL,
+	[KPASAR] NVARCHAR(255) NULL,
+	[KODECABANG] NVARCHAR(255) NULL,
+	[LA] NVARCHAR(255) NULL,
+	[LG] NVARCHAR(255) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL,
+	[VALID_FROM] DATETIME NOT NULL,
+	[VALID_TO] DATETIME NULL,
+	[PROCESS_ID] VARCHAR(36) NULL
+);
+GO
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'IX_fcustmst_history_key')
+CREATE INDEX IX_fcustmst_history_key ON dbo.fcustmst_history ([CUSTNO], [KODECABANG], [VALID_TO]);
+GO
+
+IF OBJECT_ID(N'dbo.fmaster_history', N'U') IS NULL
+CREATE TABLE dbo.fmaster_history (
+	[PRLIN] NVARCHAR(225) NULL,
+	[BRAND] NVARCHAR(225) NULL,
+	[PCODE] NVARCHAR(225) NULL,
+	[DATA1] NVARCHAR(225) NULL,
+	[PCODENAME] NVARCHAR(225) NULL,
+	[UNIT1] NVARCHAR(225) NULL,
+	[UNIT2] NVARCHAR(225) NULL,
+	[UNIT3] NVARCHAR(225) NULL,
+	[UNIT4] NVARCHAR(225) NULL,
+	[UNIT5] NVARCHAR(225) NULL,
+	[CONVUNIT2] INT NULL,
+	[CONVUNIT3] INT NULL,
+	[CONVUNIT4] INT NULL,
+	[CONVUNIT5] INT NULL,
+	[PPN] INT NULL,
+	[FLAG_AKTIF] NVARCHAR(225) NULL,
+	[FLAG_GIFT] NVARCHAR(225) NULL,
+	[SHORTNAME1] NVARCHAR(225) NULL,
+	[UOM1_BUY] NVARCHAR(225) NULL,
+	[UOM2_BUY] NVARCHAR(225) NULL,
+	[UOM3_BUY] NVARCHAR(225) NULL,
+	[UOM4_BUY] NVARCHAR(225) NULL,
+	[UOM5_BUY] NVARCHAR(225) NULL,
+	[UOM_BASE] NVARCHAR(225) NULL,
+	[UOM_MAIN] NVARCHAR(225) NULL,
+	[CORE_FILENAME] NVARCHAR(255) NULL,
+	[CORE_PROCESSDATE] DATETIME NULL,
+	[VALID_FROM] DATETIME NOT NULL,
+	[VALID_TO] DATETIME NULL,
+	[PROCESS_ID] VARCHAR(36) NULL
+);
+GO
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'IX_fmaster_history_key')
+CREATE INDEX IX_fmaster_history_key ON dbo.fmaster_history ([PCODE], [VALID_TO]);
+GO
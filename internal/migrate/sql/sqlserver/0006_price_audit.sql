@@ -0,0 +1,24 @@
+-- Every selling price the MPRICE and MKPLPRICE finalize steps changed.
+-- GHARGA is set for fprice rows, CUSTNO for fkpl_price rows.
+
+IF OBJECT_ID(N'dbo.price_audit', N'U') IS NULL
+CREATE TABLE dbo.price_audit (
+	id bigint IDENTITY(1,1) NOT NULL,
+	process_id varchar(36) NOT NULL,
+	price_table varchar(50) NOT NULL,
+	KODECABANG NVARCHAR(50) NOT NULL,
+	GHARGA NVARCHAR(50) NULL,
+	CUSTNO NVARCHAR(50) NULL,
+	PCODE NVARCHAR(50) NOT NULL,
+	price_column varchar(20) NOT NULL,
+	old_value DECIMAL(19,4) NULL,
+	new_value DECIMAL(19,4) NULL,
+	source_file nvarchar(255) NULL,
+	changed_at datetime2 NOT NULL,
+	CONSTRAINT PK_price_audit PRIMARY KEY (id)
+);
+GO
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE name = N'IX_price_audit_process')
+CREATE INDEX IX_price_audit_process ON dbo.price_audit (process_id, price_table);
+GO
@@ -0,0 +1,20 @@
+-- Prices per unit level, converted from PRICE_UOM by the parser and merged
+-- as they are by the MPRICE and MKPLPRICE finalize steps.
+
+IF COL_LENGTH(N'dbo.m_price_dummy', N'SELPRICE1') IS NULL
+ALTER TABLE dbo.m_price_dummy ADD
+	[SELPRICE1] DECIMAL(19,4) NULL,
+	[SELPRICE2] DECIMAL(19,4) NULL,
+	[SELPRICE3] DECIMAL(19,4) NULL,
+	[SELPRICE4] DECIMAL(19,4) NULL,
+	[SELPRICE5] DECIMAL(19,4) NULL;
+GO
+
+IF COL_LENGTH(N'dbo.mkplprice_dummy', N'SELLPRICE1') IS NULL
+ALTER TABLE dbo.mkplprice_dummy ADD
+	[SELLPRICE1] DECIMAL(19,4) NULL,
+	[SELLPRICE2] DECIMAL(19,4) NULL,
+	[SELLPRICE3] DECIMAL(19,4) NULL,
+	[SELLPRICE4] DECIMAL(19,4) NULL,
+	[SELLPRICE5] DECIMAL(19,4) NULL;
+GO
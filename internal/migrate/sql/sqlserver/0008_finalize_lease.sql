@@ -0,0 +1,11 @@
+-- Leases of RUNNING finalize steps: the owning run renews lease_expires_at
+-- while it works; an expired lease (a crashed run) can be taken over.
+-- Rows from before the lease have none and count as expired.
+
+IF COL_LENGTH(N'dbo.import_finalize_log', N'lease_owner') IS NULL
+ALTER TABLE dbo.import_finalize_log ADD
+	lease_owner varchar(100) NULL,
+	lease_expires_at datetime2 NULL,
+	heartbeat_at datetime2 NULL,
+	attempts int NOT NULL CONSTRAINT DF_import_finalize_log_attempts DEFAULT 0;
+GO
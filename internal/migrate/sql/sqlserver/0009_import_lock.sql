@@ -0,0 +1,12 @@
+-- Holder of each block lock (sp_getapplock resource), so a run that finds
+-- the block busy can say who has it.
+
+IF OBJECT_ID(N'dbo.import_lock', N'U') IS NULL
+CREATE TABLE dbo.import_lock (
+	resource varchar(255) NOT NULL,
+	holder varchar(100) NOT NULL,
+	process_id varchar(36) NULL,
+	acquired_at datetime2 NOT NULL,
+	CONSTRAINT PK_import_lock PRIMARY KEY (resource)
+);
+GO
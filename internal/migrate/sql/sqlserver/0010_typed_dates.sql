@@ -0,0 +1,87 @@
+-- File dates are stored as DATE instead of the text they were read as.
+-- Only the empty-date sentinels '' and '00000000' become NULL. Any other
+-- value that is not a date stops the migration before a column is changed,
+-- with the values and how many rows hold them; correct or clear those rows
+-- and run it again.
+
+-- TGLORDER is part of the forder_hd_status key; its unique index cannot
+-- stay on the column while the type changes and is rebuilt at the end.
+IF EXISTS (SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+	WHERE TABLE_SCHEMA = N'dbo' AND TABLE_NAME = N'forder_hd_status' AND COLUMN_NAME = N'TGLORDER' AND DATA_TYPE <> N'date')
+AND EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.forder_hd_status') AND name = N'ux_forder_hd_status')
+DROP INDEX [ux_forder_hd_status] ON dbo.forder_hd_status;
+GO
+
+DECLARE @cols TABLE (tbl sysname, col sysname);
+INSERT INTO @cols (tbl, col) VALUES
+	(N'sap_web_inv_sfa', N'SFA_ORDER_DATE'),
+	(N'sap_web_inv_sfa', N'ORDER_DATE'),
+	(N'sap_web_inv_sfa', N'INVOICE_DATE'),
+	(N'fpiutang_temp', N'INVDATE'),
+	(N'fpiutang_temp', N'DUEDATE'),
+	(N'forder_hd_status', N'TGLORDER'),
+	(N'fmst_custinv_d', N'INVDATE'),
+	(N'fmst_custinv_d', N'DUEDATE');
+
+-- Columns still to convert.
+DECLARE @todo TABLE (tbl sysname, col sysname);
+INSERT INTO @todo (tbl, col)
+SELECT c.tbl, c.col
+FROM @cols c
+JOIN INFORMATION_SCHEMA.COLUMNS ic
+	ON ic.TABLE_SCHEMA = N'dbo' AND ic.TABLE_NAME = c.tbl AND ic.COLUMN_NAME = c.col
+WHERE ic.DATA_TYPE <> N'date';
+
+DECLARE @bad TABLE (tbl sysname, col sysname, val nvarchar(100), n int);
+DECLARE @tbl sysname, @col sysname, @sql nvarchar(max);
+
+DECLARE todo CURSOR LOCAL FAST_FORWARD FOR SELECT tbl, col FROM @todo;
+OPEN todo;
+FETCH NEXT FROM todo INTO @tbl, @col;
+WHILE @@FETCH_STATUS = 0
+BEGIN
+	SET @sql = N'SELECT @tbl, @col, LEFT(CONVERT(nvarchar(4000), ' + QUOTENAME(@col) + N'), 100), COUNT(*)
+		FROM dbo.' + QUOTENAME(@tbl) + N'
+		WHERE ' + QUOTENAME(@col) + N' IS NOT NULL
+			AND LTRIM(RTRIM(' + QUOTENAME(@col) + N')) NOT IN (N'''', N''00000000'')
+			AND TRY_CONVERT(date, ' + QUOTENAME(@col) + N') IS NULL
+		GROUP BY ' + QUOTENAME(@col);
+	INSERT INTO @bad (tbl, col, val, n)
+	EXEC sp_executesql @sql, N'@tbl sysname, @col sysname', @tbl, @col;
+
+	FETCH NEXT FROM todo INTO @tbl, @col;
+END
+CLOSE todo;
+DEALLOCATE todo;
+
+IF EXISTS (SELECT 1 FROM @bad)
+BEGIN
+	DECLARE @msg nvarchar(2048) = N'values that are not a date, correct or clear them first: ' + LEFT(STUFF((
+		SELECT N'; dbo.' + tbl + N'.' + col + N' = ''' + val + N''' (' + CONVERT(nvarchar(12), n) + N' rows)'
+		FROM @bad
+		ORDER BY tbl, col, val
+		FOR XML PATH(''), TYPE
+	).value('.', 'nvarchar(max)'), 1, 2, N''), 1900);
+	THROW 50000, @msg, 1;
+END
+
+DECLARE todo CURSOR LOCAL FAST_FORWARD FOR SELECT tbl, col FROM @todo;
+OPEN todo;
+FETCH NEXT FROM todo INTO @tbl, @col;
+WHILE @@FETCH_STATUS = 0
+BEGIN
+	SET @sql = N'UPDATE dbo.' + QUOTENAME(@tbl) + N'
+		SET ' + QUOTENAME(@col) + N' = NULL
+		WHERE LTRIM(RTRIM(' + QUOTENAME(@col) + N')) IN (N'''', N''00000000'');
+		ALTER TABLE dbo.' + QUOTENAME(@tbl) + N' ALTER COLUMN ' + QUOTENAME(@col) + N' DATE NULL;';
+	EXEC sp_executesql @sql;
+
+	FETCH NEXT FROM todo INTO @tbl, @col;
+END
+CLOSE todo;
+DEALLOCATE todo;
+GO
+
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'dbo.forder_hd_status') AND name = N'ux_forder_hd_status')
+CREATE UNIQUE INDEX [ux_forder_hd_status] ON dbo.forder_hd_status ([TGLORDER], [ORDERNO], [SLSNO], [CUSTNO], [KODECABANG], [ORDERNO_TOPUP], [PCODE]);
+GO
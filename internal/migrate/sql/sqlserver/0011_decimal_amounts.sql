@@ -0,0 +1,120 @@
+-- Amounts, prices and quantities are stored as DECIMAL(19,4) instead of
+-- FLOAT, INT or text. Nothing is rounded and only blank text becomes NULL:
+-- a value that is not a number, is too large or has more than 4 decimals
+-- stops the migration before a column is changed, with the values and how
+-- many rows hold them. Correct or clear those rows and run it again.
+
+DECLARE @cols TABLE (tbl sysname, col sysname);
+INSERT INTO @cols (tbl, col) VALUES
+	(N'm_price_dummy', N'PRICE_VALUE'),
+	(N'mkplprice_dummy', N'PRICE_VALUE'),
+	(N'fcustmst', N'CLIMIT'),
+	(N'fcustmst_history', N'CLIMIT'),
+	(N'sap_web_inv_sfa', N'QTY'),
+	(N'sap_web_inv_sfa', N'PRICE'),
+	(N'sap_web_inv_sfa', N'DISKON'),
+	(N'sap_web_inv_sfa', N'INVAMOUNT'),
+	(N'fstockbarang', N'STOCK'),
+	(N'fpiutang_temp', N'INVAMOUNT'),
+	(N'fpiutang_temp', N'AMOUNTPAID'),
+	(N'fcredit_limit', N'CREDIT_LIMIT'),
+	(N'fcredit_limit', N'SISA_CREDIT_LIMIT'),
+	(N'fmst_custinv_d', N'INV_AMOUNT'),
+	(N'fmst_custinv_d', N'INV_OUTSTANDING'),
+	(N'DP_ZDDET', N'AMOUNT'),
+	(N'DP_ZDDET', N'PER'),
+	(N'DP_ZSCMIX', N'SCALEQTY'),
+	(N'DP_ZSCMIX', N'AMOUNT'),
+	(N'DP_ZSCMIX', N'PER'),
+	(N'DP_ZSCMIX', N'SCALEQTYTO'),
+	(N'DP_ZSCMIX', N'AMOUNTSCL'),
+	(N'DP_ZSCMIX', N'AMOUNTSCLTO'),
+	(N'FG_ZDHDR', N'QTY'),
+	(N'FG_ZDHDR', N'UOM'),
+	(N'FG_ZFRDET', N'MINIMUMQTY'),
+	(N'FG_ZFRDET', N'FREEGOODSQTY'),
+	(N'FG_ZFRDET', N'FREEGOODSAGRREDQTY'),
+	(N'FG_ZFRMIX', N'SCALEQTY'),
+	(N'FG_ZFRMIX', N'QTY'),
+	(N'FG_ZFRMIX', N'AMOUNTSCLF');
+
+-- Columns still to convert, including the shadow and previous generations
+-- of the SDEAL tables that full-refresh runs copied from dbo.
+DECLARE @todo TABLE (sch sysname, tbl sysname, col sysname, typ sysname);
+INSERT INTO @todo (sch, tbl, col, typ)
+SELECT ic.TABLE_SCHEMA, c.tbl, c.col, ic.DATA_TYPE
+FROM @cols c
+JOIN INFORMATION_SCHEMA.COLUMNS ic
+	ON ic.TABLE_SCHEMA IN (N'dbo', N'shadow', N'previous') AND ic.TABLE_NAME = c.tbl AND ic.COLUMN_NAME = c.col
+WHERE ic.DATA_TYPE <> N'decimal';
+
+-- A value converts when DECIMAL(19,4) holds it exactly: text and integers
+-- must read the same with 20 decimals, a FLOAT must come back as the same
+-- FLOAT, so 0.1 stays 0.1.
+DECLARE @bad TABLE (tbl nvarchar(400), val nvarchar(100), n int);
+DECLARE @sch sysname, @tbl sysname, @col sysname, @typ sysname;
+DECLARE @val nvarchar(200), @lost nvarchar(max), @sql nvarchar(max);
+
+DECLARE todo CURSOR LOCAL FAST_FORWARD FOR SELECT sch, tbl, col, typ FROM @todo;
+OPEN todo;
+FETCH NEXT FROM todo INTO @sch, @tbl, @col, @typ;
+WHILE @@FETCH_STATUS = 0
+BEGIN
+	IF @typ IN (N'float', N'real')
+	BEGIN
+		SET @val = N'CONVERT(nvarchar(4000), v, 3)';
+		SET @lost = N'CONVERT(float, TRY_CONVERT(DECIMAL(19,4), v)) <> v';
+	END
+	ELSE
+	BEGIN
+		SET @val = N'CONVERT(nvarchar(4000), v)';
+		SET @lost = N'TRY_CONVERT(DECIMAL(38,20), v) IS NULL OR TRY_CONVERT(DECIMAL(38,20), v) <> TRY_CONVERT(DECIMAL(19,4), v)';
+	END
+
+	SET @sql = N'SELECT @name, LEFT(' + @val + N', 100), COUNT(*)
+		FROM (SELECT ' + QUOTENAME(@col) + N' AS v FROM ' + QUOTENAME(@sch) + N'.' + QUOTENAME(@tbl) + N') t
+		WHERE v IS NOT NULL
+			AND LTRIM(RTRIM(CONVERT(nvarchar(4000), v))) <> N''''
+			AND (TRY_CONVERT(DECIMAL(19,4), v) IS NULL OR ' + @lost + N')
+		GROUP BY v';
+	INSERT INTO @bad (tbl, val, n)
+	EXEC sp_executesql @sql, N'@name nvarchar(400)', @name = @sch + N'.' + @tbl + N'.' + @col;
+
+	FETCH NEXT FROM todo INTO @sch, @tbl, @col, @typ;
+END
+CLOSE todo;
+DEALLOCATE todo;
+
+IF EXISTS (SELECT 1 FROM @bad)
+BEGIN
+	DECLARE @msg nvarchar(2048) = N'values that do not fit DECIMAL(19,4) exactly, correct or clear them first: ' + LEFT(STUFF((
+		SELECT N'; ' + tbl + N' = ''' + val + N''' (' + CONVERT(nvarchar(12), n) + N' rows)'
+		FROM @bad
+		ORDER BY tbl, val
+		FOR XML PATH(''), TYPE
+	).value('.', 'nvarchar(max)'), 1, 2, N''), 1900);
+	THROW 50000, @msg, 1;
+END
+
+DECLARE todo CURSOR LOCAL FAST_FORWARD FOR SELECT sch, tbl, col FROM @todo;
+OPEN todo;
+FETCH NEXT FROM todo INTO @sch, @tbl, @col;
+WHILE @@FETCH_STATUS = 0
+BEGIN
+	SET @sql = N'UPDATE ' + QUOTENAME(@sch) + N'.' + QUOTENAME(@tbl) + N'
+		SET ' + QUOTENAME(@col) + N' = NULL
+		WHERE LTRIM(RTRIM(CONVERT(nvarchar(4000), ' + QUOTENAME(@col) + N'))) = N'''';
+		ALTER TABLE ' + QUOTENAME(@sch) + N'.' + QUOTENAME(@tbl) + N' ALTER COLUMN ' + QUOTENAME(@col) + N' DECIMAL(19,4) NULL;';
+	EXEC sp_executesql @sql;
+
+	FETCH NEXT FROM todo INTO @sch, @tbl, @col;
+END
+CLOSE todo;
+DEALLOCATE todo;
+GO
+
+-- PCODE of the MKPLPRICE rows was declared FLOAT and written out of order.
+IF EXISTS (SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+	WHERE TABLE_SCHEMA = N'dbo' AND TABLE_NAME = N'mkplprice_dummy' AND COLUMN_NAME = N'PCODE' AND DATA_TYPE = N'float')
+ALTER TABLE dbo.mkplprice_dummy ALTER COLUMN [PCODE] NVARCHAR(255) NULL;
+GO
@@ -0,0 +1,536 @@
+-- Shadow and previous generations of the SDEAL tables (see
+-- orchestrator/swap.go), declared like their dbo tables after 0011 so a swap
+-- never changes a column type, key or index. Keys are unique indexes rather
+-- than named constraints: constraint names are schema objects and would
+-- collide while the generations rotate. Shadow tables that an older run
+-- copied with SELECT INTO keep their rows and get the missing indexes.
+
+IF SCHEMA_ID(N'shadow') IS NULL EXEC(N'CREATE SCHEMA shadow');
+GO
+
+IF SCHEMA_ID(N'previous') IS NULL EXEC(N'CREATE SCHEMA previous');
+GO
+
+IF OBJECT_ID(N'shadow.DP_FG_CHECK', N'U') IS NULL
+CREATE TABLE shadow.DP_FG_CHECK (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[PROMOID] NVARCHAR(255) NULL,
+	[DDATE] DATE NULL,
+	[CDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'shadow.DP_FG_CHECK') AND name = N'ux_DP_FG_CHECK')
+CREATE UNIQUE INDEX [ux_DP_FG_CHECK] ON shadow.DP_FG_CHECK ([BLOCKID], [PROMOID], [DDATE]);
+GO
+
+IF OBJECT_ID(N'shadow.DP_ZDHDR', N'U') IS NULL
+CREATE TABLE shadow.DP_ZDHDR (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[CONDITIONTYPE] NVARCHAR(255) NULL,
+	[KEYCOMBINATION] NVARCHAR(255) NULL,
+	[KEYCOMB] NVARCHAR(255) NULL,
+	[SALESORGANIZATION] NVARCHAR(255) NULL,
+	[DISTRIBUTIONCHANNEL] NVARCHAR(255) NULL,
+	[SALESOFFICE] NVARCHAR(255) NULL,
+	[DIVISION] NVARCHAR(255) NULL,
+	[PAYMENTTERM] NVARCHAR(255) NULL,
+	[CUSTOMER] NVARCHAR(255) NULL,
+	[MATERIAL] NVARCHAR(255) NULL,
+	[ATTRIBUT2] NVARCHAR(255) NULL,
+	[VALIDUNTIL] DATETIME NULL,
+	[VALIDFROM] DATETIME NULL,
+	[CONDITIONRECORDNO] NVARCHAR(255) NULL,
+	[SCALE] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'shadow.DP_ZDITM', N'U') IS NULL
+CREATE TABLE shadow.DP_ZDITM (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[CONDITIONTYPE] NVARCHAR(255) NULL,
+	[KEYCOMBINATION] NVARCHAR(255) NULL,
+	[KEYCOMB] NVARCHAR(255) NULL,
+	[SALESORGANIZATION] NVARCHAR(255) NULL,
+	[DISTRIBUTIONCHANNEL] NVARCHAR(255) NULL,
+	[SALESOFFICE] NVARCHAR(255) NULL,
+	[DIVISION] NVARCHAR(255) NULL,
+	[SOLDTOPARTY] NVARCHAR(255) NULL,
+	[PRICINGREFMATL] NVARCHAR(255) NULL,
+	[PAYMENTTERMS] NVARCHAR(255) NULL,
+	[INDUSTRYCODE3] NVARCHAR(255) NULL,
+	[INDUSTRYCODE4] NVARCHAR(255) NULL,
+	[INDUSTRYCODE5] NVARCHAR(255) NULL,
+	[ATTRIBUTE1] NVARCHAR(255) NULL,
+	[ATTRIBUTE2] NVARCHAR(255) NULL,
+	[MATERIAL] NVARCHAR(255) NULL,
+	[SALESUNIT] NVARCHAR(255) NULL,
+	[VALIDFROM] DATETIME NULL,
+	[VALIDUNTIL] DATETIME NULL,
+	[CONDITIONRECORDNO] NVARCHAR(255) NULL,
+	[SCALE] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'shadow.DP_ZDDET', N'U') IS NULL
+CREATE TABLE shadow.DP_ZDDET (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[CONDITIONRECORDNO] NVARCHAR(255) NULL,
+	[AMOUNT] DECIMAL(19,4) NULL,
+	[UNIT] NVARCHAR(255) NULL,
+	[PER] DECIMAL(19,4) NULL,
+	[UOM] NVARCHAR(255) NULL,
+	[SCALE] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'shadow.DP_ZPMIX', N'U') IS NULL
+CREATE TABLE shadow.DP_ZPMIX (
+	[PROCESS_ID] NVARCHAR(50) NULL,
+	[BLOCKID] NVARCHAR(3) NULL,
+	[BLOCKNAME] NVARCHAR(50) NULL,
+	[CTYP] NVARCHAR(20) NULL,
+	[KEYCOMBINATION] NVARCHAR(20) NULL,
+	[SORG] NVARCHAR(20) NULL,
+	[DCHL] NVARCHAR(20) NULL,
+	[SOFF] NVARCHAR(20) NULL,
+	[DV] NVARCHAR(20) NULL,
+	[CUSTOMER] NVARCHAR(20) NULL,
+	[INDCODE2] NVARCHAR(20) NULL,
+	[INDCODE3] NVARCHAR(20) NULL,
+	[INDCODE4] NVARCHAR(20) NULL,
+	[INDCODE5] NVARCHAR(20) NULL,
+	[PL] NVARCHAR(20) NULL,
+	[PAYT] NVARCHAR(20) NULL,
+	[MATERIAL] NVARCHAR(20) NULL,
+	[VALIDFROM] DATE NULL,
+	[VALIDUNTIL] DATE NULL,
+	[PROMOID] NVARCHAR(20) NULL,
+	[LINEITEM] INT NULL,
+	[FILENAME] NVARCHAR(200) NULL,
+	[LINENUMBER] BIGINT NULL,
+	[CDATE] DATETIME NULL,
+	[MUSTBUY] NVARCHAR(5) NULL,
+	[EXCLUDE] NVARCHAR(5) NULL,
+	[SPLIT] NVARCHAR(5) NULL,
+	[AMOUNTX] NVARCHAR(1) NULL,
+	[RANGEX] NVARCHAR(5) NULL,
+	[WITHMATERIAL] NVARCHAR(5) NULL,
+	[KELIPATAN] NVARCHAR(5) NULL,
+	[V_KELIPATAN] INT NULL,
+	[ATTR_PRD_LV2] NVARCHAR(20) NULL,
+	[ATTR_PRD_LV3] NVARCHAR(20) NULL,
+	[FL_CUST_EXC] NVARCHAR(20) NULL,
+	[CUST_EXC] NVARCHAR(20) NULL,
+	[FL_HD] NVARCHAR(100) NULL,
+	[PERBANDINGAN] NVARCHAR(20) NULL,
+	[V_PERBANDINGAN1] INT NULL,
+	[V_PERBANDINGAN2] INT NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'shadow.DP_ZPMIX') AND name = N'ux_DP_ZPMIX')
+CREATE UNIQUE INDEX [ux_DP_ZPMIX] ON shadow.DP_ZPMIX ([BLOCKID], [PROMOID], [LINEITEM], [CTYP], [KEYCOMBINATION], [SORG], [DCHL], [SOFF], [DV], [CUSTOMER], [PL], [PAYT], [MATERIAL], [INDCODE2], [INDCODE3], [INDCODE4], [INDCODE5], [CUST_EXC]);
+GO
+
+IF OBJECT_ID(N'shadow.DP_ZSCREG', N'U') IS NULL
+CREATE TABLE shadow.DP_ZSCREG (
+	[PROCESS_ID] NVARCHAR(50) NULL,
+	[BLOCKID] NVARCHAR(3) NULL,
+	[BLOCKNAME] NVARCHAR(50) NULL,
+	[CONDITIONRECORDNO] NVARCHAR(20) NULL,
+	[NO] INT NULL,
+	[LSNO] INT NULL,
+	[DISCREGHDRQTY] DECIMAL(19,4) NULL,
+	[AMOUNT] DECIMAL(19,4) NULL,
+	[UNIT] NVARCHAR(25) NULL,
+	[FILENAME] NVARCHAR(200) NULL,
+	[LINENUMBER] BIGINT NULL,
+	[CDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'shadow.DP_ZSCREG') AND name = N'ux_DP_ZSCREG')
+CREATE UNIQUE INDEX [ux_DP_ZSCREG] ON shadow.DP_ZSCREG ([BLOCKID], [CONDITIONRECORDNO], [DISCREGHDRQTY]);
+GO
+
+IF OBJECT_ID(N'shadow.DP_ZSCMIX', N'U') IS NULL
+CREATE TABLE shadow.DP_ZSCMIX (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[PROMOID] NVARCHAR(255) NULL,
+	[LINEITEM] INT NULL,
+	[SCALEQTY] DECIMAL(19,4) NULL,
+	[BUN] NVARCHAR(255) NULL,
+	[AMOUNT] DECIMAL(19,4) NULL,
+	[UNIT] NVARCHAR(255) NULL,
+	[PER] DECIMAL(19,4) NULL,
+	[UOM] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL,
+	[SCALEQTYTO] DECIMAL(19,4) NULL,
+	[AMOUNTSCL] DECIMAL(19,4) NULL,
+	[AMOUNTSCLTO] DECIMAL(19,4) NULL,
+	[UNITSCL] NVARCHAR(255) NULL,
+	[MATNRKENA] NVARCHAR(255) NULL
+);
+GO
+
+IF OBJECT_ID(N'shadow.FG_ZDHDR', N'U') IS NULL
+CREATE TABLE shadow.FG_ZDHDR (
+	[PROCESS_ID] NVARCHAR(50) NULL,
+	[BLOCKID] NVARCHAR(3) NULL,
+	[BLOCKNAME] NVARCHAR(50) NULL,
+	[CONDITIONTYPE] NVARCHAR(20) NULL,
+	[KEYCOMBINATION] NVARCHAR(20) NULL,
+	[KEYCOMB] NVARCHAR(180) NULL,
+	[SALESORGANIZATION] NVARCHAR(20) NULL,
+	[DISTRIBUTIONCHANNEL] NVARCHAR(20) NULL,
+	[DIVISION] NVARCHAR(20) NULL,
+	[SALESOFFICE] NVARCHAR(20) NULL,
+	[PRICELISTTYPE] NVARCHAR(20) NULL,
+	[ATTRIBUTE1] NVARCHAR(20) NULL,
+	[INDUSTRYCODE3] NVARCHAR(20) NULL,
+	[INDUSTRYCODE4] NVARCHAR(20) NULL,
+	[INDUSTRYCODE5] NVARCHAR(20) NULL,
+	[SOLDTOPARTY] NVARCHAR(20) NULL,
+	[MATERIAL] NVARCHAR(20) NULL,
+	[VALIDUNTIL] DATE NULL,
+	[VALIDFROM] DATE NULL,
+	[CONDITIONRECORDNO] NVARCHAR(20) NULL,
+	[PROMOID] NVARCHAR(20) NULL,
+	[PROMOITEM] NVARCHAR(20) NULL,
+	[SCALE] NVARCHAR(3) NULL,
+	[FILENAME] NVARCHAR(100) NULL,
+	[LINENUMBER] BIGINT NULL,
+	[CDATE] DATETIME NULL,
+	[MUSTBUY] NVARCHAR(5) NULL,
+	[KELIPATAN] NVARCHAR(5) NULL,
+	[F_KELIPATAN] INT NULL,
+	[WITHQTY] NVARCHAR(20) NULL,
+	[QTY] DECIMAL(19,4) NULL,
+	[UOM] DECIMAL(19,4) NULL,
+	[ZTERM] NVARCHAR(5) NULL,
+	[KATR2] NVARCHAR(20) NULL,
+	[KATR3] NVARCHAR(20) NULL,
+	[PERBANDINGAN] NVARCHAR(20) NULL,
+	[F_PERBANDINGAN1] INT NULL,
+	[F_PERBANDINGAN2] INT NULL,
+	[AMOUNTX] NVARCHAR(1) NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'shadow.FG_ZDHDR') AND name = N'ux_FG_ZDHDR')
+CREATE UNIQUE INDEX [ux_FG_ZDHDR] ON shadow.FG_ZDHDR ([BLOCKID], [PROMOID], [PROMOITEM], [CONDITIONRECORDNO], [CONDITIONTYPE], [KEYCOMBINATION], [SALESORGANIZATION], [DISTRIBUTIONCHANNEL], [DIVISION], [SALESOFFICE], [PRICELISTTYPE], [ATTRIBUTE1], [INDUSTRYCODE3], [INDUSTRYCODE4], [INDUSTRYCODE5], [SOLDTOPARTY], [MATERIAL], [ZTERM], [KATR2], [KATR3]);
+GO
+
+IF OBJECT_ID(N'shadow.FG_ZFRDET', N'U') IS NULL
+CREATE TABLE shadow.FG_ZFRDET (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[CONDITIONRECORDNO] NVARCHAR(255) NULL,
+	[MINIMUMQTY] DECIMAL(19,4) NULL,
+	[FREEGOODSQTY] DECIMAL(19,4) NULL,
+	[UOMFREEGOODS] NVARCHAR(255) NULL,
+	[FREEGOODSAGRREDQTY] DECIMAL(19,4) NULL,
+	[UOMFREEGOODSAGRRED] NVARCHAR(255) NULL,
+	[ADDITIONALMATERIAL] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'shadow.FG_ZFRMIX', N'U') IS NULL
+CREATE TABLE shadow.FG_ZFRMIX (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[PROMOID] NVARCHAR(255) NULL,
+	[PROMOITEM] NVARCHAR(255) NULL,
+	[SCALEQTY] DECIMAL(19,4) NULL,
+	[SCALEQTYUOM] NVARCHAR(255) NULL,
+	[MATERIAL] NVARCHAR(255) NULL,
+	[QTY] DECIMAL(19,4) NULL,
+	[QTYUOM] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL,
+	[AMOUNTSCLF] DECIMAL(19,4) NULL,
+	[CURRENCY] NVARCHAR(255) NULL
+);
+GO
+
+IF OBJECT_ID(N'previous.DP_FG_CHECK', N'U') IS NULL
+CREATE TABLE previous.DP_FG_CHECK (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[PROMOID] NVARCHAR(255) NULL,
+	[DDATE] DATE NULL,
+	[CDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'previous.DP_FG_CHECK') AND name = N'ux_DP_FG_CHECK')
+CREATE UNIQUE INDEX [ux_DP_FG_CHECK] ON previous.DP_FG_CHECK ([BLOCKID], [PROMOID], [DDATE]);
+GO
+
+IF OBJECT_ID(N'previous.DP_ZDHDR', N'U') IS NULL
+CREATE TABLE previous.DP_ZDHDR (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[CONDITIONTYPE] NVARCHAR(255) NULL,
+	[KEYCOMBINATION] NVARCHAR(255) NULL,
+	[KEYCOMB] NVARCHAR(255) NULL,
+	[SALESORGANIZATION] NVARCHAR(255) NULL,
+	[DISTRIBUTIONCHANNEL] NVARCHAR(255) NULL,
+	[SALESOFFICE] NVARCHAR(255) NULL,
+	[DIVISION] NVARCHAR(255) NULL,
+	[PAYMENTTERM] NVARCHAR(255) NULL,
+	[CUSTOMER] NVARCHAR(255) NULL,
+	[MATERIAL] NVARCHAR(255) NULL,
+	[ATTRIBUT2] NVARCHAR(255) NULL,
+	[VALIDUNTIL] DATETIME NULL,
+	[VALIDFROM] DATETIME NULL,
+	[CONDITIONRECORDNO] NVARCHAR(255) NULL,
+	[SCALE] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'previous.DP_ZDITM', N'U') IS NULL
+CREATE TABLE previous.DP_ZDITM (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[CONDITIONTYPE] NVARCHAR(255) NULL,
+	[KEYCOMBINATION] NVARCHAR(255) NULL,
+	[KEYCOMB] NVARCHAR(255) NULL,
+	[SALESORGANIZATION] NVARCHAR(255) NULL,
+	[DISTRIBUTIONCHANNEL] NVARCHAR(255) NULL,
+	[SALESOFFICE] NVARCHAR(255) NULL,
+	[DIVISION] NVARCHAR(255) NULL,
+	[SOLDTOPARTY] NVARCHAR(255) NULL,
+	[PRICINGREFMATL] NVARCHAR(255) NULL,
+	[PAYMENTTERMS] NVARCHAR(255) NULL,
+	[INDUSTRYCODE3] NVARCHAR(255) NULL,
+	[INDUSTRYCODE4] NVARCHAR(255) NULL,
+	[INDUSTRYCODE5] NVARCHAR(255) NULL,
+	[ATTRIBUTE1] NVARCHAR(255) NULL,
+	[ATTRIBUTE2] NVARCHAR(255) NULL,
+	[MATERIAL] NVARCHAR(255) NULL,
+	[SALESUNIT] NVARCHAR(255) NULL,
+	[VALIDFROM] DATETIME NULL,
+	[VALIDUNTIL] DATETIME NULL,
+	[CONDITIONRECORDNO] NVARCHAR(255) NULL,
+	[SCALE] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'previous.DP_ZDDET', N'U') IS NULL
+CREATE TABLE previous.DP_ZDDET (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[CONDITIONRECORDNO] NVARCHAR(255) NULL,
+	[AMOUNT] DECIMAL(19,4) NULL,
+	[UNIT] NVARCHAR(255) NULL,
+	[PER] DECIMAL(19,4) NULL,
+	[UOM] NVARCHAR(255) NULL,
+	[SCALE] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'previous.DP_ZPMIX', N'U') IS NULL
+CREATE TABLE previous.DP_ZPMIX (
+	[PROCESS_ID] NVARCHAR(50) NULL,
+	[BLOCKID] NVARCHAR(3) NULL,
+	[BLOCKNAME] NVARCHAR(50) NULL,
+	[CTYP] NVARCHAR(20) NULL,
+	[KEYCOMBINATION] NVARCHAR(20) NULL,
+	[SORG] NVARCHAR(20) NULL,
+	[DCHL] NVARCHAR(20) NULL,
+	[SOFF] NVARCHAR(20) NULL,
+	[DV] NVARCHAR(20) NULL,
+	[CUSTOMER] NVARCHAR(20) NULL,
+	[INDCODE2] NVARCHAR(20) NULL,
+	[INDCODE3] NVARCHAR(20) NULL,
+	[INDCODE4] NVARCHAR(20) NULL,
+	[INDCODE5] NVARCHAR(20) NULL,
+	[PL] NVARCHAR(20) NULL,
+	[PAYT] NVARCHAR(20) NULL,
+	[MATERIAL] NVARCHAR(20) NULL,
+	[VALIDFROM] DATE NULL,
+	[VALIDUNTIL] DATE NULL,
+	[PROMOID] NVARCHAR(20) NULL,
+	[LINEITEM] INT NULL,
+	[FILENAME] NVARCHAR(200) NULL,
+	[LINENUMBER] BIGINT NULL,
+	[CDATE] DATETIME NULL,
+	[MUSTBUY] NVARCHAR(5) NULL,
+	[EXCLUDE] NVARCHAR(5) NULL,
+	[SPLIT] NVARCHAR(5) NULL,
+	[AMOUNTX] NVARCHAR(1) NULL,
+	[RANGEX] NVARCHAR(5) NULL,
+	[WITHMATERIAL] NVARCHAR(5) NULL,
+	[KELIPATAN] NVARCHAR(5) NULL,
+	[V_KELIPATAN] INT NULL,
+	[ATTR_PRD_LV2] NVARCHAR(20) NULL,
+	[ATTR_PRD_LV3] NVARCHAR(20) NULL,
+	[FL_CUST_EXC] NVARCHAR(20) NULL,
+	[CUST_EXC] NVARCHAR(20) NULL,
+	[FL_HD] NVARCHAR(100) NULL,
+	[PERBANDINGAN] NVARCHAR(20) NULL,
+	[V_PERBANDINGAN1] INT NULL,
+	[V_PERBANDINGAN2] INT NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'previous.DP_ZPMIX') AND name = N'ux_DP_ZPMIX')
+CREATE UNIQUE INDEX [ux_DP_ZPMIX] ON previous.DP_ZPMIX ([BLOCKID], [PROMOID], [LINEITEM], [CTYP], [KEYCOMBINATION], [SORG], [DCHL], [SOFF], [DV], [CUSTOMER], [PL], [PAYT], [MATERIAL], [INDCODE2], [INDCODE3], [INDCODE4], [INDCODE5], [CUST_EXC]);
+GO
+
+IF OBJECT_ID(N'previous.DP_ZSCREG', N'U') IS NULL
+CREATE TABLE previous.DP_ZSCREG (
+	[PROCESS_ID] NVARCHAR(50) NULL,
+	[BLOCKID] NVARCHAR(3) NULL,
+	[BLOCKNAME] NVARCHAR(50) NULL,
+	[CONDITIONRECORDNO] NVARCHAR(20) NULL,
+	[NO] INT NULL,
+	[LSNO] INT NULL,
+	[DISCREGHDRQTY] DECIMAL(19,4) NULL,
+	[AMOUNT] DECIMAL(19,4) NULL,
+	[UNIT] NVARCHAR(25) NULL,
+	[FILENAME] NVARCHAR(200) NULL,
+	[LINENUMBER] BIGINT NULL,
+	[CDATE] DATETIME NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'previous.DP_ZSCREG') AND name = N'ux_DP_ZSCREG')
+CREATE UNIQUE INDEX [ux_DP_ZSCREG] ON previous.DP_ZSCREG ([BLOCKID], [CONDITIONRECORDNO], [DISCREGHDRQTY]);
+GO
+
+IF OBJECT_ID(N'previous.DP_ZSCMIX', N'U') IS NULL
+CREATE TABLE previous.DP_ZSCMIX (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[PROMOID] NVARCHAR(255) NULL,
+	[LINEITEM] INT NULL,
+	[SCALEQTY] DECIMAL(19,4) NULL,
+	[BUN] NVARCHAR(255) NULL,
+	[AMOUNT] DECIMAL(19,4) NULL,
+	[UNIT] NVARCHAR(255) NULL,
+	[PER] DECIMAL(19,4) NULL,
+	[UOM] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL,
+	[SCALEQTYTO] DECIMAL(19,4) NULL,
+	[AMOUNTSCL] DECIMAL(19,4) NULL,
+	[AMOUNTSCLTO] DECIMAL(19,4) NULL,
+	[UNITSCL] NVARCHAR(255) NULL,
+	[MATNRKENA] NVARCHAR(255) NULL
+);
+GO
+
+IF OBJECT_ID(N'previous.FG_ZDHDR', N'U') IS NULL
+CREATE TABLE previous.FG_ZDHDR (
+	[PROCESS_ID] NVARCHAR(50) NULL,
+	[BLOCKID] NVARCHAR(3) NULL,
+	[BLOCKNAME] NVARCHAR(50) NULL,
+	[CONDITIONTYPE] NVARCHAR(20) NULL,
+	[KEYCOMBINATION] NVARCHAR(20) NULL,
+	[KEYCOMB] NVARCHAR(180) NULL,
+	[SALESORGANIZATION] NVARCHAR(20) NULL,
+	[DISTRIBUTIONCHANNEL] NVARCHAR(20) NULL,
+	[DIVISION] NVARCHAR(20) NULL,
+	[SALESOFFICE] NVARCHAR(20) NULL,
+	[PRICELISTTYPE] NVARCHAR(20) NULL,
+	[ATTRIBUTE1] NVARCHAR(20) NULL,
+	[INDUSTRYCODE3] NVARCHAR(20) NULL,
+	[INDUSTRYCODE4] NVARCHAR(20) NULL,
+	[INDUSTRYCODE5] NVARCHAR(20) NULL,
+	[SOLDTOPARTY] NVARCHAR(20) NULL,
+	[MATERIAL] NVARCHAR(20) NULL,
+	[VALIDUNTIL] DATE NULL,
+	[VALIDFROM] DATE NULL,
+	[CONDITIONRECORDNO] NVARCHAR(20) NULL,
+	[PROMOID] NVARCHAR(20) NULL,
+	[PROMOITEM] NVARCHAR(20) NULL,
+	[SCALE] NVARCHAR(3) NULL,
+	[FILENAME] NVARCHAR(100) NULL,
+	[LINENUMBER] BIGINT NULL,
+	[CDATE] DATETIME NULL,
+	[MUSTBUY] NVARCHAR(5) NULL,
+	[KELIPATAN] NVARCHAR(5) NULL,
+	[F_KELIPATAN] INT NULL,
+	[WITHQTY] NVARCHAR(20) NULL,
+	[QTY] DECIMAL(19,4) NULL,
+	[UOM] DECIMAL(19,4) NULL,
+	[ZTERM] NVARCHAR(5) NULL,
+	[KATR2] NVARCHAR(20) NULL,
+	[KATR3] NVARCHAR(20) NULL,
+	[PERBANDINGAN] NVARCHAR(20) NULL,
+	[F_PERBANDINGAN1] INT NULL,
+	[F_PERBANDINGAN2] INT NULL,
+	[AMOUNTX] NVARCHAR(1) NULL
+);
+IF NOT EXISTS (SELECT 1 FROM sys.indexes WHERE object_id = OBJECT_ID(N'previous.FG_ZDHDR') AND name = N'ux_FG_ZDHDR')
+CREATE UNIQUE INDEX [ux_FG_ZDHDR] ON previous.FG_ZDHDR ([BLOCKID], [PROMOID], [PROMOITEM], [CONDITIONRECORDNO], [CONDITIONTYPE], [KEYCOMBINATION], [SALESORGANIZATION], [DISTRIBUTIONCHANNEL], [DIVISION], [SALESOFFICE], [PRICELISTTYPE], [ATTRIBUTE1], [INDUSTRYCODE3], [INDUSTRYCODE4], [INDUSTRYCODE5], [SOLDTOPARTY], [MATERIAL], [ZTERM], [KATR2], [KATR3]);
+GO
+
+IF OBJECT_ID(N'previous.FG_ZFRDET', N'U') IS NULL
+CREATE TABLE previous.FG_ZFRDET (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[CONDITIONRECORDNO] NVARCHAR(255) NULL,
+	[MINIMUMQTY] DECIMAL(19,4) NULL,
+	[FREEGOODSQTY] DECIMAL(19,4) NULL,
+	[UOMFREEGOODS] NVARCHAR(255) NULL,
+	[FREEGOODSAGRREDQTY] DECIMAL(19,4) NULL,
+	[UOMFREEGOODSAGRRED] NVARCHAR(255) NULL,
+	[ADDITIONALMATERIAL] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL
+);
+GO
+
+IF OBJECT_ID(N'previous.FG_ZFRMIX', N'U') IS NULL
+CREATE TABLE previous.FG_ZFRMIX (
+	[PROCESS_ID] NVARCHAR(255) NULL,
+	[BLOCKID] NVARCHAR(255) NULL,
+	[BLOCKNAME] NVARCHAR(255) NULL,
+	[PROMOID] NVARCHAR(255) NULL,
+	[PROMOITEM] NVARCHAR(255) NULL,
+	[SCALEQTY] DECIMAL(19,4) NULL,
+	[SCALEQTYUOM] NVARCHAR(255) NULL,
+	[MATERIAL] NVARCHAR(255) NULL,
+	[QTY] DECIMAL(19,4) NULL,
+	[QTYUOM] NVARCHAR(255) NULL,
+	[FILENAME] NVARCHAR(255) NULL,
+	[LINENUMBER] INT NULL,
+	[CDATE] DATETIME NULL,
+	[AMOUNTSCLF] DECIMAL(19,4) NULL,
+	[CURRENCY] NVARCHAR(255) NULL
+);
+GO
@@ -0,0 +1,42 @@
+-- The validity dates of DP_Z00001 (block 126) are stored as DATE instead
+-- of the YYYYMMDD number they were read as. 0, which the handler stored
+-- for an empty or unreadable date, becomes NULL. Any other value that is
+-- not a date stops the migration before a column is changed, with the
+-- values and how many rows hold them; correct or clear those rows and run
+-- it again.
+
+IF EXISTS (SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+	WHERE TABLE_SCHEMA = N'dbo' AND TABLE_NAME = N'DP_Z00001' AND COLUMN_NAME IN (N'VALIDFROM', N'VALIDTO') AND DATA_TYPE = N'int')
+BEGIN
+	DECLARE @bad nvarchar(max) = STUFF((
+		SELECT N'; dbo.DP_Z00001.' + col + N' = ''' + CONVERT(nvarchar(12), val) + N''' (' + CONVERT(nvarchar(12), COUNT(*)) + N' rows)'
+		FROM dbo.DP_Z00001
+		CROSS APPLY (VALUES (N'VALIDFROM', [VALIDFROM]), (N'VALIDTO', [VALIDTO])) v (col, val)
+		WHERE val <> 0
+			AND TRY_CONVERT(date, CONVERT(varchar(12), val), 112) IS NULL
+		GROUP BY col, val
+		ORDER BY col, val
+		FOR XML PATH(''), TYPE
+	).value('.', 'nvarchar(max)'), 1, 2, N'');
+
+	IF @bad IS NOT NULL
+	BEGIN
+		DECLARE @msg nvarchar(2048) = N'values that are not a date, correct or clear them first: ' + LEFT(@bad, 1900);
+		THROW 50000, @msg, 1;
+	END
+
+	UPDATE dbo.DP_Z00001 SET [VALIDFROM] = NULLIF([VALIDFROM], 0), [VALIDTO] = NULLIF([VALIDTO], 0);
+
+	-- INT does not convert to DATE; YYYYMMDD text does.
+	ALTER TABLE dbo.DP_Z00001 ALTER COLUMN [VALIDFROM] VARCHAR(8) NULL;
+	ALTER TABLE dbo.DP_Z00001 ALTER COLUMN [VALIDTO] VARCHAR(8) NULL;
+END
+GO
+
+IF EXISTS (SELECT 1 FROM INFORMATION_SCHEMA.COLUMNS
+	WHERE TABLE_SCHEMA = N'dbo' AND TABLE_NAME = N'DP_Z00001' AND COLUMN_NAME IN (N'VALIDFROM', N'VALIDTO') AND DATA_TYPE = N'varchar')
+BEGIN
+	ALTER TABLE dbo.DP_Z00001 ALTER COLUMN [VALIDFROM] DATE NULL;
+	ALTER TABLE dbo.DP_Z00001 ALTER COLUMN [VALIDTO] DATE NULL;
+END
+GO
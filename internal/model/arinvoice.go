@@ -1,16 +1,19 @@
 package model
 
 import (
+	"database/sql"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type ArInvoice struct {
 	CustNo          string
 	InvNo           string
-	InvDate         string
-	DueDate         string
-	InvAmount       float64
-	AmountPaid      float64
+	InvDate         sql.NullTime
+	DueDate         sql.NullTime
+	InvAmount       decimal.Decimal
+	AmountPaid      decimal.Decimal
 	SlsNo           string
 	Kodecabang      string
 	InvType         string
@@ -2,12 +2,14 @@ package model
 
 import (
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type ImStkbal struct {
 	Kg              string
 	Pcode           string
-	Stock           float64
+	Stock           decimal.Decimal
 	Kodecabang      string
 	CoreFilename    string
 	CoreProcessdate time.Time
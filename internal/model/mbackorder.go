@@ -5,7 +5,7 @@ import (
 )
 
 type MBackOrder struct {
-	TglOrder        string
+	TglOrder        time.Time
 	OrderNo         string
 	SlsNo           string
 	CustNo          string
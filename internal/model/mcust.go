@@ -1,6 +1,10 @@
 package model
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type Mcust struct {
 	Custno          string
@@ -13,7 +17,7 @@ type Mcust struct {
 	Phone1          string
 	FaxNo           string
 	Cterm           string
-	Climit          int
+	Climit          decimal.Decimal
 	FlagLimit       string
 	Gdisc           string
 	GrupOut         string
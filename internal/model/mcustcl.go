@@ -2,13 +2,15 @@ package model
 
 import (
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type McustCl struct {
 	CustNo          string
 	CustName        string
-	CreditLimit     float64
-	SisaCreditLimit float64
+	CreditLimit     decimal.Decimal
+	SisaCreditLimit decimal.Decimal
 	Kodecabang      string
 	CoreFilename    string
 	CoreProcessdate time.Time
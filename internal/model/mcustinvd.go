@@ -1,7 +1,10 @@
 package model
 
 import (
+	"database/sql"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type McustInvD struct {
@@ -12,10 +15,10 @@ type McustInvD struct {
 	CustNo          string
 	CustName        string
 	InvNo           string
-	InvDate         string
-	DueDate         string
-	InvAmount       float64
-	InvOutStanding  float64
+	InvDate         sql.NullTime
+	DueDate         sql.NullTime
+	InvAmount       decimal.Decimal
+	InvOutStanding  decimal.Decimal
 	CoreFilename    string
 	CoreProcessdate time.Time
 }
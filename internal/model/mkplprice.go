@@ -2,6 +2,8 @@ package model
 
 import (
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type MkplPrice struct {
@@ -9,8 +11,13 @@ type MkplPrice struct {
 	LineNo          int
 	CustCode        string
 	Pcode           string
-	PriceValue      float64
+	PriceValue      decimal.Decimal
 	PriceUom        string
+	SellPrice1      decimal.Decimal
+	SellPrice2      decimal.Decimal
+	SellPrice3      decimal.Decimal
+	SellPrice4      decimal.Decimal
+	SellPrice5      decimal.Decimal
 	BranchID        string
 	Cby             string
 	Cdate           time.Time
@@ -2,6 +2,8 @@ package model
 
 import (
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type Mprice struct {
@@ -10,8 +12,13 @@ type Mprice struct {
 	PriceCode       string
 	BranchID        string
 	Pcode           string
-	PriceValue      string
+	PriceValue      decimal.Decimal
 	PriceUom        string
+	SelPrice1       decimal.Decimal
+	SelPrice2       decimal.Decimal
+	SelPrice3       decimal.Decimal
+	SelPrice4       decimal.Decimal
+	SelPrice5       decimal.Decimal
 	Cby             string
 	Cdate           time.Time
 	Mby             string
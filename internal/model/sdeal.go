@@ -1,6 +1,11 @@
 package model
 
-import "time"
+import (
+	"database/sql"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 type SpProsesDpZdhdr struct {
 	ProcessId           string
@@ -17,8 +22,8 @@ type SpProsesDpZdhdr struct {
 	Customer            string
 	Material            string
 	Attribut2           string
-	ValidUntil          time.Time
-	ValidFrom           time.Time
+	ValidUntil          sql.NullTime
+	ValidFrom           sql.NullTime
 	ConditionRecordno   string
 	Scale               string
 	FileName            string
@@ -47,8 +52,8 @@ type SpProsesDpZditm struct {
 	Attribute2          string
 	Material            string
 	SalesUnit           string
-	ValidFrom           time.Time
-	ValidUntil          time.Time
+	ValidFrom           sql.NullTime
+	ValidUntil          sql.NullTime
 	ConditionRecordNo   string
 	Scale               string
 	FileName            string
@@ -61,9 +66,9 @@ type SpProsesDpZddet struct {
 	BlockId           string
 	BlockName         string
 	ConditionRecordNo string
-	Amount            float64
+	Amount            decimal.Decimal
 	Unit              string
-	Per               float64
+	Per               decimal.Decimal
 	Uom               string
 	Scale             string
 	Filename          string
@@ -89,8 +94,8 @@ type SpProsesDpZpmix struct {
 	Pl             string
 	Payt           string
 	Material       string
-	ValidFrom      time.Time
-	ValidUntil     time.Time
+	ValidFrom      sql.NullTime
+	ValidUntil     sql.NullTime
 	PromoId        string
 	LineItem       int
 	FileName       string
@@ -121,8 +126,8 @@ type SpProsesDpZscreg struct {
 	ConditionRecordNo string
 	No                int
 	Lsno              int
-	DiscRegHdrQty     float64
-	Amount            float64
+	DiscRegHdrQty     decimal.Decimal
+	Amount            decimal.Decimal
 	Unit              string
 	FileName          string
 	LineNumber        int
@@ -135,18 +140,18 @@ type SpProsesDpZscmix struct {
 	BlockName   string
 	PromoId     string
 	LineItem    int
-	ScaleQty    float64
+	ScaleQty    decimal.Decimal
 	Bun         string
-	Amount      float64
+	Amount      decimal.Decimal
 	Unit        string
-	Per         float64
+	Per         decimal.Decimal
 	Uom         string
 	FileName    string
 	LineNumber  int
 	Cdate       time.Time
-	ScaleQtyTo  float64
-	AmountScl   float64
-	AmountSclTo float64
+	ScaleQtyTo  decimal.Decimal
+	AmountScl   decimal.Decimal
+	AmountSclTo decimal.Decimal
 	UnitScl     string
 	MatnrKena   string
 }
@@ -159,8 +164,8 @@ type SpProsesDpZ00001 struct {
 	Counter       string
 	ConditionType string
 	Description   string
-	ValidFrom     int
-	ValidTo       int
+	ValidFrom     sql.NullTime
+	ValidTo       sql.NullTime
 	CondGrp       string
 	Drule         string
 	FileName      string
@@ -187,8 +192,8 @@ type SpProsesFgZdhdr struct {
 	IndustryCode5       string
 	SoldToParty         string
 	Material            string
-	ValidUntil          time.Time
-	ValidFrom           time.Time
+	ValidUntil          sql.NullTime
+	ValidFrom           sql.NullTime
 	ConditionRecordNo   string
 	PromoId             string
 	PromoItem           string
@@ -200,8 +205,8 @@ type SpProsesFgZdhdr struct {
 	Kelipatan           string
 	FKelipatan          int
 	WithQty             string
-	Qty                 int
-	Uom                 float64
+	Qty                 decimal.Decimal
+	Uom                 decimal.Decimal
 	Zterm               string
 	Katr2               string
 	Katr3               string
@@ -216,10 +221,10 @@ type SpProsesFgZfrdet struct {
 	BlockId            string
 	BlockName          string
 	ConditionRecordNo  string
-	MinimumQty         float64
-	FreeGoodsQty       float64
+	MinimumQty         decimal.Decimal
+	FreeGoodsQty       decimal.Decimal
 	UomFreeGoods       string
-	FreeGoodsAgrredQty float64
+	FreeGoodsAgrredQty decimal.Decimal
 	UomFreeGoodsAgrred string
 	AdditionalMaterial string
 	FileName           string
@@ -233,14 +238,14 @@ type SpProsesFgZfrmix struct {
 	BlockName   string
 	PromoId     string
 	PromoItem   string
-	ScaleQty    float64
+	ScaleQty    decimal.Decimal
 	ScaleQtyUom string
 	Material    string
-	Qty         float64
+	Qty         decimal.Decimal
 	QtyUom      string
 	FileName    string
 	LineNumber  int
 	CDate       time.Time
-	AmountSclf  float64
+	AmountSclf  decimal.Decimal
 	Currency    string
 }
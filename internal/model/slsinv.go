@@ -1,26 +1,29 @@
 package model
 
 import (
+	"database/sql"
 	"time"
+
+	"github.com/shopspring/decimal"
 )
 
 type SlsInv struct {
 	SlsNo           string
 	CustNo          string
 	SfaOrderNo      string
-	SfaOrderDate    string
+	SfaOrderDate    sql.NullTime
 	OrderNo         string
-	OrderDate       string
+	OrderDate       sql.NullTime
 	InvoiceNo       string
-	InvoiceDate     string
+	InvoiceDate     sql.NullTime
 	Pcode           string
-	Qty             int
-	Price           int
-	Diskon          int
+	Qty             decimal.Decimal
+	Price           decimal.Decimal
+	Diskon          decimal.Decimal
 	Kodecabang      string
 	InvType         string
 	RefCn           string
-	Invamount       int
+	Invamount       decimal.Decimal
 	CoreFilename    string
 	CoreProcessdate time.Time
 }
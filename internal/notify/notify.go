@@ -0,0 +1,259 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"go-import-file/internal/config"
+	"go-import-file/internal/report"
+)
+
+type Severity int
+
+const (
+	SeverityInfo Severity = iota
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "WARNING"
+	case SeverityError:
+		return "ERROR"
+	default:
+		return "INFO"
+	}
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func ParseSeverity(v string) (Severity, error) {
+	switch strings.ToUpper(strings.TrimSpace(v)) {
+	case "", "ERROR":
+		return SeverityError, nil
+	case "WARNING", "WARN":
+		return SeverityWarning, nil
+	case "INFO", "SUCCESS":
+		return SeverityInfo, nil
+	}
+	return SeverityError, fmt.Errorf("unknown severity %q", v)
+}
+
+type Event struct {
+	Severity  Severity       `json:"severity"`
+	Title     string         `json:"title"`
+	Message   string         `json:"message"`
+	JobName   string         `json:"job_name"`
+	Profile   string         `json:"profile,omitempty"`
+	Block     string         `json:"block"`
+	ProcessID string         `json:"process_id"`
+	Time      time.Time      `json:"time"`
+	Report    *report.Report `json:"report,omitempty"`
+}
+
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, ev Event) error
+}
+
+type target struct {
+	notifier    Notifier
+	blocks      map[string]bool
+	minSeverity Severity
+}
+
+func (t target) accepts(ev Event) bool {
+	if ev.Severity < t.minSeverity {
+		return false
+	}
+	if len(t.blocks) == 0 {
+		return true
+	}
+	return t.blocks[ev.Block]
+}
+
+// Dispatcher routes events to every notifier whose block filter and
+// minimum severity match.
+type Dispatcher struct {
+	targets []target
+}
+
+func (d *Dispatcher) Add(n Notifier, blocks []string, minSeverity Severity) {
+	t := target{notifier: n, minSeverity: minSeverity}
+	if len(blocks) > 0 {
+		t.blocks = map[string]bool{}
+		for _, b := range blocks {
+			t.blocks[strings.ToUpper(strings.TrimSpace(b))] = true
+		}
+	}
+	d.targets = append(d.targets, t)
+}
+
+// Dispatch never fails the run: delivery errors are only logged.
+func (d *Dispatcher) Dispatch(ctx context.Context, ev Event) {
+	if d == nil {
+		return
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now()
+	}
+
+	for _, t := range d.targets {
+		if !t.accepts(ev) {
+			continue
+		}
+
+		sendCtx, cancel := context.WithTimeout(ctx, 30*time.Second)
+		err := t.notifier.Notify(sendCtx, ev)
+		cancel()
+
+		if err != nil {
+			log.Printf("[NOTIFY][%s] send failed: %v", t.notifier.Name(), err)
+			continue
+		}
+		log.Printf("[NOTIFY][%s] sent %s: %s", t.notifier.Name(), ev.Severity, ev.Title)
+	}
+}
+
+func New(cfg config.NotifyConfig) (*Dispatcher, error) {
+	d := &Dispatcher{}
+
+	for i, t := range cfg.Targets {
+		sev, err := ParseSeverity(t.MinSeverity)
+		if err != nil {
+			return nil, fmt.Errorf("notify target #%d: %w", i+1, err)
+		}
+
+		var n Notifier
+		switch strings.ToLower(t.Type) {
+		case "webhook":
+			n = NewWebhook(t.URL)
+		case "slack":
+			n = NewSlack(t.URL)
+		case "smtp":
+			n = &SMTP{
+				Host:     t.SMTPHost,
+				Port:     t.SMTPPort,
+				Username: t.Username,
+				Password: t.Password,
+				From:     t.From,
+				To:       t.To,
+			}
+		default:
+			return nil, fmt.Errorf("notify target #%d: unknown type %q", i+1, t.Type)
+		}
+
+		d.Add(n, t.Blocks, sev)
+	}
+
+	return d, nil
+}
+
+// RunEvents turns a finished run into notification events: an error event
+// when the run failed (a warning when it was cancelled), a warning per
+// block whose reject rate is above the threshold and an info event when
+// everything succeeded.
+func RunEvents(r report.Report, jobName, block string, rejectRateThreshold float64) []Event {
+	base := Event{
+		JobName:   jobName,
+		Profile:   r.Profile,
+		Block:     block,
+		ProcessID: r.ProcessID,
+		Report:    &r,
+	}
+
+	var events []Event
+
+	switch r.Status {
+	case "SUCCESS":
+	case "CANCELLED":
+		ev := base
+		ev.Severity = SeverityWarning
+		ev.Title = "IMPORT CANCELLED"
+		ev.Message = r.Error
+		events = append(events, ev)
+	default:
+		ev := base
+		ev.Severity = SeverityError
+		ev.Title = "IMPORT FAILED"
+		ev.Message = r.Error
+		events = append(events, ev)
+	}
+
+	if rejectRateThreshold > 0 {
+		for _, b := range r.Blocks {
+			if b.Totals.Lines == 0 {
+				continue
+			}
+			rate := float64(b.Totals.Rejected) * 100 / float64(b.Totals.Lines)
+			if rate <= rejectRateThreshold {
+				continue
+			}
+
+			ev := base
+			ev.Severity = SeverityWarning
+			ev.Title = "REJECT RATE THRESHOLD EXCEEDED"
+			ev.Message = fmt.Sprintf(
+				"block %s rejected %d of %d lines (%.2f%%, threshold %.2f%%)",
+				b.Block, b.Totals.Rejected, b.Totals.Lines, rate, rejectRateThreshold,
+			)
+			events = append(events, ev)
+		}
+	}
+
+	if len(events) == 0 {
+		ev := base
+		ev.Severity = SeverityInfo
+		ev.Title = "IMPORT COMPLETED"
+		ev.Message = fmt.Sprintf("completed in %s", time.Duration(r.DurationMs)*time.Millisecond)
+		events = append(events, ev)
+	}
+
+	return events
+}
+
+// summaryText is the plain text body shared by the Slack and SMTP notifiers.
+func summaryText(ev Event) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "[%s] %s\n", ev.Severity, ev.Title)
+	if ev.JobName != "" {
+		fmt.Fprintf(&b, "Job       : %s\n", ev.JobName)
+	}
+	if ev.Profile != "" {
+		fmt.Fprintf(&b, "Profile   : %s\n", ev.Profile)
+	}
+	fmt.Fprintf(&b, "Block     : %s\n", ev.Block)
+	fmt.Fprintf(&b, "Process ID: %s\n", ev.ProcessID)
+	fmt.Fprintf(&b, "Time      : %s\n", ev.Time.Format("2006-01-02 15:04:05"))
+	if ev.Message != "" {
+		fmt.Fprintf(&b, "Message   : %s\n", ev.Message)
+	}
+
+	if r := ev.Report; r != nil {
+		fmt.Fprintf(&b, "Duration  : %s\n", time.Duration(r.DurationMs)*time.Millisecond)
+		fmt.Fprintf(&b, "Lines     : %d (parsed %d, rejected %d)\n", r.Totals.Lines, r.Totals.Parsed, r.Totals.Rejected)
+		fmt.Fprintf(&b, "Rows      : inserted %d, updated %d, unchanged %d\n", r.Totals.Inserted, r.Totals.Updated, r.Totals.Unchanged)
+
+		for _, blk := range r.Blocks {
+			for _, f := range blk.Finalize {
+				fmt.Fprintf(&b, "Finalize  : %s %s (inserted %d, updated %d)\n", f.Name, f.Status, f.Inserted, f.Updated)
+			}
+			for i, rr := range blk.Rejects {
+				if i == 3 {
+					break
+				}
+				fmt.Fprintf(&b, "Reject    : %s x%d\n", rr.Reason, rr.Count)
+			}
+		}
+	}
+
+	return b.String()
+}
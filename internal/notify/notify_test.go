@@ -0,0 +1,295 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"go-import-file/internal/config"
+	"go-import-file/internal/metrics"
+	"go-import-file/internal/report"
+)
+
+// posted is an Event as a webhook receiver decodes it.
+type posted struct {
+	Severity  string `json:"severity"`
+	Title     string `json:"title"`
+	Block     string `json:"block"`
+	ProcessID string `json:"process_id"`
+}
+
+func TestWebhookPostsEvent(t *testing.T) {
+	var got posted
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("content type = %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+	}))
+	defer srv.Close()
+
+	ev := Event{Severity: SeverityError, Title: "IMPORT FAILED", Block: "SLSINV", ProcessID: "p1"}
+	if err := NewWebhook(srv.URL).Notify(context.Background(), ev); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if got.Severity != "ERROR" || got.Title != ev.Title || got.Block != ev.Block || got.ProcessID != ev.ProcessID {
+		t.Errorf("posted %+v, want %+v", got, ev)
+	}
+}
+
+func TestSlackPostsSummaryText(t *testing.T) {
+	var got map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&got)
+	}))
+	defer srv.Close()
+
+	ev := Event{Severity: SeverityWarning, Title: "IMPORT CANCELLED", Block: "MPRICE"}
+	if err := NewSlack(srv.URL).Notify(context.Background(), ev); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+	if !strings.Contains(got["text"], "[WARNING] IMPORT CANCELLED") {
+		t.Errorf("text = %q", got["text"])
+	}
+}
+
+func TestWebhookFailsOnErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusBadGateway)
+	}))
+	defer srv.Close()
+
+	err := NewWebhook(srv.URL).Notify(context.Background(), Event{})
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("err = %v, want a 502 error", err)
+	}
+}
+
+func TestDispatchFiltersByBlockAndSeverity(t *testing.T) {
+	var mu sync.Mutex
+	var titles []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ev posted
+		json.NewDecoder(r.Body).Decode(&ev)
+		mu.Lock()
+		titles = append(titles, ev.Block+" "+ev.Severity)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	d, err := New(config.NotifyConfig{Targets: []config.NotifyTarget{
+		{Type: "webhook", URL: srv.URL, Blocks: []string{"sdeal"}, MinSeverity: "warning"},
+	}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+
+	for _, ev := range []Event{
+		{Block: "SDEAL", Severity: SeverityError},
+		{Block: "SDEAL", Severity: SeverityInfo},
+		{Block: "MPRICE", Severity: SeverityError},
+		{Block: "SDEAL", Severity: SeverityWarning},
+	} {
+		d.Dispatch(context.Background(), ev)
+	}
+
+	want := []string{"SDEAL ERROR", "SDEAL WARNING"}
+	if strings.Join(titles, ",") != strings.Join(want, ",") {
+		t.Errorf("delivered %v, want %v", titles, want)
+	}
+}
+
+func TestNewRejectsInvalidTargets(t *testing.T) {
+	tests := []struct {
+		name   string
+		target config.NotifyTarget
+	}{
+		{"unknown type", config.NotifyTarget{Type: "pager"}},
+		{"unknown severity", config.NotifyTarget{Type: "webhook", URL: "http://x", MinSeverity: "loud"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := New(config.NotifyConfig{Targets: []config.NotifyTarget{tt.target}}); err == nil {
+				t.Error("New succeeded, want an error")
+			}
+		})
+	}
+}
+
+func TestRunEventsFailsOnTableError(t *testing.T) {
+	snap := metrics.RunSnapshot{
+		ProcessID: "p1",
+		StartedAt: time.Now(),
+		Blocks: []metrics.BlockStats{{
+			Block:  "SLSINV",
+			Tables: []metrics.TableStats{{Table: "sap_web_inv_sfa", Error: "no such column: PRICE"}},
+		}},
+	}
+
+	r := report.Build(snap, nil)
+	if r.Status != "FAILED" {
+		t.Fatalf("status = %s, want FAILED", r.Status)
+	}
+
+	events := RunEvents(r, "job", "SLSINV", 0)
+	if len(events) != 1 || events[0].Title != "IMPORT FAILED" || events[0].Severity != SeverityError {
+		t.Fatalf("events = %+v, want a single IMPORT FAILED error", events)
+	}
+	if !strings.Contains(events[0].Message, "no such column") {
+		t.Errorf("message = %q", events[0].Message)
+	}
+}
+
+// fakeSMTP is a minimal SMTP server that accepts one message per
+// connection and hands it to the test.
+type fakeSMTP struct {
+	ln   net.Listener
+	msgs chan string
+}
+
+func newFakeSMTP(t *testing.T) *fakeSMTP {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := &fakeSMTP{ln: ln, msgs: make(chan string, 1)}
+	go f.serve()
+	t.Cleanup(func() { ln.Close() })
+	return f
+}
+
+func (f *fakeSMTP) port() int {
+	return f.ln.Addr().(*net.TCPAddr).Port
+}
+
+func (f *fakeSMTP) serve() {
+	for {
+		conn, err := f.ln.Accept()
+		if err != nil {
+			return
+		}
+		go f.session(conn)
+	}
+}
+
+func (f *fakeSMTP) session(conn net.Conn) {
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	reply := func(s string) { io.WriteString(conn, s+"\r\n") }
+
+	reply("220 fake ESMTP")
+	var data strings.Builder
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(strings.TrimSpace(line))
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			reply("250 fake")
+		case strings.HasPrefix(cmd, "MAIL"), strings.HasPrefix(cmd, "RCPT"), strings.HasPrefix(cmd, "RSET"):
+			reply("250 ok")
+		case cmd == "DATA":
+			reply("354 go ahead")
+			for {
+				l, err := r.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if l == ".\r\n" {
+					break
+				}
+				data.WriteString(l)
+			}
+			f.msgs <- data.String()
+			reply("250 queued")
+		case cmd == "QUIT":
+			reply("221 bye")
+			return
+		default:
+			reply("502 not implemented")
+		}
+	}
+}
+
+func TestSMTPSendsSummary(t *testing.T) {
+	srv := newFakeSMTP(t)
+
+	s := &SMTP{Host: "127.0.0.1", Port: srv.port(), From: "importer@example.com", To: []string{"ops@example.com"}}
+	ev := Event{Severity: SeverityError, Title: "IMPORT FAILED", JobName: "nightly", Block: "SDEAL", ProcessID: "p1", Time: time.Now()}
+
+	if err := s.Notify(context.Background(), ev); err != nil {
+		t.Fatalf("Notify: %v", err)
+	}
+
+	select {
+	case msg := <-srv.msgs:
+		for _, want := range []string{
+			"Subject: [nightly][ERROR] SDEAL IMPORT FAILED",
+			"To: ops@example.com",
+			"Process ID: p1",
+		} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("message lacks %q:\n%s", want, msg)
+			}
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message received")
+	}
+}
+
+func TestSMTPStopsAtContextDeadline(t *testing.T) {
+	// The server accepts and never greets, so the client blocks on the
+	// first read until the context ends.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+
+	closed := make(chan struct{})
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		io.Copy(io.Discard, conn)
+		close(closed)
+	}()
+
+	s := &SMTP{Host: "127.0.0.1", Port: ln.Addr().(*net.TCPAddr).Port, From: "a@example.com", To: []string{"b@example.com"}}
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	err = s.Notify(ctx, Event{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+
+	// The connection is gone once Notify returns, not left to a goroutine.
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("connection still open after Notify returned")
+	}
+}
+
+func TestSMTPNeedsRecipients(t *testing.T) {
+	if err := (&SMTP{Host: "127.0.0.1"}).Notify(context.Background(), Event{}); err == nil {
+		t.Fatal("Notify succeeded without recipients")
+	}
+}
@@ -0,0 +1,123 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTP sends the run summary as a plain text email. Authentication is only
+// attempted when Username is set, so a local stand-in server without auth
+// works out of the box.
+type SMTP struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+func (s *SMTP) Name() string {
+	return "smtp"
+}
+
+func (s *SMTP) Notify(ctx context.Context, ev Event) error {
+	if len(s.To) == 0 {
+		return fmt.Errorf("no recipients configured")
+	}
+
+	port := s.Port
+	if port == 0 {
+		port = 25
+	}
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(port))
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		auth = smtp.PlainAuth("", s.Username, s.Password, s.Host)
+	}
+
+	subject := fmt.Sprintf("[%s] %s %s", ev.Severity, ev.Block, ev.Title)
+	if ev.Profile != "" {
+		subject = "[" + ev.Profile + "]" + subject
+	}
+	if ev.JobName != "" {
+		subject = "[" + ev.JobName + "]" + subject
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", subject)
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	msg.WriteString(strings.ReplaceAll(summaryText(ev), "\n", "\r\n"))
+
+	return sendMail(ctx, addr, s.Host, auth, s.From, s.To, []byte(msg.String()))
+}
+
+// sendMail is smtp.SendMail bound to ctx: the connection is closed when ctx
+// ends, which unblocks the command in flight, so nothing outlives the call.
+func sendMail(ctx context.Context, addr, host string, auth smtp.Auth, from string, to []string, msg []byte) (err error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer func() {
+		stop()
+		conn.Close()
+		if ctxErr := ctx.Err(); err != nil && ctxErr != nil {
+			err = ctxErr
+		}
+	}()
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := c.Extension("AUTH"); !ok {
+			return fmt.Errorf("server does not support AUTH")
+		}
+		if err := c.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
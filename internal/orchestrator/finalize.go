@@ -0,0 +1,382 @@
+package orchestrator
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"go-import-file/internal/config"
+	"go-import-file/internal/lock"
+	"go-import-file/internal/metrics"
+	"go-import-file/internal/retry"
+)
+
+var errLeaseLost = errors.New("finalize lease lost")
+
+// Finalizer runs finalize steps once per process and block, tracked in
+// import_finalize_log. A step holds a lease while it runs and renews it
+// with a heartbeat; a RUNNING row whose lease expired belongs to a crashed
+// run and is taken over. The step runs in a transaction that also marks
+// the row DONE, fenced on the lease: a run that lost its lease rolls back
+// instead of committing over the run that took it. The bookkeeping
+// statements and the step are retried on transient errors, each attempt
+// in a fresh transaction.
+type Finalizer struct {
+	db     *sql.DB
+	policy retry.Policy
+	owner  string
+	lease  time.Duration
+}
+
+// FinalizeStep is the work of one finalize attempt. It runs inside tx and
+// must not commit it; it reports the rows it inserted and updated.
+type FinalizeStep func(ctx context.Context, tx *sql.Tx) (inserted, updated int64, err error)
+
+func NewFinalizer(cfg *config.Config, db *sql.DB) *Finalizer {
+	return &Finalizer{
+		db:     db,
+		policy: retry.FromConfig(cfg.Retry),
+		owner:  lock.Owner(),
+		lease:  time.Duration(cfg.FinalizeLeaseSeconds) * time.Second,
+	}
+}
+
+// Run runs finalize for block unless processID already finalized it.
+func (f *Finalizer) Run(
+	ctx context.Context,
+	block string,
+	processID string,
+	finalize FinalizeStep,
+) error {
+
+	// =============================
+	// ACQUIRE LEASE
+	// =============================
+	var skip bool
+	err := f.policy.Do(ctx, block+" FINALIZE LOCK", func(ctx context.Context) error {
+		var err error
+		skip, err = f.acquire(ctx, block, processID)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if skip {
+		log.Printf("%s FINALIZE already DONE, skip", block)
+		metrics.RecordFinalize(metrics.FinalizeStats{Name: block, Status: "SKIPPED"})
+		return nil
+	}
+
+	// =============================
+	// ACTUAL FINALIZE, MARKED DONE
+	// =============================
+	runCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	start := time.Now()
+	var inserted, updated int64
+
+	stop := f.heartbeat(runCtx, cancel, block, processID)
+	attempt := 0
+	err = f.policy.Do(runCtx, block+" FINALIZE", func(ctx context.Context) error {
+		// An attempt whose commit went through but whose acknowledgement
+		// was lost left the row DONE; running the step again would fail
+		// the fence.
+		if attempt++; attempt > 1 {
+			if done, err := f.done(ctx, block, processID); err != nil || done {
+				return err
+			}
+		}
+		var err error
+		inserted, updated, err = f.commit(ctx, block, processID, finalize)
+		return err
+	})
+	stop()
+
+	if err != nil {
+		if done, derr := f.done(context.WithoutCancel(ctx), block, processID); derr == nil && done {
+			err = nil
+		}
+	}
+	if err != nil {
+		if cause := context.Cause(runCtx); errors.Is(cause, errLeaseLost) && !errors.Is(err, errLeaseLost) {
+			err = fmt.Errorf("%w: %w", cause, err)
+		}
+		// A shutdown rolled the step back; record that with a context of
+		// its own so the next run can start it again.
+		status := "FAILED"
+		if ctx.Err() != nil {
+			status = "CANCELLED"
+		}
+		_ = f.release(context.WithoutCancel(ctx), block, processID, status, err)
+		metrics.RecordFinalize(metrics.FinalizeStats{Name: block, Status: status, Error: err.Error()})
+		return err
+	}
+
+	metrics.RecordFinalize(metrics.FinalizeStats{
+		Name:     block,
+		Status:   "DONE",
+		Inserted: inserted,
+		Updated:  updated,
+		Duration: time.Since(start),
+	})
+
+	log.Printf(
+		"%s FINALIZE completed | inserted=%d updated=%d duration=%s",
+		block,
+		inserted,
+		updated,
+		time.Since(start),
+	)
+
+	return nil
+}
+
+// commit runs one attempt of finalize and marks the row DONE in the same
+// transaction. The DONE update is the fence: it only matches while this run
+// still owns a live lease, otherwise the attempt is rolled back with
+// errLeaseLost.
+func (f *Finalizer) commit(ctx context.Context, block, processID string, finalize FinalizeStep) (int64, int64, error) {
+	tx, err := f.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer tx.Rollback()
+
+	inserted, updated, err := finalize(ctx, tx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	r, err := tx.ExecContext(ctx, `
+		UPDATE import_finalize_log
+		SET status='DONE',
+			finished_at=SYSDATETIME(),
+			error_message=NULL,
+			lease_expires_at=NULL
+		WHERE process_id=@pid AND block_code=@blk
+			AND status='RUNNING' AND lease_owner=@owner
+			AND lease_expires_at > SYSDATETIME()
+	`,
+		sql.Named("pid", processID),
+		sql.Named("blk", block),
+		sql.Named("owner", f.owner),
+	)
+	if err != nil {
+		return 0, 0, err
+	}
+	n, err := r.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+	if n == 0 {
+		return 0, 0, fmt.Errorf("%s FINALIZE rolled back: %w", block, errLeaseLost)
+	}
+
+	return inserted, updated, tx.Commit()
+}
+
+// done reports whether this run already marked the row DONE.
+func (f *Finalizer) done(ctx context.Context, block, processID string) (bool, error) {
+	var n int
+	err := f.db.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM import_finalize_log
+		WHERE process_id=@pid AND block_code=@blk
+			AND status='DONE' AND lease_owner=@owner
+	`,
+		sql.Named("pid", processID),
+		sql.Named("blk", block),
+		sql.Named("owner", f.owner),
+	).Scan(&n)
+	return n > 0, err
+}
+
+// acquire claims the block for processID. It reports skip when the block
+// is already DONE and fails while another run holds a live lease.
+func (f *Finalizer) acquire(ctx context.Context, block, processID string) (bool, error) {
+	tx, err := f.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var (
+		status  string
+		owner   sql.NullString
+		expires sql.NullTime
+		live    bool
+	)
+	err = tx.QueryRowContext(ctx, `
+		SELECT
+			status,
+			lease_owner,
+			lease_expires_at,
+			CAST(CASE WHEN lease_expires_at > SYSDATETIME() THEN 1 ELSE 0 END AS bit)
+		FROM import_finalize_log
+		WHERE process_id = @pid AND block_code = @blk
+	`, sql.Named("pid", processID), sql.Named("blk", block)).Scan(&status, &owner, &expires, &live)
+
+	if err == nil {
+		switch {
+		case status == "DONE":
+			return true, nil
+		case status == "RUNNING" && live && owner.String != f.owner:
+			return false, fmt.Errorf(
+				"%s FINALIZE already RUNNING by %s, lease until %s",
+				block, owner.String, expires.Time.Format(time.DateTime),
+			)
+		case status == "RUNNING" && owner.String != f.owner:
+			log.Printf("%s FINALIZE taking over expired lease of %s", block, nullText(owner))
+		}
+	} else if err != sql.ErrNoRows {
+		return false, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		MERGE import_finalize_log AS t
+		USING (SELECT @pid AS pid, @blk AS blk) s
+		ON t.process_id = s.pid AND t.block_code = s.blk
+		WHEN MATCHED THEN
+			UPDATE SET status='RUNNING',
+				started_at=SYSDATETIME(),
+				finished_at=NULL,
+				error_message=NULL,
+				lease_owner=@owner,
+				lease_expires_at=DATEADD(second, @lease, SYSDATETIME()),
+				heartbeat_at=SYSDATETIME(),
+				attempts=t.attempts + 1
+		WHEN NOT MATCHED THEN
+			INSERT (process_id, block_code, status, started_at, lease_owner, lease_expires_at, heartbeat_at, attempts)
+			VALUES (@pid, @blk, 'RUNNING', SYSDATETIME(), @owner, DATEADD(second, @lease, SYSDATETIME()), SYSDATETIME(), 1);
+	`,
+		sql.Named("pid", processID),
+		sql.Named("blk", block),
+		sql.Named("owner", f.owner),
+		sql.Named("lease", int(f.lease/time.Second)),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return false, tx.Commit()
+}
+
+// heartbeat renews the lease every third of its length until stop is
+// called. When the row was taken over, or no renewal succeeded for a whole
+// lease, it cancels ctx with errLeaseLost.
+func (f *Finalizer) heartbeat(
+	ctx context.Context,
+	lost context.CancelCauseFunc,
+	block, processID string,
+) (stop func()) {
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+
+		t := time.NewTicker(f.lease / 3)
+		defer t.Stop()
+		renewed := time.Now()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-t.C:
+			}
+
+			ok, err := f.renew(ctx, block, processID)
+			switch {
+			case err == nil && ok:
+				renewed = time.Now()
+			case err == nil:
+				log.Printf("%s FINALIZE lease taken over by another run", block)
+				lost(errLeaseLost)
+				return
+			case time.Since(renewed) >= f.lease:
+				log.Printf("%s FINALIZE lease expired, heartbeat failing: %v", block, err)
+				lost(errLeaseLost)
+				return
+			default:
+				log.Printf("%s FINALIZE heartbeat failed: %v", block, err)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+func (f *Finalizer) renew(ctx context.Context, block, processID string) (bool, error) {
+	r, err := f.db.ExecContext(ctx, `
+		UPDATE import_finalize_log
+		SET lease_expires_at=DATEADD(second, @lease, SYSDATETIME()),
+			heartbeat_at=SYSDATETIME()
+		WHERE process_id=@pid AND block_code=@blk
+			AND status='RUNNING' AND lease_owner=@owner
+	`,
+		sql.Named("lease", int(f.lease/time.Second)),
+		sql.Named("pid", processID),
+		sql.Named("blk", block),
+		sql.Named("owner", f.owner),
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := r.RowsAffected()
+	return n == 1, err
+}
+
+// release records a failed or cancelled outcome and ends the lease, only
+// while this run still owns the row and has not marked it DONE.
+func (f *Finalizer) release(ctx context.Context, block, processID, status string, cause error) error {
+	var msg sql.NullString
+	if cause != nil {
+		msg = sql.NullString{String: cause.Error(), Valid: true}
+	}
+
+	return f.policy.Do(ctx, block+" FINALIZE LOG", func(ctx context.Context) error {
+		r, err := f.db.ExecContext(ctx, `
+			UPDATE import_finalize_log
+			SET status=@status,
+				finished_at=SYSDATETIME(),
+				error_message=@err,
+				lease_expires_at=NULL
+			WHERE process_id=@pid AND block_code=@blk
+				AND status='RUNNING' AND lease_owner=@owner
+		`,
+			sql.Named("status", status),
+			sql.Named("err", msg),
+			sql.Named("pid", processID),
+			sql.Named("blk", block),
+			sql.Named("owner", f.owner),
+		)
+		if err != nil {
+			return err
+		}
+		if n, _ := r.RowsAffected(); n == 0 {
+			log.Printf("%s FINALIZE %s not recorded, the lease was taken over", block, status)
+		}
+		return nil
+	})
+}
+
+func nullText(s sql.NullString) string {
+	if !s.Valid {
+		return "unknown owner"
+	}
+	return s.String
+}
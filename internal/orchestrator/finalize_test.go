@@ -0,0 +1,439 @@
+package orchestrator
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"go-import-file/internal/retry"
+)
+
+// finalizeLog is an in-memory import_finalize_log behind a database/sql
+// driver. It recognises the Finalizer's statements and applies the
+// conditions of their WHERE clauses, so the lease logic can be tested
+// without SQL Server. Steps write with INSERT INTO target VALUES (@v).
+type finalizeLog struct {
+	mu      sync.Mutex
+	rows    map[string]*logRow
+	written []string // committed step writes
+
+	// commitErr, when set, is returned by a commit of step writes after
+	// it was applied: the acknowledgement is lost.
+	commitErr func() error
+}
+
+type logRow struct {
+	status   string
+	owner    string
+	expires  time.Time // zero is NULL
+	attempts int
+}
+
+func newFinalizeLog() *finalizeLog {
+	return &finalizeLog{rows: map[string]*logRow{}}
+}
+
+// set replaces the row of process p1, block B.
+func (l *finalizeLog) set(r logRow) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.rows["p1|B"] = &r
+}
+
+func (l *finalizeLog) get() (logRow, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	r, ok := l.rows["p1|B"]
+	if !ok {
+		return logRow{}, false
+	}
+	return *r, true
+}
+
+func (l *finalizeLog) writes() []string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append([]string(nil), l.written...)
+}
+
+func (l *finalizeLog) Connect(context.Context) (driver.Conn, error) {
+	return &logConn{log: l}, nil
+}
+
+func (l *finalizeLog) Driver() driver.Driver { return logDriver{} }
+
+type logDriver struct{}
+
+func (logDriver) Open(string) (driver.Conn, error) {
+	return nil, errors.New("open the finalize log through its connector")
+}
+
+type logConn struct {
+	log *finalizeLog
+	tx  *logTx
+}
+
+// logTx undoes the row changes of a rolled back transaction and holds its
+// step writes until commit.
+type logTx struct {
+	conn    *logConn
+	undo    map[string]*logRow // nil for a row the transaction inserted
+	pending []string
+}
+
+func (c *logConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (c *logConn) Close() error { return nil }
+
+func (c *logConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *logConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.tx = &logTx{conn: c, undo: map[string]*logRow{}}
+	return c.tx, nil
+}
+
+// CheckNamedValue accepts named parameters and leaves converting the
+// values to database/sql.
+func (c *logConn) CheckNamedValue(*driver.NamedValue) error { return driver.ErrSkip }
+
+func (t *logTx) Commit() error {
+	l := t.conn.log
+	l.mu.Lock()
+	l.written = append(l.written, t.pending...)
+	hook := l.commitErr
+	l.mu.Unlock()
+	t.conn.tx = nil
+
+	if hook != nil && len(t.pending) > 0 {
+		return hook()
+	}
+	return nil
+}
+
+func (t *logTx) Rollback() error {
+	l := t.conn.log
+	l.mu.Lock()
+	for key, r := range t.undo {
+		if r == nil {
+			delete(l.rows, key)
+		} else {
+			l.rows[key] = r
+		}
+	}
+	l.mu.Unlock()
+	t.conn.tx = nil
+	return nil
+}
+
+func named(args []driver.NamedValue) map[string]any {
+	m := map[string]any{}
+	for _, a := range args {
+		m[a.Name] = a.Value
+	}
+	return m
+}
+
+func (c *logConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	a := named(args)
+	l := c.log
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if strings.Contains(query, "INSERT INTO target") {
+		if c.tx == nil {
+			l.written = append(l.written, fmt.Sprint(a["v"]))
+		} else {
+			c.tx.pending = append(c.tx.pending, fmt.Sprint(a["v"]))
+		}
+		return driver.RowsAffected(1), nil
+	}
+
+	key := fmt.Sprintf("%v|%v", a["pid"], a["blk"])
+	r := l.rows[key]
+	now := time.Now()
+	live := r != nil && r.expires.After(now)
+	owned := r != nil && r.owner == a["owner"]
+	running := r != nil && r.status == "RUNNING"
+
+	touch := func() *logRow {
+		if c.tx != nil {
+			if _, saved := c.tx.undo[key]; !saved {
+				if r == nil {
+					c.tx.undo[key] = nil
+				} else {
+					prev := *r
+					c.tx.undo[key] = &prev
+				}
+			}
+		}
+		if r == nil {
+			r = &logRow{}
+			l.rows[key] = r
+		}
+		return r
+	}
+
+	var match bool
+	switch {
+	case strings.Contains(query, "MERGE import_finalize_log"):
+		match = true
+		r := touch()
+		r.status = "RUNNING"
+		r.owner = a["owner"].(string)
+		r.expires = now.Add(time.Duration(a["lease"].(int64)) * time.Second)
+		r.attempts++
+
+	case strings.Contains(query, "SET status='DONE'"):
+		match = running && owned &&
+			(live || !strings.Contains(query, "lease_expires_at > SYSDATETIME()"))
+		if match {
+			r := touch()
+			r.status = "DONE"
+			r.expires = time.Time{}
+		}
+
+	case strings.Contains(query, "SET lease_expires_at=DATEADD"):
+		match = running && owned
+		if match {
+			touch().expires = now.Add(time.Duration(a["lease"].(int64)) * time.Second)
+		}
+
+	case strings.Contains(query, "SET status=@status"):
+		match = owned && (running || !strings.Contains(query, "status='RUNNING'"))
+		if match {
+			r := touch()
+			r.status = a["status"].(string)
+			r.expires = time.Time{}
+		}
+
+	default:
+		return nil, fmt.Errorf("unexpected statement: %s", query)
+	}
+
+	if !match {
+		return driver.RowsAffected(0), nil
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (c *logConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	a := named(args)
+	l := c.log
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r := l.rows[fmt.Sprintf("%v|%v", a["pid"], a["blk"])]
+
+	switch {
+	case strings.Contains(query, "CASE WHEN lease_expires_at > SYSDATETIME()"):
+		rows := &logRows{cols: []string{"status", "lease_owner", "lease_expires_at", "live"}}
+		if r != nil {
+			var expires driver.Value
+			if !r.expires.IsZero() {
+				expires = r.expires
+			}
+			rows.vals = append(rows.vals, []driver.Value{r.status, r.owner, expires, r.expires.After(time.Now())})
+		}
+		return rows, nil
+
+	case strings.Contains(query, "COUNT(*)") && strings.Contains(query, "status='DONE'"):
+		n := int64(0)
+		if r != nil && r.status == "DONE" && r.owner == a["owner"] {
+			n = 1
+		}
+		return &logRows{cols: []string{"n"}, vals: [][]driver.Value{{n}}}, nil
+	}
+	return nil, fmt.Errorf("unexpected query: %s", query)
+}
+
+type logRows struct {
+	cols []string
+	vals [][]driver.Value
+}
+
+func (r *logRows) Columns() []string { return r.cols }
+func (r *logRows) Close() error      { return nil }
+
+func (r *logRows) Next(dest []driver.Value) error {
+	if len(r.vals) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.vals[0])
+	r.vals = r.vals[1:]
+	return nil
+}
+
+func newTestFinalizer(t *testing.T, l *finalizeLog, lease time.Duration) *Finalizer {
+	t.Helper()
+
+	db := sql.OpenDB(l)
+	t.Cleanup(func() { db.Close() })
+	return &Finalizer{
+		db:     db,
+		policy: retry.Policy{Attempts: 3, Backoff: time.Millisecond},
+		owner:  "me",
+		lease:  lease,
+	}
+}
+
+// write is a step that writes v and counts its runs.
+func write(v string, runs *int) FinalizeStep {
+	return func(ctx context.Context, tx *sql.Tx) (int64, int64, error) {
+		*runs++
+		_, err := tx.ExecContext(ctx, `INSERT INTO target VALUES (@v)`, sql.Named("v", v))
+		return 1, 0, err
+	}
+}
+
+func TestFinalizerRunsOnceAndSkipsWhenDone(t *testing.T) {
+	l := newFinalizeLog()
+	f := newTestFinalizer(t, l, time.Minute)
+
+	var runs int
+	for i := 0; i < 2; i++ {
+		if err := f.Run(context.Background(), "B", "p1", write("x", &runs)); err != nil {
+			t.Fatalf("run %d: %v", i+1, err)
+		}
+	}
+	if runs != 1 {
+		t.Errorf("step ran %d times, want 1", runs)
+	}
+	if r, _ := l.get(); r.status != "DONE" || r.owner != "me" || r.attempts != 1 {
+		t.Errorf("row = %+v, want DONE by me after 1 attempt", r)
+	}
+	if got := l.writes(); len(got) != 1 {
+		t.Errorf("writes = %v, want one", got)
+	}
+}
+
+func TestFinalizerRefusesLiveLease(t *testing.T) {
+	l := newFinalizeLog()
+	l.set(logRow{status: "RUNNING", owner: "other", expires: time.Now().Add(time.Minute), attempts: 1})
+	f := newTestFinalizer(t, l, time.Minute)
+
+	var runs int
+	err := f.Run(context.Background(), "B", "p1", write("x", &runs))
+	if err == nil || !strings.Contains(err.Error(), "already RUNNING by other") {
+		t.Fatalf("err = %v, want the live lease of other", err)
+	}
+	if runs != 0 {
+		t.Errorf("step ran %d times, want 0", runs)
+	}
+	if r, _ := l.get(); r.status != "RUNNING" || r.owner != "other" {
+		t.Errorf("row = %+v, want it left to other", r)
+	}
+}
+
+func TestFinalizerTakesOverExpiredLease(t *testing.T) {
+	l := newFinalizeLog()
+	l.set(logRow{status: "RUNNING", owner: "crashed", expires: time.Now().Add(-time.Second), attempts: 1})
+	f := newTestFinalizer(t, l, time.Minute)
+
+	var runs int
+	if err := f.Run(context.Background(), "B", "p1", write("x", &runs)); err != nil {
+		t.Fatal(err)
+	}
+	if r, _ := l.get(); r.status != "DONE" || r.owner != "me" || r.attempts != 2 {
+		t.Errorf("row = %+v, want DONE by me after 2 attempts", r)
+	}
+}
+
+func TestFinalizerHeartbeatLossCancelsStep(t *testing.T) {
+	l := newFinalizeLog()
+	f := newTestFinalizer(t, l, time.Second)
+
+	step := func(ctx context.Context, tx *sql.Tx) (int64, int64, error) {
+		// Another run takes the row over while the step runs; the next
+		// renewal no longer matches.
+		l.set(logRow{status: "RUNNING", owner: "other", expires: time.Now().Add(time.Minute), attempts: 2})
+		select {
+		case <-ctx.Done():
+			return 0, 0, ctx.Err()
+		case <-time.After(5 * time.Second):
+			return 0, 0, errors.New("step was not cancelled")
+		}
+	}
+
+	err := f.Run(context.Background(), "B", "p1", step)
+	if !errors.Is(err, errLeaseLost) {
+		t.Fatalf("err = %v, want %v", err, errLeaseLost)
+	}
+	if r, _ := l.get(); r.status != "RUNNING" || r.owner != "other" {
+		t.Errorf("row = %+v, want it left to other", r)
+	}
+}
+
+func TestFinalizerFenceRollsBackAfterTakeover(t *testing.T) {
+	l := newFinalizeLog()
+	f := newTestFinalizer(t, l, time.Minute)
+
+	var runs int
+	step := func(ctx context.Context, tx *sql.Tx) (int64, int64, error) {
+		in, up, err := write("x", &runs)(ctx, tx)
+		l.set(logRow{status: "RUNNING", owner: "other", expires: time.Now().Add(time.Minute), attempts: 2})
+		return in, up, err
+	}
+
+	err := f.Run(context.Background(), "B", "p1", step)
+	if !errors.Is(err, errLeaseLost) {
+		t.Fatalf("err = %v, want %v", err, errLeaseLost)
+	}
+	if runs != 1 {
+		t.Errorf("step ran %d times, want 1", runs)
+	}
+	if got := l.writes(); len(got) != 0 {
+		t.Errorf("writes = %v, want the step rolled back", got)
+	}
+	if r, _ := l.get(); r.status != "RUNNING" || r.owner != "other" {
+		t.Errorf("row = %+v, want it left to other", r)
+	}
+}
+
+func TestFinalizerLostCommitAckIsDone(t *testing.T) {
+	l := newFinalizeLog()
+	var once sync.Once
+	l.commitErr = func() (err error) {
+		once.Do(func() {
+			err = &net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}
+		})
+		return err
+	}
+	f := newTestFinalizer(t, l, time.Minute)
+
+	var runs int
+	if err := f.Run(context.Background(), "B", "p1", write("x", &runs)); err != nil {
+		t.Fatalf("err = %v, want the committed attempt to count", err)
+	}
+	if runs != 1 {
+		t.Errorf("step ran %d times, want 1", runs)
+	}
+	if r, _ := l.get(); r.status != "DONE" {
+		t.Errorf("row = %+v, want DONE", r)
+	}
+}
+
+func TestFinalizerReleaseKeepsDone(t *testing.T) {
+	l := newFinalizeLog()
+	l.set(logRow{status: "DONE", owner: "me", attempts: 1})
+	f := newTestFinalizer(t, l, time.Minute)
+
+	if err := f.release(context.Background(), "B", "p1", "FAILED", errLeaseLost); err != nil {
+		t.Fatal(err)
+	}
+	if r, _ := l.get(); r.status != "DONE" {
+		t.Errorf("row = %+v, want DONE kept", r)
+	}
+}
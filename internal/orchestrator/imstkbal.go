@@ -2,7 +2,6 @@ package orchestrator
 
 import (
 	"context"
-	"database/sql"
 	"log"
 	"path/filepath"
 	"sync"
@@ -11,20 +10,19 @@ import (
 	"go-import-file/internal/config"
 	"go-import-file/internal/metrics"
 	"go-import-file/internal/model"
+	"go-import-file/internal/sink"
 	"go-import-file/internal/utils"
 	"go-import-file/internal/worker"
 )
 
 func RunImStkbal(
 	ctx context.Context,
-	dbConn *sql.DB,
-	filePath string,
+	cfg *config.Config,
+	s sink.Sink,
 	processID string,
 ) error {
 
-	cfg := config.Load()
-
-	files, err := filepath.Glob(filePath + "/*_IMSTKBAL.txt")
+	files, err := filepath.Glob(cfg.FilePath + "/*_IMSTKBAL.txt")
 	if err != nil || len(files) == 0 {
 		return err
 	}
@@ -67,6 +65,7 @@ func RunImStkbal(
 		parseWg.Add(1)
 		go worker.ParseWorker(
 			ctx,
+			cfg,
 			&parseWg,
 			jobs,
 			fileMetrics,
@@ -126,15 +125,15 @@ func RunImStkbal(
 	// ======================
 	// Bulk Insert
 	// ======================
-	done39 := make(chan struct{})
-	go worker.Bulk39(ctx, dbConn, ch39, done39)
+	done39 := make(chan error, 1)
+	go worker.Bulk39(ctx, cfg, s, ch39, done39)
 
 	// ======================
 	// Shutdown Order (CRITICAL)
 	// ======================
 	parseWg.Wait()
 	close(ch39)
-	<-done39
+	loadErr := <-done39
 
 	close(fileMetrics)
 	<-metricsDone
@@ -145,5 +144,5 @@ func RunImStkbal(
 		atomic.LoadInt64(&metrics.InsertedRows),
 	)
 
-	return nil
+	return loadErr
 }
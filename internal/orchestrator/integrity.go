@@ -0,0 +1,82 @@
+package orchestrator
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"go-import-file/internal/config"
+	"go-import-file/internal/sink"
+	"go-import-file/internal/worker"
+)
+
+// integritySamples is how many dangling values a rule reports.
+const integritySamples = 5
+
+type IntegrityResult struct {
+	Rule worker.IntegrityRule
+	sink.Dangling
+}
+
+// SampleText lists the sampled values, composite values joined by "+".
+func (r IntegrityResult) SampleText() string {
+	out := make([]string, len(r.Samples))
+	for i, s := range r.Samples {
+		out[i] = strings.Join(s, "+")
+	}
+	return strings.Join(out, ", ")
+}
+
+// CheckIntegrity runs the integrity rules of block, or all rules for "".
+// Rules on staging tables need the processID of a run and are skipped
+// without one.
+func CheckIntegrity(ctx context.Context, s sink.Sink, block, processID string) ([]IntegrityResult, error) {
+	var results []IntegrityResult
+	for _, rule := range worker.IntegrityRules(block) {
+		if rule.ProcessColumn != "" && processID == "" {
+			continue
+		}
+
+		d, err := s.Dangling(ctx, rule.Reference, processID, integritySamples)
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", rule.Block, rule.Name(), err)
+		}
+		results = append(results, IntegrityResult{Rule: rule, Dangling: d})
+	}
+	return results, nil
+}
+
+// RunIntegrityCheck is the CHECK INTEGRITY step of block: it logs the rows
+// referencing missing master rows and fails when block is in
+// integrity_gate.
+func RunIntegrityCheck(
+	ctx context.Context,
+	cfg *config.Config,
+	s sink.Sink,
+	block string,
+	processID string,
+) error {
+	results, err := CheckIntegrity(ctx, s, block, processID)
+	if err != nil {
+		return err
+	}
+
+	var dangling int64
+	for _, r := range results {
+		if r.Rows == 0 {
+			continue
+		}
+		dangling += r.Rows
+		log.Printf("INTEGRITY %s %s: %d rows dangling, e.g. %s", block, r.Rule.Name(), r.Rows, r.SampleText())
+	}
+
+	if dangling == 0 {
+		log.Printf("INTEGRITY %s: %d rules ok", block, len(results))
+		return nil
+	}
+	if cfg.IntegrityGated(block) {
+		return fmt.Errorf("%d rows of %s reference missing master rows (integrity_gate)", dangling, block)
+	}
+	return nil
+}
@@ -2,7 +2,6 @@ package orchestrator
 
 import (
 	"context"
-	"database/sql"
 	"log"
 	"path/filepath"
 	"sync"
@@ -11,20 +10,19 @@ import (
 	"go-import-file/internal/config"
 	"go-import-file/internal/metrics"
 	"go-import-file/internal/model"
+	"go-import-file/internal/sink"
 	"go-import-file/internal/utils"
 	"go-import-file/internal/worker"
 )
 
 func RunMCust(
 	ctx context.Context,
-	dbConn *sql.DB,
-	filePath string,
+	cfg *config.Config,
+	s sink.Sink,
 	processID string,
 ) error {
 
-	cfg := config.Load()
-
-	files, err := filepath.Glob(filePath + "/*_MCUST.txt")
+	files, err := filepath.Glob(cfg.FilePath + "/*_MCUST.txt")
 	if err != nil || len(files) == 0 {
 		return err
 	}
@@ -67,6 +65,7 @@ func RunMCust(
 		parseWg.Add(1)
 		go worker.ParseWorker(
 			ctx,
+			cfg,
 			&parseWg,
 			jobs,
 			fileMetrics,
@@ -126,15 +125,15 @@ func RunMCust(
 	// ======================
 	// Bulk Insert
 	// ======================
-	done01 := make(chan struct{})
-	go worker.Bulk01(ctx, dbConn, ch01, done01)
+	done01 := make(chan error, 1)
+	go worker.Bulk01(ctx, cfg, s, ch01, done01)
 
 	// ======================
 	// Shutdown Order (CRITICAL)
 	// ======================
 	parseWg.Wait()
 	close(ch01)
-	<-done01
+	loadErr := <-done01
 
 	close(fileMetrics)
 	<-metricsDone
@@ -145,5 +144,5 @@ func RunMCust(
 		atomic.LoadInt64(&metrics.InsertedRows),
 	)
 
-	return nil
+	return loadErr
 }
@@ -2,7 +2,6 @@ package orchestrator
 
 import (
 	"context"
-	"database/sql"
 	"log"
 	"path/filepath"
 	"sync"
@@ -11,20 +10,19 @@ import (
 	"go-import-file/internal/config"
 	"go-import-file/internal/metrics"
 	"go-import-file/internal/model"
+	"go-import-file/internal/sink"
 	"go-import-file/internal/utils"
 	"go-import-file/internal/worker"
 )
 
 func RunMCustGrp(
 	ctx context.Context,
-	dbConn *sql.DB,
-	filePath string,
+	cfg *config.Config,
+	s sink.Sink,
 	processID string,
 ) error {
 
-	cfg := config.Load()
-
-	files, err := filepath.Glob(filePath + "/*_MCUSTGRP.txt")
+	files, err := filepath.Glob(cfg.FilePath + "/*_MCUSTGRP.txt")
 	if err != nil || len(files) == 0 {
 		return err
 	}
@@ -67,6 +65,7 @@ func RunMCustGrp(
 		parseWg.Add(1)
 		go worker.ParseWorker(
 			ctx,
+			cfg,
 			&parseWg,
 			jobs,
 			fileMetrics,
@@ -126,15 +125,15 @@ func RunMCustGrp(
 	// ======================
 	// Bulk Insert
 	// ======================
-	done02 := make(chan struct{})
-	go worker.Bulk02(ctx, dbConn, ch02, done02)
+	done02 := make(chan error, 1)
+	go worker.Bulk02(ctx, cfg, s, ch02, done02)
 
 	// ======================
 	// Shutdown Order (CRITICAL)
 	// ======================
 	parseWg.Wait()
 	close(ch02)
-	<-done02
+	loadErr := <-done02
 
 	close(fileMetrics)
 	<-metricsDone
@@ -145,5 +144,5 @@ func RunMCustGrp(
 		atomic.LoadInt64(&metrics.InsertedRows),
 	)
 
-	return nil
+	return loadErr
 }
@@ -13,20 +13,19 @@ import (
 	"go-import-file/internal/importer"
 	"go-import-file/internal/metrics"
 	"go-import-file/internal/model"
+	"go-import-file/internal/sink"
 	"go-import-file/internal/utils"
 	"go-import-file/internal/worker"
 )
 
 func RunMkplPrice(
 	ctx context.Context,
-	dbConn *sql.DB,
-	filePath string,
+	cfg *config.Config,
+	s sink.Sink,
 	processID string,
 ) error {
 
-	cfg := config.Load()
-
-	files, err := filepath.Glob(filePath + "/*_MKPLPRICE.txt")
+	files, err := filepath.Glob(cfg.FilePath + "/*_MKPLPRICE.txt")
 	if err != nil || len(files) == 0 {
 		return err
 	}
@@ -43,6 +42,12 @@ func RunMkplPrice(
 
 	log.Printf("TOTAL LINES: %d\n", totalLines)
 
+	// SELLPRICE1..5 are computed while parsing, from the ladders in fmaster.
+	ladders, err := worker.LoadLadders(ctx, s)
+	if err != nil {
+		return fmt.Errorf("load unit ladders: %w", err)
+	}
+
 	// ======================
 	// Channels
 	// ======================
@@ -67,6 +72,7 @@ func RunMkplPrice(
 		parseWg.Add(1)
 		go worker.ParseWorker(
 			ctx,
+			cfg,
 			&parseWg,
 			jobs,
 			fileMetrics,
@@ -119,6 +125,7 @@ func RunMkplPrice(
 		jobs <- worker.FileJob{
 			FilePath: path,
 			FileName: filepath.Base(path),
+			Ladders:  ladders,
 		}
 	}
 	close(jobs)
@@ -126,15 +133,15 @@ func RunMkplPrice(
 	// ======================
 	// Bulk Insert
 	// ======================
-	done113 := make(chan struct{})
-	go worker.Bulk113(ctx, dbConn, ch113, done113)
+	done113 := make(chan error, 1)
+	go worker.Bulk113(ctx, cfg, s, ch113, done113)
 
 	// ======================
 	// Shutdown Order (CRITICAL)
 	// ======================
 	parseWg.Wait()
 	close(ch113)
-	<-done113
+	loadErr := <-done113
 
 	close(fileMetrics)
 	<-metricsDone
@@ -145,101 +152,29 @@ func RunMkplPrice(
 		atomic.LoadInt64(&metrics.InsertedRows),
 	)
 
-	return nil
+	return loadErr
 }
 
 func RunMkplPriceFinalizeIdempotent(
 	ctx context.Context,
+	cfg *config.Config,
 	db *sql.DB,
 	processID string,
 ) error {
-
-	const block = "MKPLPRICE"
-
-	// =============================
-	// ACQUIRE FINALIZE LOCK
-	// =============================
-	tx, err := db.BeginTx(ctx, &sql.TxOptions{
-		Isolation: sql.LevelSerializable,
-	})
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	var status string
-	err = tx.QueryRowContext(ctx, `
-		SELECT status
-		FROM import_finalize_log
-		WHERE process_id = @pid AND block_code = @blk
-	`, sql.Named("pid", processID), sql.Named("blk", block)).Scan(&status)
-
-	if err == nil {
-		switch status {
-		case "DONE":
-			log.Println("MKPLPRICE FINALIZE already DONE, skip")
-			return nil
-		case "RUNNING":
-			return fmt.Errorf("MKPLPRICE FINALIZE already RUNNING")
+	err := NewFinalizer(cfg, db).Run(ctx, "MKPLPRICE", processID, func(ctx context.Context, tx *sql.Tx) (int64, int64, error) {
+		// The fmaster join drops prices of unknown products; list them first.
+		if err := checkOrphanPrices(ctx, cfg, tx, processID, mkplPriceOrphans); err != nil {
+			return 0, 0, err
 		}
-	} else if err != sql.ErrNoRows {
-		return err
-	}
-
-	_, err = tx.ExecContext(ctx, `
-		MERGE import_finalize_log AS t
-		USING (SELECT @pid AS pid, @blk AS blk) s
-		ON t.process_id = s.pid AND t.block_code = s.blk
-		WHEN MATCHED THEN
-			UPDATE SET status='RUNNING',
-				started_at=SYSDATETIME(),
-				finished_at=NULL,
-				error_message=NULL
-		WHEN NOT MATCHED THEN
-			INSERT (process_id, block_code, status, started_at)
-			VALUES (@pid, @blk, 'RUNNING', SYSDATETIME());
-	`,
-		sql.Named("pid", processID),
-		sql.Named("blk", block),
-	)
+		return importer.RunMkplPriceFinalize(ctx, tx, processID, cfg.Kodecabang)
+	})
 	if err != nil {
 		return err
 	}
 
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-
-	// =============================
-	// ACTUAL FINALIZE (YOUR FUNCTION)
-	// =============================
-	if err := importer.RunMkplPriceFinalize(ctx, db, processID); err != nil {
-		_, _ = db.ExecContext(ctx, `
-			UPDATE import_finalize_log
-			SET status='FAILED',
-				finished_at=SYSDATETIME(),
-				error_message=@err
-			WHERE process_id=@pid AND block_code=@blk
-		`,
-			sql.Named("err", err.Error()),
-			sql.Named("pid", processID),
-			sql.Named("blk", block),
-		)
-		return err
+	// The prices are committed; a missing report is only logged.
+	if err := writePriceMovement(ctx, cfg, db, processID, "fkpl_price"); err != nil {
+		log.Printf("MKPLPRICE price movement report failed: %v", err)
 	}
-
-	// =============================
-	// MARK DONE
-	// =============================
-	_, err = db.ExecContext(ctx, `
-		UPDATE import_finalize_log
-		SET status='DONE',
-			finished_at=SYSDATETIME()
-		WHERE process_id=@pid AND block_code=@blk
-	`,
-		sql.Named("pid", processID),
-		sql.Named("blk", block),
-	)
-
-	return err
+	return nil
 }
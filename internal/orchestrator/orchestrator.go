@@ -2,19 +2,36 @@ package orchestrator
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"time"
+
+	"go-import-file/internal/metrics"
+	"go-import-file/internal/worker"
 )
 
 type ImportStep struct {
-	Name string
-	Run  func(ctx context.Context) error
+	Name  string
+	Run   func(ctx context.Context) error
+	Watch bool // cancelled by the watchdog when it stops making progress
 }
 
 type ImportChain struct {
 	Steps []ImportStep
+
+	// Timeout returns the deadline of a step by name, 0 for none.
+	Timeout func(name string) time.Duration
+
+	// Idle is how long a watched step may go without progress, 0 for ever.
+	Idle time.Duration
 }
 
+// abandonAfter is how long a stopped step gets to roll back and return. A
+// step that does not, typically stuck in a driver call that ignores
+// cancellation, is abandoned: its goroutines run on until the process
+// exits. Its context is cancelled, so its transactions cannot commit.
+const abandonAfter = 10 * time.Second
+
 func New() *ImportChain {
 	return &ImportChain{}
 }
@@ -26,18 +43,95 @@ func (c *ImportChain) Add(name string, fn func(ctx context.Context) error) {
 	})
 }
 
+// AddWatched adds a step that reads files, so a stall in its pipeline is
+// caught by the watchdog.
+func (c *ImportChain) AddWatched(name string, fn func(ctx context.Context) error) {
+	c.Steps = append(c.Steps, ImportStep{
+		Name:  name,
+		Run:   fn,
+		Watch: true,
+	})
+}
+
 func (c *ImportChain) Run(ctx context.Context) error {
 	for i, step := range c.Steps {
+		// No new step starts after a shutdown request.
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		logSection(step.Name)
 
 		start := time.Now()
-		if err := step.Run(ctx); err != nil {
+		err := c.runStep(ctx, step)
+
+		stats := metrics.StepStats{Name: step.Name, Duration: time.Since(start)}
+		if err != nil {
+			stats.Error = err.Error()
+		}
+		metrics.RecordStep(stats)
+
+		if err != nil {
 			return err
 		}
 
 		log.Printf("Step %d completed in %s\n", i+1, time.Since(start))
 	}
-	return nil
+
+	// Import steps log a rolled-back load instead of failing, so a signal
+	// during the last step is only seen here.
+	return ctx.Err()
+}
+
+// runStep runs step under its deadline and the watchdog. When either stops
+// it, the step's error is replaced by the reason.
+func (c *ImportChain) runStep(ctx context.Context, step ImportStep) error {
+	stepCtx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	if c.Timeout != nil {
+		if d := c.Timeout(step.Name); d > 0 {
+			var cancelTimeout context.CancelFunc
+			stepCtx, cancelTimeout = context.WithTimeoutCause(stepCtx, d,
+				fmt.Errorf("%w: %s after %s", ErrStepTimeout, step.Name, d))
+			defer cancelTimeout()
+		}
+	}
+
+	if step.Watch && c.Idle > 0 {
+		stop := watch(stepCtx, cancel, step.Name, c.Idle)
+		defer stop()
+	}
+
+	// A step that returns nil has committed its load; from then on its
+	// files stay in the success and failed dirs whatever happens next.
+	done := make(chan error, 1)
+	go func() {
+		err := step.Run(stepCtx)
+		if err == nil {
+			worker.CommitMoves()
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if stepCtx.Err() != nil && ctx.Err() == nil {
+			return context.Cause(stepCtx)
+		}
+		return err
+	case <-stepCtx.Done():
+	}
+
+	select {
+	case <-done:
+	case <-time.After(abandonAfter):
+		log.Printf("%s did not stop within %s, abandoned; its goroutines run on until the process exits", step.Name, abandonAfter)
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+	return context.Cause(stepCtx)
 }
 
 func logSection(title string) {
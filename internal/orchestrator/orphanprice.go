@@ -0,0 +1,114 @@
+package orchestrator
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"go-import-file/internal/config"
+)
+
+// orphanSource is a staging table whose rows the finalize step joins to
+// dbo.fmaster by PCODE.
+type orphanSource struct {
+	Block     string
+	Table     string
+	PriceCode string // column identifying the price list, e.g. PRICE_CODE
+}
+
+var (
+	mPriceOrphans    = orphanSource{Block: "MPRICE", Table: "dbo.m_price_dummy", PriceCode: "PRICE_CODE"}
+	mkplPriceOrphans = orphanSource{Block: "MKPLPRICE", Table: "dbo.mkplprice_dummy", PriceCode: "CUST_CODE"}
+)
+
+// orphanPrice is a staged price the fmaster join of the finalize drops.
+type orphanPrice struct {
+	Pcode     sql.NullString
+	PriceCode string
+	Value     string
+	Uom       string
+	File      string
+	Line      int
+}
+
+// checkOrphanPrices lists the prices of processID in src whose PCODE is
+// not in fmaster to orphan_prices_<block>_<processID>.csv in the log dir.
+// It fails when there are more than cfg.OrphanPriceMax of them, before
+// anything is merged. It reads in tx, the finalize transaction, so it sees
+// the fmaster the merge joins to.
+func checkOrphanPrices(
+	ctx context.Context,
+	cfg *config.Config,
+	tx *sql.Tx,
+	processID string,
+	src orphanSource,
+) error {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT
+			mpd.PCODE, ISNULL(mpd.`+src.PriceCode+`, ''), ISNULL(CONVERT(NVARCHAR(255), mpd.PRICE_VALUE), ''),
+			ISNULL(mpd.PRICE_UOM, ''), ISNULL(mpd.CORE_FILENAME, ''), ISNULL(mpd.LINE_NO, 0)
+		FROM `+src.Table+` mpd
+		LEFT JOIN dbo.fmaster fm ON mpd.PCODE = fm.PCODE
+		WHERE mpd.UNIQ_ID = @p1 AND fm.PCODE IS NULL
+		ORDER BY mpd.CORE_FILENAME, mpd.LINE_NO`,
+		processID,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var orphans []orphanPrice
+	for rows.Next() {
+		var o orphanPrice
+		if err := rows.Scan(&o.Pcode, &o.PriceCode, &o.Value, &o.Uom, &o.File, &o.Line); err != nil {
+			return err
+		}
+		orphans = append(orphans, o)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(orphans) == 0 {
+		log.Printf("ORPHAN PRICES %s: none", src.Block)
+		return nil
+	}
+
+	path := filepath.Join(cfg.LogsDir, fmt.Sprintf("orphan_prices_%s_%s.csv", src.Block, processID))
+	if err := writeOrphanPrices(path, orphans); err != nil {
+		return err
+	}
+	log.Printf("ORPHAN PRICES %s: %d rows with a PCODE missing from fmaster | %s", src.Block, len(orphans), path)
+
+	if cfg.OrphanPriceMax >= 0 && len(orphans) > cfg.OrphanPriceMax {
+		return fmt.Errorf(
+			"%d prices have a PCODE missing from fmaster, above orphan_price_max %d (see %s)",
+			len(orphans), cfg.OrphanPriceMax, path,
+		)
+	}
+	return nil
+}
+
+func writeOrphanPrices(path string, orphans []orphanPrice) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"PCODE", "price_code", "price_value", "PRICE_UOM", "source_file", "line_no"})
+	for _, o := range orphans {
+		w.Write([]string{
+			o.Pcode.String, o.PriceCode, o.Value, o.Uom, o.File, strconv.Itoa(o.Line),
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
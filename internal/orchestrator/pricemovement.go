@@ -0,0 +1,132 @@
+package orchestrator
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"go-import-file/internal/config"
+)
+
+// priceMovement is one audited price change of a run.
+type priceMovement struct {
+	Table      string
+	Kodecabang string
+	Gharga     string
+	Custno     string
+	Pcode      string
+	Column     string
+	Old        sql.NullFloat64
+	New        sql.NullFloat64
+	File       string
+}
+
+// ChangePct is the relative change in percent; +Inf when a zero or empty
+// price got a value.
+func (m priceMovement) ChangePct() float64 {
+	if !m.Old.Valid || m.Old.Float64 == 0 {
+		if m.New.Valid && m.New.Float64 != 0 {
+			return math.Inf(1)
+		}
+		return 0
+	}
+	return (m.New.Float64 - m.Old.Float64) / m.Old.Float64 * 100
+}
+
+// writePriceMovement writes the price changes priceTable got in the run to
+// price_movement_<table>_<processID>.csv in the log dir, flagging those
+// that moved more than cfg.PriceMovementPct percent either way.
+func writePriceMovement(
+	ctx context.Context,
+	cfg *config.Config,
+	db *sql.DB,
+	processID string,
+	priceTable string,
+) error {
+	rows, err := db.QueryContext(ctx, `
+		SELECT
+			price_table, KODECABANG, ISNULL(GHARGA, ''), ISNULL(CUSTNO, ''), PCODE,
+			price_column, old_value, new_value, ISNULL(source_file, '')
+		FROM dbo.price_audit
+		WHERE process_id = @p1 AND price_table = @p2
+		ORDER BY KODECABANG, GHARGA, CUSTNO, PCODE, price_column`,
+		processID, priceTable,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var moves []priceMovement
+	for rows.Next() {
+		var m priceMovement
+		if err := rows.Scan(
+			&m.Table, &m.Kodecabang, &m.Gharga, &m.Custno, &m.Pcode,
+			&m.Column, &m.Old, &m.New, &m.File,
+		); err != nil {
+			return err
+		}
+		moves = append(moves, m)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(moves) == 0 {
+		log.Printf("PRICE MOVEMENT %s: no price changes", priceTable)
+		return nil
+	}
+
+	path := filepath.Join(cfg.LogsDir, fmt.Sprintf("price_movement_%s_%s.csv", priceTable, processID))
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := csv.NewWriter(f)
+	w.Write([]string{
+		"price_table", "KODECABANG", "GHARGA", "CUSTNO", "PCODE",
+		"price_column", "old_value", "new_value", "change_pct", "flagged", "source_file",
+	})
+
+	var flagged int
+	for _, m := range moves {
+		pct := m.ChangePct()
+		flag := math.Abs(pct) > cfg.PriceMovementPct
+		if flag {
+			flagged++
+		}
+
+		w.Write([]string{
+			m.Table, m.Kodecabang, m.Gharga, m.Custno, m.Pcode, m.Column,
+			nullFloat(m.Old), nullFloat(m.New),
+			strconv.FormatFloat(pct, 'f', 2, 64),
+			strconv.FormatBool(flag),
+			m.File,
+		})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return err
+	}
+
+	log.Printf(
+		"PRICE MOVEMENT %s: %d changes, %d above %g%% | %s",
+		priceTable, len(moves), flagged, cfg.PriceMovementPct, path,
+	)
+	return nil
+}
+
+func nullFloat(v sql.NullFloat64) string {
+	if !v.Valid {
+		return ""
+	}
+	return strconv.FormatFloat(v.Float64, 'f', -1, 64)
+}
@@ -3,6 +3,7 @@ package orchestrator
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"log"
 	"path/filepath"
@@ -14,6 +15,7 @@ import (
 	"go-import-file/internal/importer"
 	"go-import-file/internal/metrics"
 	"go-import-file/internal/model"
+	"go-import-file/internal/sink"
 	"go-import-file/internal/utils"
 	"go-import-file/internal/worker"
 )
@@ -22,14 +24,12 @@ var ErrNoSDealFiles = fmt.Errorf("No SDEAL files found!")
 
 func RunSalesDeal(
 	ctx context.Context,
-	dbConn *sql.DB,
-	filePath string,
+	cfg *config.Config,
+	s sink.Sink,
 	processID string,
 ) error {
 
-	cfg := config.Load()
-
-	files, err := filepath.Glob(filePath + "/SDEAL_*.txt")
+	files, err := filepath.Glob(cfg.FilePath + "/SDEAL_*.txt")
 	if err != nil || len(files) == 0 {
 		return err
 	}
@@ -90,6 +90,7 @@ func RunSalesDeal(
 
 		go worker.ParseWorker(
 			ctx,
+			cfg,
 			&parseWg,
 			jobs,
 			fileMetrics,
@@ -149,81 +150,82 @@ func RunSalesDeal(
 	// ======================
 	// Bulk Insert
 	// ======================
-	done120 := make(chan struct{})
-	go worker.Bulk120(ctx, dbConn, ch120, done120)
+	var loadErr error
+	done120 := make(chan error, 1)
+	go worker.Bulk120(ctx, cfg, s, ch120, done120)
 
-	done121 := make(chan struct{})
-	go worker.Bulk121(ctx, dbConn, ch121, done121)
+	done121 := make(chan error, 1)
+	go worker.Bulk121(ctx, cfg, s, ch121, done121)
 
-	done122 := make(chan struct{})
-	go worker.Bulk122(ctx, dbConn, ch122, done122)
+	done122 := make(chan error, 1)
+	go worker.Bulk122(ctx, cfg, s, ch122, done122)
 
-	done123 := make(chan struct{})
-	go worker.Bulk123(ctx, dbConn, ch123, done123)
+	done123 := make(chan error, 1)
+	go worker.Bulk123(ctx, cfg, s, ch123, done123)
 
-	done123Promo := make(chan struct{})
-	go worker.Bulk123Promo(ctx, dbConn, ch123Promo, done123Promo)
+	done123Promo := make(chan error, 1)
+	go worker.Bulk123Promo(ctx, cfg, s, ch123Promo, done123Promo)
 
-	done124 := make(chan struct{})
-	go worker.Bulk124(ctx, dbConn, ch124, done124)
+	done124 := make(chan error, 1)
+	go worker.Bulk124(ctx, cfg, s, ch124, done124)
 
-	done125 := make(chan struct{})
-	go worker.Bulk125(ctx, dbConn, ch125, done125)
+	done125 := make(chan error, 1)
+	go worker.Bulk125(ctx, cfg, s, ch125, done125)
 
-	done126 := make(chan struct{})
-	go worker.Bulk126(ctx, dbConn, ch126, done126)
+	done126 := make(chan error, 1)
+	go worker.Bulk126(ctx, cfg, s, ch126, done126)
 
-	done130 := make(chan struct{})
-	go worker.Bulk130(ctx, dbConn, ch130, done130)
+	done130 := make(chan error, 1)
+	go worker.Bulk130(ctx, cfg, s, ch130, done130)
 
-	done130Promo := make(chan struct{})
-	go worker.Bulk130Promo(ctx, dbConn, ch130Promo, done130Promo)
+	done130Promo := make(chan error, 1)
+	go worker.Bulk130Promo(ctx, cfg, s, ch130Promo, done130Promo)
 
-	done131 := make(chan struct{})
-	go worker.Bulk131(ctx, dbConn, ch131, done131)
+	done131 := make(chan error, 1)
+	go worker.Bulk131(ctx, cfg, s, ch131, done131)
 
-	done132 := make(chan struct{})
-	go worker.Bulk132(ctx, dbConn, ch132, done132)
+	done132 := make(chan error, 1)
+	go worker.Bulk132(ctx, cfg, s, ch132, done132)
 
 	// ======================
 	// Shutdown Order (CRITICAL)
 	// ======================
 	parseWg.Wait()
 	close(ch120)
-	<-done120
+	loadErr = errors.Join(loadErr, <-done120)
 
 	close(ch121)
-	<-done121
+	loadErr = errors.Join(loadErr, <-done121)
 
 	close(ch122)
-	<-done122
+	loadErr = errors.Join(loadErr, <-done122)
 
 	close(ch123)
-	<-done123
+	loadErr = errors.Join(loadErr, <-done123)
 
 	close(ch123Promo)
-	<-done123Promo
+	loadErr = errors.Join(loadErr, <-done123Promo)
 
 	close(ch124)
-	<-done124
+	loadErr = errors.Join(loadErr, <-done124)
 
 	close(ch125)
-	<-done125
+	loadErr = errors.Join(loadErr, <-done125)
 
 	close(ch126)
-	<-done126
+	loadErr = errors.Join(loadErr, <-done126)
 
 	close(ch130)
-	<-done130
+	loadErr = errors.Join(loadErr, <-done130)
 
 	close(ch130Promo)
-	<-done130Promo
+	loadErr = errors.Join(loadErr, <-done130Promo)
 
 	close(ch131)
-	<-done131
+	loadErr = errors.Join(loadErr, <-done131)
 
 	close(ch132)
-	<-done132
+	loadErr = errors.Join(loadErr, <-done132)
 
 	close(fileMetrics)
 	<-metricsDone
@@ -234,143 +236,74 @@ func RunSalesDeal(
 		atomic.LoadInt64(&metrics.InsertedRows),
 	)
 
-	return nil
+	return loadErr
+}
+
+// sdealTables are replaced by every SDEAL import (full refresh).
+var sdealTables = []string{
+	"dbo.DP_FG_CHECK",
+	"dbo.DP_ZDHDR",
+	"dbo.DP_ZDITM",
+	"dbo.DP_ZDDET",
+	"dbo.DP_ZPMIX",
+	"dbo.DP_ZSCREG",
+	"dbo.DP_ZSCMIX",
+	"dbo.FG_ZDHDR",
+	"dbo.FG_ZFRDET",
+	"dbo.FG_ZFRMIX",
 }
 
 func RunSalesDealTruncate(
 	ctx context.Context,
-	db *sql.DB,
+	s sink.Sink,
 	processID string,
 ) error {
+	return s.Truncate(ctx, sdealTables...)
+}
 
-	tx, err := db.BeginTx(ctx, &sql.TxOptions{
-		Isolation: sql.LevelSerializable,
-	})
-	if err != nil {
-		return err
-	}
+// SalesDealShadowSink writes the SDEAL tables to their shadow copies.
+func SalesDealShadowSink(s sink.Sink) sink.Sink {
+	return sink.Redirect(s, ShadowTables(sdealTables))
+}
 
-	defer func() {
-		if err != nil {
-			tx.Rollback()
-		}
-	}()
-
-	_, err = tx.ExecContext(
-		ctx,
-		`
-		TRUNCATE TABLE dbo.DP_FG_CHECK;
-		TRUNCATE TABLE dbo.DP_ZDHDR;
-		TRUNCATE TABLE dbo.DP_ZDITM;
-		TRUNCATE TABLE dbo.DP_ZDDET;
-		TRUNCATE TABLE dbo.DP_ZPMIX;
-		TRUNCATE TABLE dbo.DP_ZSCREG;
-		TRUNCATE TABLE dbo.DP_ZSCMIX;
-		TRUNCATE TABLE dbo.FG_ZDHDR;
-		TRUNCATE TABLE dbo.FG_ZFRDET;
-		TRUNCATE TABLE dbo.FG_ZFRMIX;
-		`,
-	)
+func RunSalesDealPrepareShadow(
+	ctx context.Context,
+	db *sql.DB,
+	resume bool,
+) error {
+	return PrepareShadow(ctx, db, sdealTables, resume)
+}
 
-	if err != nil {
-		return err
-	}
+// RunSalesDealSwapIdempotent validates the shadow tables and swaps them in
+// once per process, so a resumed run does not rotate a second time.
+func RunSalesDealSwapIdempotent(
+	ctx context.Context,
+	cfg *config.Config,
+	db *sql.DB,
+	processID string,
+) error {
+	return NewFinalizer(cfg, db).Run(ctx, "SDEAL_SWAP", processID, func(ctx context.Context, tx *sql.Tx) (int64, int64, error) {
+		if err := ValidateShadow(ctx, db, sdealTables); err != nil {
+			return 0, 0, err
+		}
+		return 0, 0, SwapShadow(ctx, tx, sdealTables)
+	})
+}
 
-	return tx.Commit()
+func RunSalesDealRollback(
+	ctx context.Context,
+	db *sql.DB,
+) error {
+	return RollbackSwap(ctx, db, sdealTables)
 }
 
 func RunSalesDealFinalizeIdempotent(
 	ctx context.Context,
+	cfg *config.Config,
 	db *sql.DB,
 	processID string,
 ) error {
-
-	const block = "SDEAL"
-
-	// =============================
-	// ACQUIRE FINALIZE LOCK
-	// =============================
-	tx, err := db.BeginTx(ctx, &sql.TxOptions{
-		Isolation: sql.LevelSerializable,
+	return NewFinalizer(cfg, db).Run(ctx, "SDEAL", processID, func(ctx context.Context, tx *sql.Tx) (int64, int64, error) {
+		return 0, 0, importer.RunSDealFromDummy(ctx, tx, processID)
 	})
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback()
-
-	var status string
-	err = tx.QueryRowContext(ctx, `
-		SELECT status
-		FROM import_finalize_log
-		WHERE process_id = @pid AND block_code = @blk
-	`, sql.Named("pid", processID), sql.Named("blk", block)).Scan(&status)
-
-	if err == nil {
-		switch status {
-		case "DONE":
-			log.Println("MPRICE FINALIZE already DONE, skip")
-			return nil
-		case "RUNNING":
-			return fmt.Errorf("MPRICE FINALIZE already RUNNING")
-		}
-	} else if err != sql.ErrNoRows {
-		return err
-	}
-
-	_, err = tx.ExecContext(ctx, `
-		MERGE import_finalize_log AS t
-		USING (SELECT @pid AS pid, @blk AS blk) s
-		ON t.process_id = s.pid AND t.block_code = s.blk
-		WHEN MATCHED THEN
-			UPDATE SET status='RUNNING',
-				started_at=SYSDATETIME(),
-				finished_at=NULL,
-				error_message=NULL
-		WHEN NOT MATCHED THEN
-			INSERT (process_id, block_code, status, started_at)
-			VALUES (@pid, @blk, 'RUNNING', SYSDATETIME());
-	`,
-		sql.Named("pid", processID),
-		sql.Named("blk", block),
-	)
-	if err != nil {
-		return err
-	}
-
-	if err := tx.Commit(); err != nil {
-		return err
-	}
-
-	// =============================
-	// ACTUAL FINALIZE (YOUR FUNCTION)
-	// =============================
-	if err := importer.RunSDealFromDummy(ctx, db, processID); err != nil {
-		_, _ = db.ExecContext(ctx, `
-			UPDATE import_finalize_log
-			SET status='FAILED',
-				finished_at=SYSDATETIME(),
-				error_message=@err
-			WHERE process_id=@pid AND block_code=@blk
-		`,
-			sql.Named("err", err.Error()),
-			sql.Named("pid", processID),
-			sql.Named("blk", block),
-		)
-		return err
-	}
-
-	// =============================
-	// MARK DONE
-	// =============================
-	_, err = db.ExecContext(ctx, `
-		UPDATE import_finalize_log
-		SET status='DONE',
-			finished_at=SYSDATETIME()
-		WHERE process_id=@pid AND block_code=@blk
-	`,
-		sql.Named("pid", processID),
-		sql.Named("blk", block),
-	)
-
-	return err
 }
@@ -0,0 +1,213 @@
+package orchestrator
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+)
+
+// Full-refresh blocks load into shadow.<table> while dbo.<table> keeps
+// serving the last good data. After validation the generations rotate in
+// one transaction with ALTER SCHEMA TRANSFER:
+//
+//	dbo      -> previous (kept for rollback)
+//	shadow   -> dbo
+//	previous -> shadow   (recycled, emptied by the next prepare)
+//
+// The shadow and previous tables are declared by migration 0012. All of
+// this is SQL Server only.
+const (
+	ShadowSchema   = "shadow"
+	PreviousSchema = "previous"
+)
+
+func bareTable(table string) string {
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		return table[i+1:]
+	}
+	return table
+}
+
+// ShadowTables maps each live table to its shadow copy.
+func ShadowTables(tables []string) map[string]string {
+	out := make(map[string]string, len(tables))
+	for _, t := range tables {
+		out[t] = ShadowSchema + "." + bareTable(t)
+	}
+	return out
+}
+
+// PrepareShadow empties the shadow copy of every table. The shadow and
+// previous tables are declared by the migrations like their dbo tables,
+// keys and indexes included; a missing one means migrate up has not run.
+// keep leaves existing shadow rows alone, which a resumed run needs.
+func PrepareShadow(ctx context.Context, db *sql.DB, tables []string, keep bool) error {
+	for _, t := range tables {
+		shadow := ShadowSchema + "." + bareTable(t)
+
+		var exists bool
+		err := db.QueryRowContext(ctx,
+			`SELECT CASE WHEN OBJECT_ID(@p1, N'U') IS NULL THEN 0 ELSE 1 END`,
+			shadow,
+		).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("prepare %s: %w", shadow, err)
+		}
+		if !exists {
+			return fmt.Errorf("%s does not exist, run migrate up", shadow)
+		}
+
+		if keep {
+			continue
+		}
+		if _, err := db.ExecContext(ctx, "TRUNCATE TABLE "+shadow); err != nil {
+			return fmt.Errorf("prepare %s: %w", shadow, err)
+		}
+	}
+
+	log.Printf("Shadow tables ready (%d)", len(tables))
+	return nil
+}
+
+// ValidateShadow refuses a swap when the new generation is empty, which
+// would wipe the live data. A failed load already failed IMPORT SDEAL, so
+// the swap is never reached.
+func ValidateShadow(ctx context.Context, db *sql.DB, tables []string) error {
+	var total int64
+	for _, t := range tables {
+		name := bareTable(t)
+
+		var shadowRows, liveRows int64
+		err := db.QueryRowContext(ctx, fmt.Sprintf(
+			`SELECT (SELECT COUNT_BIG(*) FROM %s.%s), (SELECT COUNT_BIG(*) FROM dbo.%s)`,
+			ShadowSchema, name, name,
+		)).Scan(&shadowRows, &liveRows)
+		if err != nil {
+			return fmt.Errorf("count %s: %w", name, err)
+		}
+
+		log.Printf("SWAP CHECK %-12s shadow=%d live=%d", name, shadowRows, liveRows)
+		total += shadowRows
+	}
+
+	if total == 0 {
+		return fmt.Errorf("all shadow tables are empty, keeping the live tables")
+	}
+	return nil
+}
+
+// keepGrants wraps a batch that replaces dbo.<name> with another table.
+// ALTER SCHEMA TRANSFER drops every permission granted on a table, so the
+// grants and denies on the outgoing dbo table are read first and applied to
+// the table that takes its place. Schema-wide grants on dbo are not touched.
+func keepGrants(name, transfer string) string {
+	return fmt.Sprintf(`
+		DECLARE @grants nvarchar(max) = (
+			SELECT CASE p.state WHEN 'D' THEN N'DENY ' ELSE N'GRANT ' END
+				+ p.permission_name + N' ON dbo.%[1]s'
+				+ CASE WHEN p.minor_id <> 0 THEN N' (' + QUOTENAME(COL_NAME(p.major_id, p.minor_id)) + N')' ELSE N'' END
+				+ N' TO ' + QUOTENAME(pr.name)
+				+ CASE p.state WHEN 'W' THEN N' WITH GRANT OPTION' ELSE N'' END
+				+ N'; '
+			FROM sys.database_permissions p
+			JOIN sys.database_principals pr ON pr.principal_id = p.grantee_principal_id
+			WHERE p.class = 1 AND p.major_id = OBJECT_ID(N'dbo.%[1]s')
+			FOR XML PATH(''), TYPE
+		).value('.', 'nvarchar(max)');
+%[2]s
+
+		IF @grants IS NOT NULL
+			EXEC sp_executesql @grants;`,
+		name, transfer,
+	)
+}
+
+// SwapShadow rotates shadow -> dbo -> previous for all tables inside tx,
+// so once it commits readers see either the old or the new generation.
+func SwapShadow(ctx context.Context, tx *sql.Tx, tables []string) error {
+	if _, err := tx.ExecContext(ctx, `SET XACT_ABORT ON;`); err != nil {
+		return err
+	}
+
+	for _, t := range tables {
+		name := bareTable(t)
+
+		q := keepGrants(name, fmt.Sprintf(`
+			IF OBJECT_ID(N'%[2]s.%[1]s', N'U') IS NOT NULL
+				EXEC sp_rename N'%[2]s.%[1]s', N'%[1]s__recycle';
+
+			ALTER SCHEMA %[2]s TRANSFER dbo.%[1]s;
+			ALTER SCHEMA dbo TRANSFER %[3]s.%[1]s;
+
+			IF OBJECT_ID(N'%[2]s.%[1]s__recycle', N'U') IS NOT NULL
+			BEGIN
+				EXEC(N'ALTER SCHEMA %[3]s TRANSFER %[2]s.%[1]s__recycle');
+				EXEC sp_rename N'%[3]s.%[1]s__recycle', N'%[1]s';
+			END`,
+			name, PreviousSchema, ShadowSchema,
+		))
+
+		if _, err := tx.ExecContext(ctx, q); err != nil {
+			return fmt.Errorf("swap %s: %w", name, err)
+		}
+	}
+
+	log.Printf("Swapping %d tables into dbo, previous generation kept in %s", len(tables), PreviousSchema)
+	return nil
+}
+
+// RollbackSwap puts the previous generation back into dbo and moves the
+// current one to shadow for inspection.
+func RollbackSwap(ctx context.Context, db *sql.DB, tables []string) error {
+	for _, t := range tables {
+		name := bareTable(t)
+
+		var exists bool
+		err := db.QueryRowContext(ctx,
+			`SELECT CASE WHEN OBJECT_ID(@p1, N'U') IS NULL THEN 0 ELSE 1 END`,
+			PreviousSchema+"."+name,
+		).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("no previous generation of %s, nothing to roll back", name)
+		}
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `SET XACT_ABORT ON;`); err != nil {
+		return err
+	}
+
+	for _, t := range tables {
+		name := bareTable(t)
+
+		q := keepGrants(name, fmt.Sprintf(`
+			IF OBJECT_ID(N'%[3]s.%[1]s', N'U') IS NOT NULL
+				DROP TABLE %[3]s.%[1]s;
+
+			ALTER SCHEMA %[3]s TRANSFER dbo.%[1]s;
+			ALTER SCHEMA dbo TRANSFER %[2]s.%[1]s;`,
+			name, PreviousSchema, ShadowSchema,
+		))
+
+		if _, err := tx.ExecContext(ctx, q); err != nil {
+			return fmt.Errorf("rollback %s: %w", name, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	log.Printf("Rolled back %d tables to the previous generation", len(tables))
+	return nil
+}
@@ -0,0 +1,116 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"runtime"
+	"sync/atomic"
+	"time"
+
+	"go-import-file/internal/metrics"
+)
+
+var (
+	// ErrStalled stops a step that made no progress for idle_timeout_seconds.
+	ErrStalled = errors.New("pipeline stalled")
+
+	// ErrStepTimeout stops a step that ran past its deadline.
+	ErrStepTimeout = errors.New("step deadline exceeded")
+)
+
+// progress is what the watchdog watches: lines read and rows written.
+type progress struct {
+	lines    int64
+	inserted int64
+}
+
+func currentProgress() progress {
+	return progress{
+		lines:    atomic.LoadInt64(&metrics.ProcessedLines),
+		inserted: atomic.LoadInt64(&metrics.InsertedRows),
+	}
+}
+
+// parsePhase reports whether the step is still reading files: no parse
+// worker started yet, or one started since the watch began is still
+// running. Workers left running by an abandoned earlier step are in the
+// baseline and do not count.
+type parsePhase struct {
+	started, running int64
+}
+
+func beginParsePhase() parsePhase {
+	return parsePhase{
+		started: atomic.LoadInt64(&metrics.ParseWorkersStarted),
+		running: atomic.LoadInt64(&metrics.ParseWorkersRunning),
+	}
+}
+
+func (p parsePhase) over() bool {
+	return atomic.LoadInt64(&metrics.ParseWorkersStarted) > p.started &&
+		atomic.LoadInt64(&metrics.ParseWorkersRunning) <= p.running
+}
+
+// watch cancels ctx with ErrStalled once neither ProcessedLines nor
+// InsertedRows moved for idle while the step reads its files, after
+// dumping every goroutine to the log. Once the files are read it stops:
+// what is left is the MERGE, finalize and commit work of the writers,
+// whose statements report no progress while they run and are bounded by
+// the step deadline instead. The returned func stops watching.
+func watch(ctx context.Context, cancel context.CancelCauseFunc, step string, idle time.Duration) func() {
+	done := make(chan struct{})
+	phase := beginParsePhase()
+
+	go func() {
+		tick := time.NewTicker(min(max(idle/10, time.Second), 30*time.Second))
+		defer tick.Stop()
+
+		last, since := currentProgress(), time.Now()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-tick.C:
+			}
+
+			if phase.over() {
+				log.Printf("WATCHDOG %s: files read, writing is left to the step deadline", step)
+				return
+			}
+			if p := currentProgress(); p != last {
+				last, since = p, time.Now()
+				continue
+			}
+			if time.Since(since) < idle {
+				continue
+			}
+
+			log.Printf("WATCHDOG %s: no progress for %s (lines=%d inserted=%d), cancelling",
+				step, idle, last.lines, last.inserted)
+			logGoroutines()
+			cancel(fmt.Errorf("%w: %s made no progress for %s", ErrStalled, step, idle))
+			return
+		}
+	}()
+
+	return func() { close(done) }
+}
+
+// logGoroutines writes the stack of every goroutine to the log, to see
+// which channel or query the pipeline is stuck on.
+func logGoroutines() {
+	buf := make([]byte, 1<<20)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+	log.Printf("WATCHDOG goroutines:\n%s", buf)
+}
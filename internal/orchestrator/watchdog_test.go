@@ -0,0 +1,72 @@
+package orchestrator
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go-import-file/internal/metrics"
+)
+
+// blocked is a step that waits until it is stopped.
+func blocked(ctx context.Context) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(10 * time.Second):
+		return errors.New("step was not stopped")
+	}
+}
+
+func TestWatchCancelsStalledStep(t *testing.T) {
+	c := &ImportChain{Idle: 100 * time.Millisecond}
+	c.AddWatched("IMPORT T", blocked)
+
+	err := c.Run(context.Background())
+	if !errors.Is(err, ErrStalled) {
+		t.Fatalf("err = %v, want %v", err, ErrStalled)
+	}
+}
+
+func TestWatchKeepsStepThatProgresses(t *testing.T) {
+	c := &ImportChain{Idle: 500 * time.Millisecond}
+	c.AddWatched("IMPORT T", func(ctx context.Context) error {
+		for range 15 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(100 * time.Millisecond):
+			}
+			atomic.AddInt64(&metrics.ProcessedLines, 1)
+		}
+		return nil
+	})
+
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatalf("err = %v, want the step to finish", err)
+	}
+}
+
+func TestUnwatchedStepIsNotStalled(t *testing.T) {
+	c := &ImportChain{Idle: 100 * time.Millisecond}
+	c.Add("FINALIZE T", func(ctx context.Context) error {
+		time.Sleep(1500 * time.Millisecond)
+		return ctx.Err()
+	})
+
+	if err := c.Run(context.Background()); err != nil {
+		t.Fatalf("err = %v, want the step to finish", err)
+	}
+}
+
+func TestStepDeadline(t *testing.T) {
+	c := &ImportChain{Timeout: func(string) time.Duration { return 100 * time.Millisecond }}
+	c.Add("FINALIZE T", blocked)
+
+	err := c.Run(context.Background())
+	if !errors.Is(err, ErrStepTimeout) {
+		t.Fatalf("err = %v, want %v", err, ErrStepTimeout)
+	}
+}
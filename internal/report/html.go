@@ -0,0 +1,96 @@
+package report
+
+import (
+	"html/template"
+	"time"
+)
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"ms": func(v int64) string {
+		return (time.Duration(v) * time.Millisecond).String()
+	},
+	"ts": func(t time.Time) string {
+		return t.Format("2006-01-02 15:04:05")
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Import report {{.ProcessID}}</title>
+<style>
+body { font-family: Arial, sans-serif; margin: 24px; color: #222; }
+h1 { font-size: 20px; }
+h2 { font-size: 17px; margin-top: 32px; border-bottom: 1px solid #ccc; }
+h3 { font-size: 14px; margin-top: 20px; }
+table { border-collapse: collapse; margin: 8px 0; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; font-size: 13px; text-align: left; }
+th { background: #f0f0f0; }
+td.num { text-align: right; }
+.SUCCESS, .DONE { color: #1a7f37; font-weight: bold; }
+.FAILED { color: #cf222e; font-weight: bold; }
+.CANCELLED { color: #9a6700; font-weight: bold; }
+.SKIPPED { color: #9a6700; font-weight: bold; }
+pre { margin: 0; white-space: pre-wrap; font-size: 12px; }
+</style>
+</head>
+<body>
+<h1>Import report</h1>
+<table>
+{{if .Profile}}<tr><th>Profile</th><td>{{.Profile}}</td></tr>{{end}}
+<tr><th>Process ID</th><td>{{.ProcessID}}</td></tr>
+<tr><th>Status</th><td class="{{.Status}}">{{.Status}}</td></tr>
+{{if .Error}}<tr><th>Error</th><td>{{.Error}}</td></tr>{{end}}
+<tr><th>Started</th><td>{{ts .StartedAt}}</td></tr>
+<tr><th>Finished</th><td>{{ts .FinishedAt}}</td></tr>
+<tr><th>Duration</th><td>{{ms .DurationMs}}</td></tr>
+<tr><th>Lines / Parsed / Rejected</th><td>{{.Totals.Lines}} / {{.Totals.Parsed}} / {{.Totals.Rejected}}</td></tr>
+<tr><th>Inserted / Updated / Unchanged</th><td>{{.Totals.Inserted}} / {{.Totals.Updated}} / {{.Totals.Unchanged}}</td></tr>
+</table>
+
+{{range .Blocks}}
+<h2>Block {{.Block}}</h2>
+
+{{if .Files}}
+<h3>Files</h3>
+<table>
+<tr><th>File</th><th>Status</th><th>Lines</th><th>Parsed</th><th>Rejected</th><th>Duration</th></tr>
+{{range .Files}}<tr><td>{{.File}}</td><td class="{{.Status}}">{{.Status}}</td><td class="num">{{.Lines}}</td><td class="num">{{.Parsed}}</td><td class="num">{{.Rejected}}</td><td>{{ms .DurationMs}}</td></tr>
+{{end}}</table>
+{{end}}
+
+{{if .Tables}}
+<h3>Tables</h3>
+<table>
+<tr><th>Table</th><th>Rows</th><th>Inserted</th><th>Updated</th><th>Unchanged</th><th>Retired</th><th>Duration</th><th>Error</th></tr>
+{{range .Tables}}<tr><td>{{.Table}}</td><td class="num">{{.Rows}}</td><td class="num">{{.Inserted}}</td><td class="num">{{.Updated}}</td><td class="num">{{.Unchanged}}</td><td class="num">{{.Retired}}</td><td>{{ms .DurationMs}}</td><td>{{.Error}}</td></tr>
+{{end}}</table>
+{{end}}
+
+{{if .Finalize}}
+<h3>Finalize</h3>
+<table>
+<tr><th>Name</th><th>Status</th><th>Inserted</th><th>Updated</th><th>Duration</th><th>Error</th></tr>
+{{range .Finalize}}<tr><td>{{.Name}}</td><td class="{{.Status}}">{{.Status}}</td><td class="num">{{.Inserted}}</td><td class="num">{{.Updated}}</td><td>{{ms .DurationMs}}</td><td>{{.Error}}</td></tr>
+{{end}}</table>
+{{end}}
+
+{{if .Steps}}
+<h3>Steps</h3>
+<table>
+<tr><th>Step</th><th>Duration</th><th>Error</th></tr>
+{{range .Steps}}<tr><td>{{.Name}}</td><td>{{ms .DurationMs}}</td><td>{{.Error}}</td></tr>
+{{end}}</table>
+{{end}}
+
+{{if .Rejects}}
+<h3>Top reject reasons</h3>
+<table>
+<tr><th>Reason</th><th>Count</th><th>Samples</th></tr>
+{{range .Rejects}}<tr><td>{{.Reason}}</td><td class="num">{{.Count}}</td><td>{{range .Samples}}<pre>{{.File}}:{{.Line}}  {{.Raw}}{{if .Detail}}
+{{.Detail}}{{end}}</pre>{{end}}</td></tr>
+{{end}}</table>
+{{end}}
+{{end}}
+</body>
+</html>
+`))
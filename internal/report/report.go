@@ -0,0 +1,244 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go-import-file/internal/metrics"
+)
+
+type Report struct {
+	Profile    string        `json:"profile,omitempty"`
+	ProcessID  string        `json:"process_id"`
+	Status     string        `json:"status"`
+	Error      string        `json:"error,omitempty"`
+	StartedAt  time.Time     `json:"started_at"`
+	FinishedAt time.Time     `json:"finished_at"`
+	DurationMs int64         `json:"duration_ms"`
+	Totals     Totals        `json:"totals"`
+	Blocks     []BlockReport `json:"blocks"`
+}
+
+type Totals struct {
+	Lines     int64 `json:"lines"`
+	Parsed    int64 `json:"parsed"`
+	Rejected  int64 `json:"rejected"`
+	Inserted  int64 `json:"inserted"`
+	Updated   int64 `json:"updated"`
+	Unchanged int64 `json:"unchanged"`
+}
+
+type BlockReport struct {
+	Block    string           `json:"block"`
+	Totals   Totals           `json:"totals"`
+	Files    []FileReport     `json:"files"`
+	Tables   []TableReport    `json:"tables"`
+	Steps    []StepReport     `json:"steps"`
+	Finalize []FinalizeReport `json:"finalize"`
+	Rejects  []RejectReport   `json:"top_rejects"`
+}
+
+type FileReport struct {
+	File       string `json:"file"`
+	Status     string `json:"status"`
+	Lines      int64  `json:"lines"`
+	Parsed     int64  `json:"parsed"`
+	Rejected   int64  `json:"rejected"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+type TableReport struct {
+	Table      string `json:"table"`
+	Rows       int64  `json:"rows"`
+	Inserted   int64  `json:"inserted"`
+	Updated    int64  `json:"updated"`
+	Unchanged  int64  `json:"unchanged"`
+	Retired    int64  `json:"retired,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+type StepReport struct {
+	Name       string `json:"name"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+type FinalizeReport struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Inserted   int64  `json:"inserted"`
+	Updated    int64  `json:"updated"`
+	DurationMs int64  `json:"duration_ms"`
+	Error      string `json:"error,omitempty"`
+}
+
+type RejectReport struct {
+	Reason  string         `json:"reason"`
+	Count   int64          `json:"count"`
+	Samples []SampleReport `json:"samples"`
+}
+
+type SampleReport struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Detail string `json:"detail,omitempty"`
+	Raw    string `json:"raw"`
+}
+
+// maxRejectReasons limits the reject section to the most frequent reasons.
+const maxRejectReasons = 10
+
+// Build turns a metrics snapshot into a report. runErr is the error the
+// import chain returned, if any; a table that recorded an error fails the
+// report as well.
+func Build(snap metrics.RunSnapshot, runErr error) Report {
+	finished := time.Now()
+
+	r := Report{
+		ProcessID:  snap.ProcessID,
+		Status:     "SUCCESS",
+		StartedAt:  snap.StartedAt,
+		FinishedAt: finished,
+		DurationMs: finished.Sub(snap.StartedAt).Milliseconds(),
+	}
+	if runErr != nil {
+		r.Status = "FAILED"
+		r.Error = runErr.Error()
+	}
+	if errors.Is(runErr, context.Canceled) {
+		r.Status = "CANCELLED"
+	}
+
+	for _, b := range snap.Blocks {
+		br := BlockReport{Block: b.Block}
+
+		for _, f := range b.Files {
+			br.Files = append(br.Files, FileReport{
+				File:       f.FileName,
+				Status:     f.Status,
+				Lines:      f.TotalLines,
+				Parsed:     f.ParsedRows,
+				Rejected:   f.ErrorCount,
+				DurationMs: f.Duration.Milliseconds(),
+			})
+			br.Totals.Lines += f.TotalLines
+			br.Totals.Parsed += f.ParsedRows
+			br.Totals.Rejected += f.ErrorCount
+		}
+
+		for _, t := range b.Tables {
+			br.Tables = append(br.Tables, TableReport{
+				Table:      t.Table,
+				Rows:       t.Rows,
+				Inserted:   t.Inserted,
+				Updated:    t.Updated,
+				Unchanged:  t.Unchanged,
+				Retired:    t.Retired,
+				DurationMs: t.Duration.Milliseconds(),
+				Error:      t.Error,
+			})
+			br.Totals.Inserted += t.Inserted
+			br.Totals.Updated += t.Updated
+			br.Totals.Unchanged += t.Unchanged
+		}
+
+		for _, s := range b.Steps {
+			br.Steps = append(br.Steps, StepReport{
+				Name:       s.Name,
+				DurationMs: s.Duration.Milliseconds(),
+				Error:      s.Error,
+			})
+		}
+
+		for _, f := range b.Finalize {
+			br.Finalize = append(br.Finalize, FinalizeReport{
+				Name:       f.Name,
+				Status:     f.Status,
+				Inserted:   f.Inserted,
+				Updated:    f.Updated,
+				DurationMs: f.Duration.Milliseconds(),
+				Error:      f.Error,
+			})
+		}
+
+		for i, rr := range b.Rejects {
+			if i == maxRejectReasons {
+				break
+			}
+			rep := RejectReport{Reason: rr.Reason, Count: rr.Count}
+			for _, s := range rr.Samples {
+				rep.Samples = append(rep.Samples, SampleReport{
+					File:   s.File,
+					Line:   s.Line,
+					Detail: s.Detail,
+					Raw:    s.Raw,
+				})
+			}
+			br.Rejects = append(br.Rejects, rep)
+		}
+
+		r.Totals.Lines += br.Totals.Lines
+		r.Totals.Parsed += br.Totals.Parsed
+		r.Totals.Rejected += br.Totals.Rejected
+		r.Totals.Inserted += br.Totals.Inserted
+		r.Totals.Updated += br.Totals.Updated
+		r.Totals.Unchanged += br.Totals.Unchanged
+
+		r.Blocks = append(r.Blocks, br)
+	}
+
+	// A table that failed to load fails the run, also when the chain
+	// itself reported no error.
+	if runErr == nil {
+	tables:
+		for _, b := range r.Blocks {
+			for _, t := range b.Tables {
+				if t.Error != "" {
+					r.Status = "FAILED"
+					r.Error = fmt.Sprintf("block %s table %s: %s", b.Block, t.Table, t.Error)
+					break tables
+				}
+			}
+		}
+	}
+
+	return r
+}
+
+// Write stores the report as report_<processID>.json and .html in dir and
+// returns both paths.
+func Write(dir string, r Report) (string, string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", "", err
+	}
+
+	base := filepath.Join(dir, "report_"+r.ProcessID)
+	jsonPath := base + ".json"
+	htmlPath := base + ".html"
+
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return "", "", fmt.Errorf("marshal report: %w", err)
+	}
+	if err := os.WriteFile(jsonPath, data, 0644); err != nil {
+		return "", "", err
+	}
+
+	f, err := os.Create(htmlPath)
+	if err != nil {
+		return jsonPath, "", err
+	}
+	defer f.Close()
+
+	if err := htmlTemplate.Execute(f, r); err != nil {
+		return jsonPath, "", fmt.Errorf("render html report: %w", err)
+	}
+
+	return jsonPath, htmlPath, nil
+}
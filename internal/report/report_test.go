@@ -0,0 +1,217 @@
+package report
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"go-import-file/internal/metrics"
+)
+
+// snapshot is a run of block SLSINV: one file with a reject, one table
+// and a finalize step.
+func snapshot(tableErr string) metrics.RunSnapshot {
+	return metrics.RunSnapshot{
+		ProcessID: "p1",
+		StartedAt: time.Now().Add(-time.Minute),
+		Blocks: []metrics.BlockStats{{
+			Block: "SLSINV",
+			Files: []metrics.FileMetric{
+				{FileName: "A_SLSINV.txt", Status: "SUCCESS", TotalLines: 10, ParsedRows: 9, ErrorCount: 1, Duration: 2 * time.Second},
+			},
+			Tables: []metrics.TableStats{
+				{Table: "dbo.sap_web_inv_sfa", Rows: 9, Inserted: 5, Updated: 3, Unchanged: 1, Error: tableErr},
+			},
+			Steps: []metrics.StepStats{{Name: "IMPORT SLSINV", Duration: 3 * time.Second}},
+			Finalize: []metrics.FinalizeStats{
+				{Name: "SLSINV", Status: "DONE", Inserted: 2},
+			},
+			Rejects: []metrics.RejectReason{{
+				Reason: "field 3: invalid date",
+				Count:  1,
+				Samples: []metrics.RejectSample{
+					{File: "A_SLSINV.txt", Line: 4, Detail: `field 3: invalid date "2026"`, Raw: "SO2|<script>|2026"},
+				},
+			}},
+		}},
+	}
+}
+
+func TestBuildStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		tableErr   string
+		runErr     error
+		wantStatus string
+		wantError  string
+	}{
+		{"success", "", nil, "SUCCESS", ""},
+		{"chain error", "", errors.New("IMPORT SLSINV: boom"), "FAILED", "IMPORT SLSINV: boom"},
+		{"cancelled", "", fmt.Errorf("IMPORT SLSINV: %w", context.Canceled), "CANCELLED", "IMPORT SLSINV: context canceled"},
+		{"table error", "deadlock", nil, "FAILED", "block SLSINV table dbo.sap_web_inv_sfa: deadlock"},
+		{"chain error wins", "deadlock", errors.New("IMPORT SLSINV: boom"), "FAILED", "IMPORT SLSINV: boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := Build(snapshot(tt.tableErr), tt.runErr)
+			if r.Status != tt.wantStatus || r.Error != tt.wantError {
+				t.Errorf("status %s error %q, want %s %q", r.Status, r.Error, tt.wantStatus, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestBuildTotals(t *testing.T) {
+	r := Build(snapshot(""), nil)
+
+	want := Totals{Lines: 10, Parsed: 9, Rejected: 1, Inserted: 5, Updated: 3, Unchanged: 1}
+	if r.Totals != want {
+		t.Errorf("totals = %+v, want %+v", r.Totals, want)
+	}
+	if len(r.Blocks) != 1 || r.Blocks[0].Totals != want {
+		t.Errorf("blocks = %+v, want SLSINV with the same totals", r.Blocks)
+	}
+	if r.ProcessID != "p1" || r.DurationMs < time.Minute.Milliseconds() {
+		t.Errorf("process %s duration %dms", r.ProcessID, r.DurationMs)
+	}
+}
+
+func TestBuildKeepsMostFrequentRejects(t *testing.T) {
+	snap := snapshot("")
+	snap.Blocks[0].Rejects = nil
+	for i := range maxRejectReasons + 2 {
+		snap.Blocks[0].Rejects = append(snap.Blocks[0].Rejects, metrics.RejectReason{
+			Reason: fmt.Sprintf("reason %02d", i),
+			Count:  int64(100 - i),
+		})
+	}
+
+	rejects := Build(snap, nil).Blocks[0].Rejects
+	if len(rejects) != maxRejectReasons {
+		t.Fatalf("%d reject reasons, want %d", len(rejects), maxRejectReasons)
+	}
+	if rejects[0].Reason != "reason 00" || rejects[len(rejects)-1].Reason != "reason 09" {
+		t.Errorf("rejects run from %s to %s", rejects[0].Reason, rejects[len(rejects)-1].Reason)
+	}
+}
+
+func TestWriteJSONAndHTML(t *testing.T) {
+	r := Build(snapshot("deadlock"), nil)
+	r.Profile = "jakarta"
+
+	jsonPath, htmlPath, err := Write(t.TempDir(), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(jsonPath, "report_p1.json") || !strings.HasSuffix(htmlPath, "report_p1.html") {
+		t.Errorf("paths %s, %s", jsonPath, htmlPath)
+	}
+
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Report
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Profile != "jakarta" || got.Status != "FAILED" || got.Totals != r.Totals {
+		t.Errorf("json = %+v", got)
+	}
+	if tbl := got.Blocks[0].Tables[0]; tbl.Error != "deadlock" || tbl.Updated != 3 {
+		t.Errorf("json table = %+v", tbl)
+	}
+	if rr := got.Blocks[0].Rejects[0]; rr.Reason != "field 3: invalid date" || rr.Samples[0].Line != 4 {
+		t.Errorf("json rejects = %+v", rr)
+	}
+	for _, key := range []string{`"top_rejects"`, `"process_id": "p1"`, `"unchanged": 1`} {
+		if !strings.Contains(string(data), key) {
+			t.Errorf("json has no %s", key)
+		}
+	}
+
+	page, err := os.ReadFile(htmlPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	html := string(page)
+	for _, want := range []string{
+		"<title>Import report p1</title>",
+		`<td class="FAILED">FAILED</td>`,
+		"block SLSINV table dbo.sap_web_inv_sfa: deadlock",
+		`<td class="DONE">DONE</td>`,
+		"A_SLSINV.txt:4  SO2|&lt;script&gt;|2026",
+	} {
+		if !strings.Contains(html, want) {
+			t.Errorf("html has no %s", want)
+		}
+	}
+	if strings.Contains(html, "<script>") {
+		t.Error("html does not escape the raw line")
+	}
+}
+
+func TestSummary(t *testing.T) {
+	ok := Report{ProcessID: "p1", Status: "SUCCESS", Totals: Totals{Lines: 10, Inserted: 4}}
+	failed := Report{ProcessID: "p2", Status: "FAILED", Error: "boom", Totals: Totals{Lines: 5, Rejected: 5}}
+	cancelled := Report{ProcessID: "p3", Status: "CANCELLED", Totals: Totals{Lines: 1}}
+
+	tests := []struct {
+		name              string
+		reports           []Report
+		status            string
+		failed, cancelled int
+	}{
+		{"all succeed", []Report{ok, ok}, "SUCCESS", 0, 0},
+		{"a failure fails the run", []Report{ok, failed, cancelled}, "FAILED", 1, 1},
+		{"cancelled without failure", []Report{ok, cancelled}, "CANCELLED", 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSummary("ALL")
+			for i, r := range tt.reports {
+				s.Add(fmt.Sprintf("profile%d", i), r)
+			}
+			s.Finish()
+
+			if s.Status != tt.status || s.Failed != tt.failed || s.Cancelled != tt.cancelled {
+				t.Errorf("status %s failed %d cancelled %d, want %s %d %d",
+					s.Status, s.Failed, s.Cancelled, tt.status, tt.failed, tt.cancelled)
+			}
+			var lines int64
+			for _, r := range tt.reports {
+				lines += r.Totals.Lines
+			}
+			if s.Totals.Lines != lines || len(s.Profiles) != len(tt.reports) {
+				t.Errorf("lines %d over %d profiles, want %d over %d", s.Totals.Lines, len(s.Profiles), lines, len(tt.reports))
+			}
+		})
+	}
+}
+
+func TestWriteSummary(t *testing.T) {
+	s := NewSummary("SLSINV")
+	s.Add("jakarta", Report{ProcessID: "p1", Status: "FAILED", Error: "boom"})
+	s.Finish()
+
+	path, err := WriteSummary(t.TempDir(), s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got Summary
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != "FAILED" || got.Failed != 1 || got.Profiles[0].Error != "boom" || got.Profiles[0].Profile != "jakarta" {
+		t.Errorf("summary = %+v", got)
+	}
+}
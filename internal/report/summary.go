@@ -0,0 +1,91 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Summary combines the reports of several profile runs started by one
+// invocation (-profile=ALL).
+type Summary struct {
+	Block      string           `json:"block"`
+	Status     string           `json:"status"`
+	StartedAt  time.Time        `json:"started_at"`
+	FinishedAt time.Time        `json:"finished_at"`
+	DurationMs int64            `json:"duration_ms"`
+	Failed     int              `json:"failed"`
+	Cancelled  int              `json:"cancelled"`
+	Totals     Totals           `json:"totals"`
+	Profiles   []ProfileSummary `json:"profiles"`
+}
+
+type ProfileSummary struct {
+	Profile    string `json:"profile"`
+	ProcessID  string `json:"process_id"`
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+	Totals     Totals `json:"totals"`
+}
+
+func NewSummary(block string) *Summary {
+	return &Summary{Block: block, StartedAt: time.Now()}
+}
+
+func (s *Summary) Add(profile string, r Report) {
+	s.Profiles = append(s.Profiles, ProfileSummary{
+		Profile:    profile,
+		ProcessID:  r.ProcessID,
+		Status:     r.Status,
+		Error:      r.Error,
+		DurationMs: r.DurationMs,
+		Totals:     r.Totals,
+	})
+
+	switch r.Status {
+	case "SUCCESS":
+	case "CANCELLED":
+		s.Cancelled++
+	default:
+		s.Failed++
+	}
+
+	s.Totals.Lines += r.Totals.Lines
+	s.Totals.Parsed += r.Totals.Parsed
+	s.Totals.Rejected += r.Totals.Rejected
+	s.Totals.Inserted += r.Totals.Inserted
+	s.Totals.Updated += r.Totals.Updated
+	s.Totals.Unchanged += r.Totals.Unchanged
+}
+
+func (s *Summary) Finish() {
+	s.FinishedAt = time.Now()
+	s.DurationMs = s.FinishedAt.Sub(s.StartedAt).Milliseconds()
+
+	switch {
+	case s.Failed > 0:
+		s.Status = "FAILED"
+	case s.Cancelled > 0:
+		s.Status = "CANCELLED"
+	default:
+		s.Status = "SUCCESS"
+	}
+}
+
+// WriteSummary stores the summary as summary_<timestamp>.json in dir.
+func WriteSummary(dir string, s *Summary) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, "summary_"+s.StartedAt.Format("20060102_150405")+".json")
+
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal summary: %w", err)
+	}
+	return path, os.WriteFile(path, data, 0644)
+}
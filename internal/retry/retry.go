@@ -0,0 +1,150 @@
+package retry
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net"
+	"syscall"
+	"time"
+
+	"go-import-file/internal/config"
+)
+
+// Policy retries transient database errors with exponential backoff.
+type Policy struct {
+	Attempts   int // total tries, 1 disables retries
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	Jitter     float64 // fraction of each delay that is randomised, 0..1
+}
+
+func FromConfig(c config.RetryConfig) Policy {
+	return Policy{
+		Attempts:   c.Attempts,
+		Backoff:    time.Duration(c.BackoffMs) * time.Millisecond,
+		MaxBackoff: time.Duration(c.MaxBackoffMs) * time.Millisecond,
+		Jitter:     c.Jitter,
+	}
+}
+
+// Do runs fn until it succeeds, fails with a non-transient error or the
+// attempts are used up. fn must be safe to repeat: every attempt has to
+// start from state the failed attempt did not touch (a rolled back
+// transaction on a fresh connection).
+func (p Policy) Do(ctx context.Context, name string, fn func(context.Context) error) error {
+	return p.DoWhen(ctx, name, Transient, fn)
+}
+
+// DoWhen is Do with its own classifier: only errors for which retryable
+// reports true are retried.
+func (p Policy) DoWhen(ctx context.Context, name string, retryable func(error) bool, fn func(context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= p.Attempts || !retryable(err) {
+			return err
+		}
+
+		d := p.delay(attempt)
+		log.Printf("[RETRY] %s attempt %d/%d failed: %v (retry in %s)", name, attempt, p.Attempts, err, d)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(d):
+		}
+	}
+}
+
+func (p Policy) delay(attempt int) time.Duration {
+	d := p.Backoff << (attempt - 1)
+	if p.MaxBackoff > 0 && (d > p.MaxBackoff || d <= 0) {
+		d = p.MaxBackoff
+	}
+	if p.Jitter > 0 && d > 0 {
+		spread := float64(d) * p.Jitter
+		d += time.Duration(spread * (2*rand.Float64() - 1))
+	}
+	return max(d, 0)
+}
+
+// contentionNumbers are SQL Server errors that roll back the statement
+// but keep the session, and with it its temp tables.
+var contentionNumbers = map[int32]bool{
+	1205: true, // deadlock victim
+	1222: true, // lock request timeout
+}
+
+// lostNumbers are SQL Server errors that end the session.
+var lostNumbers = map[int32]bool{
+	-2:    true, // client timeout
+	233:   true, // connection closed by server
+	64:    true, // connection dropped
+	10053: true, // transport-level error
+	10054: true, // connection reset by peer
+	10060: true, // connection timed out
+	40197: true, // Azure: service error processing request
+	40501: true, // Azure: service busy
+	40613: true, // Azure: database unavailable
+	49918: true, // Azure: not enough resources
+	49919: true,
+	49920: true,
+}
+
+// Transient reports whether err is a deadlock, lock timeout or a dropped
+// connection. Cancellation and timeouts of the caller's context never are.
+func Transient(err error) bool {
+	return Contention(err) || ConnectionLost(err)
+}
+
+// Contention reports whether err is a deadlock or lock timeout. The
+// session survives those, so work that relies on session state such as a
+// staged #temp table can be replayed on the same connection.
+func Contention(err error) bool {
+	if cancelled(err) {
+		return false
+	}
+	n, ok := sqlErrorNumber(err)
+	return ok && contentionNumbers[n]
+}
+
+// ConnectionLost reports whether err ended the session: a dropped or
+// reset connection, a client timeout or an Azure failover. Only work that
+// starts over on a new connection can be retried after one.
+func ConnectionLost(err error) bool {
+	if cancelled(err) {
+		return false
+	}
+	if n, ok := sqlErrorNumber(err); ok {
+		return lostNumbers[n]
+	}
+
+	if errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func cancelled(err error) bool {
+	return err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// sqlErrorNumber returns the server error number of err, if it has one.
+// mssql.Error and sink.ProcedureError both expose it.
+func sqlErrorNumber(err error) (int32, bool) {
+	var numbered interface{ SQLErrorNumber() int32 }
+	if errors.As(err, &numbered) {
+		return numbered.SQLErrorNumber(), true
+	}
+	return 0, false
+}
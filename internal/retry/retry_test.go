@@ -0,0 +1,138 @@
+package retry
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	mssql "github.com/microsoft/go-mssqldb"
+)
+
+func serverError(number int32) error {
+	return fmt.Errorf("MERGE dbo.fmaster: %w", mssql.Error{Number: number, Message: "server error"})
+}
+
+func TestClassify(t *testing.T) {
+	opErr := &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+
+	tests := []struct {
+		name       string
+		err        error
+		contention bool
+		lost       bool
+	}{
+		{"deadlock victim 1205", serverError(1205), true, false},
+		{"lock timeout 1222", serverError(1222), true, false},
+		{"client timeout -2", serverError(-2), false, true},
+		{"connection closed 233", serverError(233), false, true},
+		{"transport error 10054", serverError(10054), false, true},
+		{"azure unavailable 40613", serverError(40613), false, true},
+		{"duplicate key 2627", serverError(2627), false, false},
+		{"conversion 245", serverError(245), false, false},
+		{"bad connection", driver.ErrBadConn, false, true},
+		{"unexpected EOF", fmt.Errorf("read: %w", io.ErrUnexpectedEOF), false, true},
+		{"connection reset", opErr, false, true},
+		{"broken pipe", syscall.EPIPE, false, true},
+		{"cancelled", fmt.Errorf("MERGE: %w", context.Canceled), false, false},
+		{"deadline", context.DeadlineExceeded, false, false},
+		{"plain error", errors.New("no column named N"), false, false},
+		{"nil", nil, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Contention(tt.err); got != tt.contention {
+				t.Errorf("Contention = %v, want %v", got, tt.contention)
+			}
+			if got := ConnectionLost(tt.err); got != tt.lost {
+				t.Errorf("ConnectionLost = %v, want %v", got, tt.lost)
+			}
+			if got := Transient(tt.err); got != (tt.contention || tt.lost) {
+				t.Errorf("Transient = %v", got)
+			}
+		})
+	}
+}
+
+// fails returns an fn that fails with errs in turn, then succeeds, and
+// the number of calls made.
+func fails(errs ...error) (func(context.Context) error, *int) {
+	calls := new(int)
+	return func(context.Context) error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}, calls
+}
+
+var quick = Policy{Attempts: 3, Backoff: time.Millisecond, MaxBackoff: 5 * time.Millisecond}
+
+// An upsert merge replays from its #temp table, which only survives
+// contention: after a lost connection the merge must not be replayed.
+func TestMergeReplaysOnlyOnContention(t *testing.T) {
+	tests := []struct {
+		name      string
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{"deadlock is replayed", []error{serverError(1205)}, 2, false},
+		{"lock timeouts until attempts run out", []error{serverError(1222), serverError(1222), serverError(1222)}, 3, true},
+		{"lost connection is not replayed", []error{serverError(10054)}, 1, true},
+		{"reset after a deadlock stops", []error{serverError(1205), driver.ErrBadConn}, 2, true},
+		{"other errors are not replayed", []error{serverError(2627)}, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fn, calls := fails(tt.errs...)
+			err := quick.DoWhen(context.Background(), "MERGE dbo.fmaster", Contention, fn)
+
+			if *calls != tt.wantCalls || (err != nil) != tt.wantErr {
+				t.Errorf("%d calls, err %v; want %d calls, error %v", *calls, err, tt.wantCalls, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDoRetriesLostConnection(t *testing.T) {
+	fn, calls := fails(serverError(10054), driver.ErrBadConn)
+	if err := quick.Do(context.Background(), "FINALIZE", fn); err != nil || *calls != 3 {
+		t.Errorf("%d calls, err %v; want 3 calls and success", *calls, err)
+	}
+}
+
+func TestDoStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	fn, calls := fails(serverError(1205), serverError(1205))
+	slow := Policy{Attempts: 3, Backoff: time.Hour}
+	if err := slow.Do(ctx, "FINALIZE", fn); err == nil || *calls != 1 {
+		t.Errorf("%d calls, err %v; want 1 call and the deadlock", *calls, err)
+	}
+}
+
+func TestDelay(t *testing.T) {
+	p := Policy{Backoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, want := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 800 * time.Millisecond, 5: time.Second, 70: time.Second} {
+		if got := p.delay(attempt); got != want {
+			t.Errorf("delay(%d) = %v, want %v", attempt, got, want)
+		}
+	}
+
+	p.Jitter = 0.5
+	for range 100 {
+		if d := p.delay(2); d < 100*time.Millisecond || d > 300*time.Millisecond {
+			t.Fatalf("jittered delay %v outside 100ms..300ms", d)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+)
+
+// CheckpointTable records, per process, target table and source file, how
+// many rows BulkLoadBatches has committed. It is created by the migrations.
+const CheckpointTable = "dbo.import_checkpoint"
+
+// Batch configures BulkLoadBatches.
+type Batch struct {
+	Size      int
+	ProcessID string
+
+	// Committed holds the rows per source file an earlier attempt of the
+	// same process already committed (see Sink.Checkpoints). Those rows are
+	// skipped, which works because one parser reads a file in line order.
+	Committed map[string]int64
+}
+
+// batchLoader is what each sink provides to share loadBatches.
+type batchLoader interface {
+	begin(ctx context.Context) (*sql.Tx, error)
+	load(ctx context.Context, tx *sql.Tx, spec TableSpec, rows <-chan []any) (int64, error)
+	saveCheckpoint(ctx context.Context, tx *sql.Tx, processID, table, file string, rows int64) error
+}
+
+// fileColumn is the index of the column holding the source file name, or -1.
+func (s TableSpec) fileColumn() int {
+	for i, c := range s.Columns {
+		if c.Name == "CORE_FILENAME" || c.Name == "FILENAME" {
+			return i
+		}
+	}
+	return -1
+}
+
+// loadBatches commits every b.Size rows in its own transaction together
+// with the checkpoints of the files in that batch, so a failed run leaves
+// only whole batches behind and knows exactly where to continue.
+func loadBatches(ctx context.Context, l batchLoader, spec TableSpec, rows <-chan []any, b Batch) (Result, error) {
+	var res Result
+
+	size := b.Size
+	if size < 1 {
+		size = 1
+	}
+
+	fileIdx := spec.fileColumn()
+	fileOf := func(row []any) string {
+		if fileIdx < 0 || fileIdx >= len(row) {
+			return ""
+		}
+		f, _ := row[fileIdx].(string)
+		return f
+	}
+
+	committed := make(map[string]int64, len(b.Committed))
+	for f, n := range b.Committed {
+		committed[f] = n
+	}
+	seen := map[string]int64{}
+
+	buf := make([][]any, 0, size)
+	pending := map[string]int64{}
+
+	flush := func() error {
+		if len(buf) == 0 {
+			return nil
+		}
+
+		tx, err := l.begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		ch := make(chan []any, len(buf))
+		for _, row := range buf {
+			ch <- row
+		}
+		close(ch)
+
+		n, err := l.load(ctx, tx, spec, ch)
+		if err != nil {
+			return err
+		}
+
+		for f, c := range pending {
+			if err := l.saveCheckpoint(ctx, tx, b.ProcessID, spec.Table, f, committed[f]+c); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+
+		for f, c := range pending {
+			committed[f] += c
+		}
+		res.Rows += n
+		res.Inserted += n
+
+		buf = buf[:0]
+		clear(pending)
+		return nil
+	}
+
+	for row := range rows {
+		f := fileOf(row)
+
+		seen[f]++
+		if seen[f] <= b.Committed[f] {
+			continue
+		}
+
+		buf = append(buf, row)
+		pending[f]++
+
+		if len(buf) >= size {
+			if err := flush(); err != nil {
+				return res, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+// scanCheckpoints reads file_name, rows_committed rows into a map.
+func scanCheckpoints(rows *sql.Rows) (map[string]int64, error) {
+	defer rows.Close()
+
+	out := map[string]int64{}
+	for rows.Next() {
+		var (
+			file string
+			n    int64
+		)
+		if err := rows.Scan(&file, &n); err != nil {
+			return nil, err
+		}
+		out[file] = n
+	}
+	return out, rows.Err()
+}
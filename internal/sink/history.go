@@ -0,0 +1,149 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go-import-file/internal/dates"
+)
+
+// History columns added to the spec columns in <table>_history. A version
+// is open while VALID_TO is NULL.
+const (
+	ValidFromColumn = "VALID_FROM"
+	ValidToColumn   = "VALID_TO"
+	ProcessIDColumn = "PROCESS_ID"
+)
+
+// History makes Upsert keep a slowly changing dimension (type 2) copy of
+// spec.Table: every new or changed row opens a version in HistoryTable,
+// closing the version it replaces. The source file is the CORE_FILENAME
+// column of the row itself.
+type History struct {
+	ProcessID string
+}
+
+// HistoryTable is the history table of spec, e.g. dbo.fcustmst_history.
+func (s TableSpec) HistoryTable() string {
+	return s.Table + "_history"
+}
+
+// historyColumns are the spec columns stored per version, then the
+// process ID unless the spec already carries one.
+func (s TableSpec) historyColumns() (cols []string, withProcessID bool) {
+	cols = s.ColumnNames()
+	return cols, !slices.Contains(cols, ProcessIDColumn)
+}
+
+func placeholder(driver string, n int) string {
+	switch driver {
+	case DriverSQLServer:
+		return "@p" + strconv.Itoa(n)
+	case DriverPostgres:
+		return "$" + strconv.Itoa(n)
+	}
+	return "?" + strconv.Itoa(n)
+}
+
+// sameValues is a NULL-safe equality of the columns of two row aliases.
+func sameValues(driver string, ident func(string) string, a, b string, cols []string) string {
+	if len(cols) == 0 {
+		return "1 = 1"
+	}
+
+	as := make([]string, len(cols))
+	bs := make([]string, len(cols))
+	for i, c := range cols {
+		as[i] = a + "." + ident(c)
+		bs[i] = b + "." + ident(c)
+	}
+
+	switch driver {
+	case DriverSQLServer:
+		return "EXISTS (SELECT " + strings.Join(as, ", ") + " INTERSECT SELECT " + strings.Join(bs, ", ") + ")"
+	case DriverPostgres:
+		return "(" + strings.Join(as, ", ") + ") IS NOT DISTINCT FROM (" + strings.Join(bs, ", ") + ")"
+	}
+
+	conds := make([]string, len(cols))
+	for i := range cols {
+		conds[i] = as[i] + " IS " + bs[i]
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// recordHistory versions the rows of srcSQL into historyTable before they
+// are merged, in the same transaction as the merge. Keys seen for the
+// first time, including rows that predate the history table, open their
+// first version.
+func recordHistory(
+	ctx context.Context,
+	tx *sql.Tx,
+	driver, historyTable, srcSQL string,
+	spec TableSpec,
+	ident func(string) string,
+) error {
+	if spec.History == nil {
+		return nil
+	}
+
+	now := dates.Now()
+	cols, withProcessID := spec.historyColumns()
+
+	keyConds := make([]string, len(spec.Keys))
+	for i, k := range spec.Keys {
+		keyConds[i] = "h." + ident(k) + " = src." + ident(k)
+	}
+	sameKey := strings.Join(keyConds, " AND ")
+	open := "h." + ident(ValidToColumn) + " IS NULL"
+
+	closeSQL := "UPDATE " + historyTable + " AS h SET " + ident(ValidToColumn) + " = " + placeholder(driver, 1) +
+		" WHERE " + open + " AND EXISTS (SELECT 1 FROM (" + srcSQL + ") AS src WHERE " + sameKey +
+		" AND NOT (" + sameValues(driver, ident, "src", "h", spec.compareColumns()) + "))"
+	if driver == DriverSQLServer {
+		closeSQL = "UPDATE h SET " + ident(ValidToColumn) + " = " + placeholder(driver, 1) +
+			" FROM " + historyTable + " AS h" +
+			" WHERE " + open + " AND EXISTS (SELECT 1 FROM (" + srcSQL + ") AS src WHERE " + sameKey +
+			" AND NOT (" + sameValues(driver, ident, "src", "h", spec.compareColumns()) + "))"
+	}
+
+	if _, err := tx.ExecContext(ctx, closeSQL, now); err != nil {
+		return fmt.Errorf("close history versions: %w", err)
+	}
+
+	quoted := make([]string, len(cols))
+	srcCols := make([]string, len(cols))
+	for i, c := range cols {
+		quoted[i] = ident(c)
+		srcCols[i] = "src." + ident(c)
+	}
+	quoted = append(quoted, ident(ValidFromColumn))
+	srcCols = append(srcCols, placeholder(driver, 1))
+	args := []any{now}
+	if withProcessID {
+		quoted = append(quoted, ident(ProcessIDColumn))
+		srcCols = append(srcCols, placeholder(driver, 2))
+		args = append(args, spec.History.ProcessID)
+	}
+
+	openSQL := "INSERT INTO " + historyTable + " (" + strings.Join(quoted, ", ") + ")" +
+		" SELECT " + strings.Join(srcCols, ", ") + " FROM (" + srcSQL + ") AS src" +
+		" WHERE NOT EXISTS (SELECT 1 FROM " + historyTable + " AS h WHERE " + open + " AND " + sameKey + ")"
+
+	if _, err := tx.ExecContext(ctx, openSQL, args...); err != nil {
+		return fmt.Errorf("open history versions: %w", err)
+	}
+	return nil
+}
+
+// asOfSQL selects the versions of historyTable valid at placeholder 1.
+func asOfSQL(driver, historyTable string, ident func(string) string) string {
+	at := placeholder(driver, 1)
+	return "SELECT * FROM " + historyTable +
+		" WHERE " + ident(ValidFromColumn) + " <= " + at +
+		" AND (" + ident(ValidToColumn) + " IS NULL OR " + ident(ValidToColumn) + " > " + at + ")"
+}
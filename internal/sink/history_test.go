@@ -0,0 +1,91 @@
+package sink
+
+import "testing"
+
+func masterSpec() TableSpec {
+	return TableSpec{
+		Table:   "dbo.fmaster_test",
+		Columns: []Column{String("PCODE", 20), String("PNAME", 100), String("CORE_FILENAME", 255)},
+		Keys:    []string{"PCODE"},
+		History: &History{ProcessID: "p1"},
+	}
+}
+
+func TestHistoryVersionsNewAndChangedRows(t *testing.T) {
+	s := openSQLite(t)
+	spec := masterSpec()
+
+	steps := []struct {
+		name      string
+		rows      [][]any
+		wantP1    int    // versions of P1
+		wantOpen  string // PNAME of the open version of P1
+		wantTotal int    // versions of all keys
+	}{
+		{"new row opens a version", [][]any{{"P1", "one", "a.txt"}}, 1, "one", 1},
+		{"unchanged row adds nothing", [][]any{{"P1", "one", "a.txt"}}, 1, "one", 1},
+		{"audit column alone is no change", [][]any{{"P1", "one", "b.txt"}}, 1, "one", 1},
+		{"changed row closes and opens", [][]any{{"P1", "renamed", "c.txt"}}, 2, "renamed", 2},
+		{"other key is versioned apart", [][]any{{"P1", "renamed", "c.txt"}, {"P2", "two", "c.txt"}}, 2, "renamed", 3},
+	}
+	for _, st := range steps {
+		mustUpsert(t, s, spec, st.rows...)
+
+		if n := count(t, s, "SELECT COUNT(*) FROM fmaster_test_history WHERE PCODE = 'P1'"); n != st.wantP1 {
+			t.Errorf("%s: P1 has %d versions, want %d", st.name, n, st.wantP1)
+		}
+		if n := count(t, s, "SELECT COUNT(*) FROM fmaster_test_history WHERE PCODE = 'P1' AND VALID_TO IS NULL AND PNAME = '"+st.wantOpen+"'"); n != 1 {
+			t.Errorf("%s: P1 has no single open version %s", st.name, st.wantOpen)
+		}
+		if n := count(t, s, "SELECT COUNT(*) FROM fmaster_test_history"); n != st.wantTotal {
+			t.Errorf("%s: %d versions, want %d", st.name, n, st.wantTotal)
+		}
+	}
+
+	if n := count(t, s, "SELECT COUNT(*) FROM fmaster_test_history WHERE PROCESS_ID = 'p1' AND VALID_FROM IS NOT NULL"); n != 3 {
+		t.Errorf("%d versions carry the process and VALID_FROM, want 3", n)
+	}
+	if n := count(t, s, "SELECT COUNT(*) FROM fmaster_test_history WHERE PNAME = 'one' AND VALID_TO >= VALID_FROM"); n != 1 {
+		t.Errorf("replaced version of P1 is not closed")
+	}
+}
+
+func TestHistoryOpensVersionForExistingRow(t *testing.T) {
+	s := openSQLite(t)
+	spec := masterSpec()
+
+	// The row predates history being switched on.
+	plain := spec
+	plain.History = nil
+	mustUpsert(t, s, plain, []any{"P1", "one", "a.txt"})
+
+	res := mustUpsert(t, s, spec, []any{"P1", "one", "a.txt"})
+	if res.Unchanged != 1 {
+		t.Errorf("unchanged %d, want 1", res.Unchanged)
+	}
+	if n := count(t, s, "SELECT COUNT(*) FROM fmaster_test_history WHERE PCODE = 'P1' AND VALID_TO IS NULL"); n != 1 {
+		t.Errorf("%d open versions, want 1", n)
+	}
+}
+
+func TestHistoryOfRetiredRow(t *testing.T) {
+	s := openSQLite(t)
+	spec := custSpec(SnapshotFlag, 1)
+	spec.History = &History{ProcessID: "p1"}
+
+	mustUpsert(t, s, spec, []any{"A", "C1", "one"}, []any{"A", "C2", "two"})
+	mustUpsert(t, s, spec, []any{"A", "C1", "one"})
+
+	if n := count(t, s, "SELECT COUNT(*) FROM mcust_test_history WHERE CUSTNO = 'C2' AND VALID_TO IS NOT NULL"); n != 1 {
+		t.Errorf("retired C2 has %d closed versions, want 1", n)
+	}
+
+	// Coming back with other values opens one new version.
+	mustUpsert(t, s, spec, []any{"A", "C1", "one"}, []any{"A", "C2", "renamed"})
+	if n := count(t, s, "SELECT COUNT(*) FROM mcust_test_history WHERE CUSTNO = 'C2' AND VALID_TO IS NULL AND NAME = 'renamed'"); n != 1 {
+		t.Errorf("revived C2 has no open version")
+	}
+	if n := count(t, s, "SELECT COUNT(*) FROM mcust_test_history WHERE CUSTNO = 'C2'"); n != 2 {
+		t.Errorf("C2 has %d versions, want 2", n)
+	}
+}
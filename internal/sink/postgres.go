@@ -0,0 +1,339 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Postgres loads with COPY and upserts with INSERT ... ON CONFLICT, which
+// needs a unique index on the spec keys. Identifiers are quoted so table
+// and column names keep the same case as on SQL Server.
+type Postgres struct {
+	db     *sql.DB
+	schema string
+}
+
+// NewPostgres maps the dbo schema used by the writers onto schema; an empty
+// schema keeps dbo.
+func NewPostgres(db *sql.DB, schema string) *Postgres {
+	return &Postgres{db: db, schema: schema}
+}
+
+func (p *Postgres) Driver() string { return DriverPostgres }
+func (p *Postgres) DB() *sql.DB    { return p.db }
+
+func (p *Postgres) split(table string) (string, string) {
+	schema, name := "", table
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		schema, name = table[:i], table[i+1:]
+	}
+	if p.schema != "" && (schema == "" || strings.EqualFold(schema, "dbo")) {
+		schema = p.schema
+	}
+	return schema, name
+}
+
+func (p *Postgres) table(table string) string {
+	schema, name := p.split(table)
+	if schema == "" {
+		return pq.QuoteIdentifier(name)
+	}
+	return pq.QuoteIdentifier(schema) + "." + pq.QuoteIdentifier(name)
+}
+
+func quoted(prefix string, cols []string) []string {
+	out := make([]string, len(cols))
+	for i, c := range cols {
+		out[i] = prefix + pq.QuoteIdentifier(c)
+	}
+	return out
+}
+
+/* =========================
+   BULK LOAD
+========================= */
+
+func (p *Postgres) BulkLoad(ctx context.Context, spec TableSpec, rows <-chan []any) (Result, error) {
+	var res Result
+
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback()
+
+	n, err := p.load(ctx, tx, spec, rows)
+	if err != nil {
+		return res, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return res, fmt.Errorf("commit: %w", err)
+	}
+
+	res.Rows = n
+	res.Inserted = n
+	return res, nil
+}
+
+func (p *Postgres) BulkLoadBatches(ctx context.Context, spec TableSpec, rows <-chan []any, b Batch) (Result, error) {
+	return loadBatches(ctx, p, spec, rows, b)
+}
+
+func (p *Postgres) begin(ctx context.Context) (*sql.Tx, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	return tx, nil
+}
+
+func (p *Postgres) load(ctx context.Context, tx *sql.Tx, spec TableSpec, rows <-chan []any) (int64, error) {
+	cols := spec.ColumnNames()
+	schema, name := p.split(spec.Table)
+
+	stmt, err := tx.Prepare(pq.CopyInSchema(schema, name, cols...))
+	if err != nil {
+		return 0, fmt.Errorf("prepare: %w", err)
+	}
+	defer stmt.Close()
+
+	return copyRows(ctx, stmt, cols, rows)
+}
+
+func (p *Postgres) saveCheckpoint(ctx context.Context, tx *sql.Tx, processID, table, file string, rows int64) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO `+p.table(CheckpointTable)+` (process_id, table_name, file_name, rows_committed, updated_at)
+		VALUES ($1, $2, $3, $4, now())
+		ON CONFLICT (process_id, table_name, file_name)
+		DO UPDATE SET rows_committed = EXCLUDED.rows_committed, updated_at = EXCLUDED.updated_at`,
+		processID, table, file, rows,
+	)
+	return err
+}
+
+func (p *Postgres) Checkpoints(ctx context.Context, processID, table string) (map[string]int64, error) {
+	rows, err := p.db.QueryContext(ctx,
+		"SELECT file_name, rows_committed FROM "+p.table(CheckpointTable)+" WHERE process_id = $1 AND table_name = $2",
+		processID, table,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanCheckpoints(rows)
+}
+
+/* =========================
+   UPSERT VIA TEMP TABLE
+========================= */
+
+func (p *Postgres) Upsert(ctx context.Context, spec TableSpec, rows <-chan []any) (Result, error) {
+	var res Result
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback()
+
+	tempTable := "tmp_" + spec.BareName()
+
+	if _, err := tx.ExecContext(ctx, p.tempTableDDL(tempTable, spec)); err != nil {
+		return res, err
+	}
+
+	cols := spec.ColumnNames()
+
+	stmt, err := tx.Prepare(pq.CopyIn(tempTable, cols...))
+	if err != nil {
+		return res, err
+	}
+	defer stmt.Close()
+
+	n, err := copyRows(ctx, stmt, cols, rows)
+	res.Rows = n
+	if err != nil {
+		return res, err
+	}
+
+	srcSQL := p.sourceSQL(tempTable, spec)
+
+	if err := recordHistory(ctx, tx, DriverPostgres, p.table(spec.HistoryTable()), srcSQL, spec, pq.QuoteIdentifier); err != nil {
+		return res, err
+	}
+
+	merged, err := p.upsertFromTemp(ctx, tx, spec, srcSQL)
+	if err != nil {
+		return res, err
+	}
+
+	merged.Retired, err = retireMissing(ctx, tx, DriverPostgres, p.table(spec.Table), p.table(spec.HistoryTable()), pq.QuoteIdentifier(tempTable), spec, pq.QuoteIdentifier)
+	if err != nil {
+		return res, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return res, err
+	}
+
+	merged.Rows = n
+	return merged, nil
+}
+
+func (p *Postgres) tempTableDDL(tempTable string, spec TableSpec) string {
+	defs := make([]string, len(spec.Columns))
+	for i, c := range spec.Columns {
+		defs[i] = "\t" + pq.QuoteIdentifier(c.Name) + " " + postgresType(c)
+	}
+	return "CREATE TEMP TABLE " + pq.QuoteIdentifier(tempTable) + " (\n" + strings.Join(defs, ",\n") + "\n) ON COMMIT DROP"
+}
+
+func postgresType(c Column) string {
+	switch c.Type {
+	case TypeInt:
+		return "INTEGER"
+	case TypeBigInt:
+		return "BIGINT"
+	case TypeFloat:
+		return "DOUBLE PRECISION"
+	case TypeDecimal:
+		return fmt.Sprintf("NUMERIC(%d,%d)", c.Precision, c.Scale)
+	case TypeDate:
+		return "DATE"
+	case TypeDateTime:
+		return "TIMESTAMP"
+	}
+	if c.Size <= 0 {
+		return "TEXT"
+	}
+	return fmt.Sprintf("VARCHAR(%d)", c.Size)
+}
+
+// sourceSQL mirrors the SQL Server variant: DISTINCT rows, or the first
+// row per spec.Dedup partition.
+func (p *Postgres) sourceSQL(tempTable string, spec TableSpec) string {
+	cols := strings.Join(quoted("", spec.ColumnNames()), ", ")
+
+	if len(spec.Dedup) == 0 {
+		return "SELECT DISTINCT " + cols + " FROM " + pq.QuoteIdentifier(tempTable)
+	}
+
+	partition := strings.Join(quoted("", spec.Dedup), ", ")
+	return "SELECT DISTINCT ON (" + partition + ") " + cols +
+		" FROM " + pq.QuoteIdentifier(tempTable) +
+		" ORDER BY " + partition
+}
+
+// upsertFromTemp counts unchanged rows first, then inserts with ON CONFLICT
+// and tells inserts from updates by xmax (0 for a freshly inserted row).
+func (p *Postgres) upsertFromTemp(ctx context.Context, tx *sql.Tx, spec TableSpec, srcSQL string) (Result, error) {
+	var res Result
+
+	target := p.table(spec.Table)
+
+	keyConds := make([]string, len(spec.Keys))
+	for i, k := range spec.Keys {
+		q := pq.QuoteIdentifier(k)
+		keyConds[i] = "tgt." + q + " = src." + q
+	}
+
+	if cmp := spec.compareColumns(); len(cmp) > 0 {
+		unchangedSQL := `
+			WITH src AS (` + srcSQL + `)
+			SELECT COUNT(*)
+			FROM src
+			INNER JOIN ` + target + ` AS tgt
+				ON ` + strings.Join(keyConds, " AND ") + `
+			WHERE (` + strings.Join(quoted("src.", cmp), ", ") + `)
+				IS NOT DISTINCT FROM (` + strings.Join(quoted("tgt.", cmp), ", ") + `)`
+
+		if err := tx.QueryRowContext(ctx, unchangedSQL).Scan(&res.Unchanged); err != nil {
+			return Result{}, err
+		}
+	}
+
+	cols := quoted("", spec.ColumnNames())
+
+	sets := make([]string, 0, len(cols))
+	for _, c := range spec.UpdateColumns() {
+		q := pq.QuoteIdentifier(c)
+		sets = append(sets, q+" = EXCLUDED."+q)
+	}
+	if revive := spec.Snapshot.reviveSet(pq.QuoteIdentifier); revive != "" {
+		sets = append(sets, revive)
+	}
+
+	upsertSQL := `
+		WITH src AS (` + srcSQL + `),
+		up AS (
+			INSERT INTO ` + target + ` (` + strings.Join(cols, ", ") + `)
+			SELECT ` + strings.Join(cols, ", ") + ` FROM src
+			ON CONFLICT (` + strings.Join(quoted("", spec.Keys), ", ") + `)
+			DO UPDATE SET ` + strings.Join(sets, ", ") + `
+			RETURNING (xmax = 0) AS inserted
+		)
+		SELECT
+			COUNT(*) FILTER (WHERE inserted),
+			COUNT(*) FILTER (WHERE NOT inserted)
+		FROM up`
+
+	var matched int64
+	if err := tx.QueryRowContext(ctx, upsertSQL).Scan(&res.Inserted, &matched); err != nil {
+		return Result{}, err
+	}
+
+	res.Updated = matched - res.Unchanged
+	if res.Updated < 0 {
+		res.Updated = 0
+	}
+
+	return res, nil
+}
+
+func (p *Postgres) AsOf(ctx context.Context, spec TableSpec, t time.Time) (*sql.Rows, error) {
+	return p.db.QueryContext(ctx, asOfSQL(DriverPostgres, p.table(spec.HistoryTable()), pq.QuoteIdentifier), t)
+}
+
+func (p *Postgres) Select(ctx context.Context, spec TableSpec) (*sql.Rows, error) {
+	cols := quoted("", spec.ColumnNames())
+	return p.db.QueryContext(ctx, "SELECT "+strings.Join(cols, ", ")+" FROM "+p.table(spec.Table))
+}
+
+func (p *Postgres) Dangling(ctx context.Context, ref Reference, processID string, samples int) (Dangling, error) {
+	return dangling(ctx, p.db, DriverPostgres, p.table, pq.QuoteIdentifier, ref, processID, samples)
+}
+
+/* =========================
+   TRUNCATE / PROCEDURES
+========================= */
+
+func (p *Postgres) Truncate(ctx context.Context, tables ...string) error {
+	names := make([]string, len(tables))
+	for i, t := range tables {
+		names[i] = p.table(t)
+	}
+
+	_, err := p.db.ExecContext(ctx, "TRUNCATE TABLE "+strings.Join(names, ", "))
+	return err
+}
+
+// ExecProcedure calls a procedure with named notation (name => value).
+func (p *Postgres) ExecProcedure(ctx context.Context, name string, args ...sql.NamedArg) error {
+	params := make([]string, len(args))
+	values := make([]any, len(args))
+	for i, a := range args {
+		params[i] = fmt.Sprintf("%s => $%d", strings.ToLower(a.Name), i+1)
+		values[i] = a.Value
+	}
+
+	_, err := p.db.ExecContext(ctx, "CALL "+p.table(name)+"("+strings.Join(params, ", ")+")", values...)
+	if err != nil {
+		return fmt.Errorf("execute %s failed: %w", name, err)
+	}
+	return nil
+}
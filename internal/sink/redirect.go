@@ -0,0 +1,50 @@
+package sink
+
+import "context"
+
+// Redirect sends everything written to the given tables to another table,
+// e.g. dbo.DP_ZDHDR to shadow.DP_ZDHDR. Other tables pass through.
+func Redirect(s Sink, tables map[string]string) Sink {
+	return &redirect{Sink: s, tables: tables}
+}
+
+type redirect struct {
+	Sink
+	tables map[string]string
+}
+
+func (r *redirect) name(table string) string {
+	if t, ok := r.tables[table]; ok {
+		return t
+	}
+	return table
+}
+
+func (r *redirect) spec(spec TableSpec) TableSpec {
+	spec.Table = r.name(spec.Table)
+	return spec
+}
+
+func (r *redirect) BulkLoad(ctx context.Context, spec TableSpec, rows <-chan []any) (Result, error) {
+	return r.Sink.BulkLoad(ctx, r.spec(spec), rows)
+}
+
+func (r *redirect) BulkLoadBatches(ctx context.Context, spec TableSpec, rows <-chan []any, b Batch) (Result, error) {
+	return r.Sink.BulkLoadBatches(ctx, r.spec(spec), rows, b)
+}
+
+func (r *redirect) Upsert(ctx context.Context, spec TableSpec, rows <-chan []any) (Result, error) {
+	return r.Sink.Upsert(ctx, r.spec(spec), rows)
+}
+
+func (r *redirect) Checkpoints(ctx context.Context, processID, table string) (map[string]int64, error) {
+	return r.Sink.Checkpoints(ctx, processID, r.name(table))
+}
+
+func (r *redirect) Truncate(ctx context.Context, tables ...string) error {
+	names := make([]string, len(tables))
+	for i, t := range tables {
+		names[i] = r.name(t)
+	}
+	return r.Sink.Truncate(ctx, names...)
+}
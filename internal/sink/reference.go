@@ -0,0 +1,97 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Reference says that Columns of Table hold a key (RefColumns) of
+// RefTable. Rows with any of Columns empty reference nothing.
+type Reference struct {
+	Table      string
+	Columns    []string
+	RefTable   string
+	RefColumns []string
+
+	// ProcessColumn limits the check to the rows of one process, for
+	// staging tables shared by all runs.
+	ProcessColumn string
+}
+
+// Dangling are the rows whose reference has no match.
+type Dangling struct {
+	Rows    int64
+	Samples [][]string // distinct values of ref.Columns
+}
+
+// dangling runs ref against db. table names a table for the driver.
+func dangling(
+	ctx context.Context,
+	db *sql.DB,
+	driver string,
+	table, ident func(string) string,
+	ref Reference,
+	processID string,
+	samples int,
+) (Dangling, error) {
+	if len(ref.Columns) == 0 || len(ref.Columns) != len(ref.RefColumns) {
+		return Dangling{}, fmt.Errorf("reference %s -> %s: columns do not match", ref.Table, ref.RefTable)
+	}
+
+	cols := make([]string, len(ref.Columns))
+	where := make([]string, 0, len(ref.Columns)+2)
+	match := make([]string, len(ref.Columns))
+	for i, c := range ref.Columns {
+		cols[i] = "c." + ident(c)
+		where = append(where, cols[i]+" IS NOT NULL AND "+cols[i]+" <> ''")
+		match[i] = "p." + ident(ref.RefColumns[i]) + " = " + cols[i]
+	}
+
+	var args []any
+	if ref.ProcessColumn != "" {
+		where = append(where, "c."+ident(ref.ProcessColumn)+" = "+placeholder(driver, 1))
+		args = append(args, processID)
+	}
+	where = append(where, "NOT EXISTS (SELECT 1 FROM "+table(ref.RefTable)+" AS p WHERE "+strings.Join(match, " AND ")+")")
+
+	from := " FROM " + table(ref.Table) + " AS c WHERE " + strings.Join(where, " AND ")
+
+	var d Dangling
+	if err := db.QueryRowContext(ctx, "SELECT COUNT(*)"+from, args...).Scan(&d.Rows); err != nil {
+		return Dangling{}, err
+	}
+	if d.Rows == 0 || samples <= 0 {
+		return d, nil
+	}
+
+	q := "SELECT DISTINCT " + strings.Join(cols, ", ") + from + " ORDER BY " + strings.Join(cols, ", ") + " LIMIT " + strconv.Itoa(samples)
+	if driver == DriverSQLServer {
+		q = "SELECT DISTINCT TOP " + strconv.Itoa(samples) + " " + strings.Join(cols, ", ") + from + " ORDER BY " + strings.Join(cols, ", ")
+	}
+
+	rows, err := db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return Dangling{}, err
+	}
+	defer rows.Close()
+
+	values := make([]sql.NullString, len(cols))
+	ptrs := make([]any, len(cols))
+	for i := range values {
+		ptrs[i] = &values[i]
+	}
+	for rows.Next() {
+		if err := rows.Scan(ptrs...); err != nil {
+			return Dangling{}, err
+		}
+		sample := make([]string, len(values))
+		for i, v := range values {
+			sample[i] = v.String
+		}
+		d.Samples = append(d.Samples, sample)
+	}
+	return d, rows.Err()
+}
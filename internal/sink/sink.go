@@ -0,0 +1,211 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go-import-file/internal/config"
+	"go-import-file/internal/metrics"
+	"go-import-file/internal/retry"
+)
+
+const (
+	DriverSQLServer = "sqlserver"
+	DriverPostgres  = "postgres"
+	DriverSQLite    = "sqlite"
+)
+
+// Sink is where parsed rows end up. Writers describe their table with a
+// TableSpec and never build dialect specific SQL themselves.
+type Sink interface {
+	Driver() string
+	DB() *sql.DB
+
+	// BulkLoad appends every row to spec.Table in one transaction.
+	BulkLoad(ctx context.Context, spec TableSpec, rows <-chan []any) (Result, error)
+
+	// BulkLoadBatches appends the rows committing every b.Size rows, each
+	// commit recording checkpoints in CheckpointTable.
+	BulkLoadBatches(ctx context.Context, spec TableSpec, rows <-chan []any, b Batch) (Result, error)
+
+	// Checkpoints returns the rows per source file committed into table by
+	// processID.
+	Checkpoints(ctx context.Context, processID, table string) (map[string]int64, error)
+
+	// Upsert stages the rows and merges them into spec.Table by spec.Keys.
+	Upsert(ctx context.Context, spec TableSpec, rows <-chan []any) (Result, error)
+
+	// AsOf returns the rows of spec.HistoryTable valid at t.
+	AsOf(ctx context.Context, spec TableSpec, t time.Time) (*sql.Rows, error)
+
+	// Select returns spec.Columns of every row of spec.Table.
+	Select(ctx context.Context, spec TableSpec) (*sql.Rows, error)
+
+	// Dangling counts the rows of ref.Table whose reference has no match,
+	// with up to samples of their values.
+	Dangling(ctx context.Context, ref Reference, processID string, samples int) (Dangling, error)
+
+	Truncate(ctx context.Context, tables ...string) error
+	ExecProcedure(ctx context.Context, name string, args ...sql.NamedArg) error
+}
+
+func New(cfg *config.Config, db *sql.DB) (Sink, error) {
+	switch cfg.DB.Driver {
+	case DriverSQLServer:
+		return NewSQLServer(db, retry.FromConfig(cfg.Retry)), nil
+	case DriverPostgres:
+		return NewPostgres(db, cfg.DB.Schema), nil
+	case DriverSQLite:
+		return NewSQLite(db), nil
+	}
+	return nil, fmt.Errorf("unsupported database driver %q", cfg.DB.Driver)
+}
+
+type Result struct {
+	Rows      int64
+	Inserted  int64
+	Updated   int64
+	Unchanged int64
+	Retired   int64 // deactivated, flagged or deleted by a snapshot
+}
+
+/* =========================
+   TABLE SPEC
+========================= */
+
+type ColumnType int
+
+const (
+	TypeString ColumnType = iota
+	TypeInt
+	TypeBigInt
+	TypeFloat
+	TypeDecimal
+	TypeDate
+	TypeDateTime
+)
+
+type Column struct {
+	Name      string
+	Type      ColumnType
+	Size      int // String length
+	Precision int // Decimal
+	Scale     int // Decimal
+}
+
+func String(name string, size int) Column { return Column{Name: name, Type: TypeString, Size: size} }
+func Int(name string) Column              { return Column{Name: name, Type: TypeInt} }
+func BigInt(name string) Column           { return Column{Name: name, Type: TypeBigInt} }
+func Float(name string) Column            { return Column{Name: name, Type: TypeFloat} }
+func Date(name string) Column             { return Column{Name: name, Type: TypeDate} }
+func DateTime(name string) Column         { return Column{Name: name, Type: TypeDateTime} }
+
+func Decimal(name string, precision, scale int) Column {
+	return Column{Name: name, Type: TypeDecimal, Precision: precision, Scale: scale}
+}
+
+// TableSpec declares a target table the way a writer fills it. Columns are
+// in the order of the row values.
+type TableSpec struct {
+	Table   string // schema qualified, e.g. dbo.fcustmst
+	Columns []Column
+
+	// Keys identify a row for Upsert.
+	Keys []string
+
+	// Update lists the columns overwritten on a key match; empty means all.
+	Update []string
+
+	// Dedup keeps only the first staged row per combination of these
+	// columns. Without it the staged rows are made DISTINCT.
+	Dedup []string
+
+	// Snapshot, when set, retires target rows missing from the staged rows.
+	Snapshot *Snapshot
+
+	// History, when set, versions new and changed rows in HistoryTable.
+	History *History
+}
+
+func (s TableSpec) ColumnNames() []string {
+	names := make([]string, len(s.Columns))
+	for i, c := range s.Columns {
+		names[i] = c.Name
+	}
+	return names
+}
+
+func (s TableSpec) UpdateColumns() []string {
+	if len(s.Update) > 0 {
+		return s.Update
+	}
+	return s.ColumnNames()
+}
+
+// BareName is the table name without schema.
+func (s TableSpec) BareName() string {
+	if i := strings.LastIndex(s.Table, "."); i >= 0 {
+		return s.Table[i+1:]
+	}
+	return s.Table
+}
+
+// auditColumns change on every load (file name, process date, ...) and are
+// ignored when deciding whether a matched row is actually unchanged.
+var auditColumns = map[string]bool{
+	"CORE_FILENAME":    true,
+	"CORE_PROCESSDATE": true,
+	"PROCESS_ID":       true,
+	"FILENAME":         true,
+	"LINENUMBER":       true,
+	"CDATE":            true,
+}
+
+// compareColumns are the columns that decide whether a matched row changed:
+// the ones an upsert updates, without the audit columns. A column outside
+// Update keeps its stored value, so a difference there changes nothing.
+func (s TableSpec) compareColumns() []string {
+	var out []string
+	for _, c := range s.UpdateColumns() {
+		if !auditColumns[c] {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func prefixed(prefix string, cols []string) []string {
+	out := make([]string, len(cols))
+	for i, c := range cols {
+		out[i] = prefix + c
+	}
+	return out
+}
+
+/* =========================
+   PROGRESS
+========================= */
+
+// progress feeds metrics.InsertedRows in chunks while rows are written.
+type progress struct {
+	pending int64
+}
+
+func (p *progress) add() {
+	p.pending++
+	if p.pending%1000 == 0 {
+		atomic.AddInt64(&metrics.InsertedRows, p.pending)
+		p.pending = 0
+	}
+}
+
+func (p *progress) flush() {
+	if p.pending > 0 {
+		atomic.AddInt64(&metrics.InsertedRows, p.pending)
+		p.pending = 0
+	}
+}
@@ -0,0 +1,53 @@
+package sink
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"go-import-file/internal/retry"
+)
+
+func TestCompareColumns(t *testing.T) {
+	cols := []Column{String("PCODE", 20), String("PNAME", 100), Int("QTY"), String("CORE_FILENAME", 255), DateTime("CORE_PROCESSDATE")}
+
+	tests := []struct {
+		name   string
+		update []string
+		want   []string
+	}{
+		{"all columns without audit", nil, []string{"PCODE", "PNAME", "QTY"}},
+		{"only updated columns", []string{"PNAME", "CORE_FILENAME"}, []string{"PNAME"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec := TableSpec{Table: "dbo.fmaster", Columns: cols, Keys: []string{"PCODE"}, Update: tt.update}
+			if got := spec.compareColumns(); !slices.Equal(got, tt.want) {
+				t.Errorf("compareColumns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// A deadlock a finalize procedure catches and returns as a row is retried
+// like one raised by the server.
+func TestProcedureErrorIsClassified(t *testing.T) {
+	tests := []struct {
+		number     int32
+		contention bool
+		lost       bool
+	}{
+		{1205, true, false},
+		{1222, true, false},
+		{40613, false, true},
+		{547, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprint(tt.number), func(t *testing.T) {
+			err := fmt.Errorf("FINALIZE MPRICE: %w", &ProcedureError{Name: "dbo.sp_finalize", Number: tt.number})
+			if retry.Contention(err) != tt.contention || retry.ConnectionLost(err) != tt.lost {
+				t.Errorf("contention %v lost %v, want %v %v", retry.Contention(err), retry.ConnectionLost(err), tt.contention, tt.lost)
+			}
+		})
+	}
+}
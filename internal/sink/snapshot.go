@@ -0,0 +1,186 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"go-import-file/internal/config"
+	"go-import-file/internal/dates"
+)
+
+// Snapshot modes: what Upsert does with target rows that are missing from
+// a full snapshot.
+const (
+	SnapshotDeactivate = config.SnapshotDeactivate // IS_ACTIVE = 0
+	SnapshotFlag       = config.SnapshotFlag       // DELETED_AT = now
+	SnapshotDelete     = config.SnapshotDelete
+)
+
+// Columns maintained by snapshot upserts, added by the migrations to the
+// master tables that support it. A row present in the snapshot again is
+// reactivated.
+const (
+	ActiveColumn  = "IS_ACTIVE"
+	DeletedColumn = "DELETED_AT"
+)
+
+// Snapshot makes Upsert treat the staged rows as the complete content of
+// the target, limited to the Scope values present in them.
+type Snapshot struct {
+	Mode string
+
+	// Scope is the column a file covers, e.g. KODECABANG: rows of other
+	// branches are left alone. Empty means the whole table.
+	Scope string
+
+	// MaxRatio is the largest share of the live rows in scope one run may
+	// retire; above it the whole upsert is rolled back.
+	MaxRatio float64
+}
+
+// reviveSet is the assignment that marks a matched row live again, or ""
+// when the mode keeps no marker.
+func (s *Snapshot) reviveSet(ident func(string) string) string {
+	if s == nil {
+		return ""
+	}
+	switch s.Mode {
+	case SnapshotDeactivate:
+		return ident(ActiveColumn) + " = 1"
+	case SnapshotFlag:
+		return ident(DeletedColumn) + " = NULL"
+	}
+	return ""
+}
+
+// retireMissing applies spec.Snapshot after the rows of tempTable were
+// merged into target, in the same transaction. With spec.History the open
+// versions of the retired rows in historyTable are closed. ident quotes
+// identifiers for the driver.
+func retireMissing(
+	ctx context.Context,
+	tx *sql.Tx,
+	driver, target, historyTable, tempTable string,
+	spec TableSpec,
+	ident func(string) string,
+) (int64, error) {
+	snap := spec.Snapshot
+	if snap == nil {
+		return 0, nil
+	}
+
+	// An empty file is not a snapshot of anything.
+	var staged int64
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM "+tempTable).Scan(&staged); err != nil {
+		return 0, err
+	}
+	if staged == 0 {
+		return 0, nil
+	}
+
+	keyConds := make([]string, len(spec.Keys))
+	for i, k := range spec.Keys {
+		keyConds[i] = "src." + ident(k) + " = tgt." + ident(k)
+	}
+	missing := "NOT EXISTS (SELECT 1 FROM " + tempTable + " AS src WHERE " + strings.Join(keyConds, " AND ") + ")"
+
+	var where []string
+	if snap.Scope != "" {
+		where = append(where, "tgt."+ident(snap.Scope)+" IN (SELECT src."+ident(snap.Scope)+" FROM "+tempTable+" AS src)")
+	}
+	switch snap.Mode {
+	case SnapshotDeactivate:
+		where = append(where, "tgt."+ident(ActiveColumn)+" = 1")
+	case SnapshotFlag:
+		where = append(where, "tgt."+ident(DeletedColumn)+" IS NULL")
+	}
+	live := "1 = 1"
+	if len(where) > 0 {
+		live = strings.Join(where, " AND ")
+	}
+
+	var total, retire int64
+	err := tx.QueryRowContext(ctx, `
+		SELECT
+			COUNT(*),
+			COALESCE(SUM(CASE WHEN `+missing+` THEN 1 ELSE 0 END), 0)
+		FROM `+target+` AS tgt
+		WHERE `+live,
+	).Scan(&total, &retire)
+	if err != nil {
+		return 0, fmt.Errorf("count missing rows: %w", err)
+	}
+	if retire == 0 {
+		return 0, nil
+	}
+
+	if ratio := float64(retire) / float64(total); ratio > snap.MaxRatio {
+		return 0, fmt.Errorf(
+			"snapshot would %s %d of %d rows in %s (%.1f%%), above max_ratio %.1f%%",
+			snap.Mode, retire, total, spec.Table, ratio*100, snap.MaxRatio*100,
+		)
+	}
+
+	cond := live + " AND " + missing
+
+	if spec.History != nil {
+		if err := closeRetiredHistory(ctx, tx, driver, target, historyTable, cond, spec, ident); err != nil {
+			return 0, err
+		}
+	}
+
+	var set string
+	switch snap.Mode {
+	case SnapshotDeactivate:
+		set = ident(ActiveColumn) + " = 0"
+	case SnapshotFlag:
+		set = ident(DeletedColumn) + " = CURRENT_TIMESTAMP"
+	}
+
+	var q string
+	switch {
+	case snap.Mode == SnapshotDelete && driver == DriverSQLServer:
+		q = "DELETE tgt FROM " + target + " AS tgt WHERE " + cond
+	case snap.Mode == SnapshotDelete:
+		q = "DELETE FROM " + target + " AS tgt WHERE " + cond
+	case driver == DriverSQLServer:
+		q = "UPDATE tgt SET " + set + " FROM " + target + " AS tgt WHERE " + cond
+	default:
+		q = "UPDATE " + target + " AS tgt SET " + set + " WHERE " + cond
+	}
+
+	r, err := tx.ExecContext(ctx, q)
+	if err != nil {
+		return 0, fmt.Errorf("%s missing rows: %w", snap.Mode, err)
+	}
+	return r.RowsAffected()
+}
+
+// closeRetiredHistory ends the open history versions of the target rows
+// matching cond, before they are retired.
+func closeRetiredHistory(
+	ctx context.Context,
+	tx *sql.Tx,
+	driver, target, historyTable, cond string,
+	spec TableSpec,
+	ident func(string) string,
+) error {
+	keyConds := make([]string, len(spec.Keys))
+	for i, k := range spec.Keys {
+		keyConds[i] = "h." + ident(k) + " = tgt." + ident(k)
+	}
+	where := " WHERE h." + ident(ValidToColumn) + " IS NULL AND EXISTS (SELECT 1 FROM " + target + " AS tgt WHERE " +
+		strings.Join(keyConds, " AND ") + " AND " + cond + ")"
+
+	q := "UPDATE " + historyTable + " AS h SET " + ident(ValidToColumn) + " = " + placeholder(driver, 1) + where
+	if driver == DriverSQLServer {
+		q = "UPDATE h SET " + ident(ValidToColumn) + " = " + placeholder(driver, 1) + " FROM " + historyTable + " AS h" + where
+	}
+
+	if _, err := tx.ExecContext(ctx, q, dates.Now()); err != nil {
+		return fmt.Errorf("close history versions of retired rows: %w", err)
+	}
+	return nil
+}
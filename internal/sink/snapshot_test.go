@@ -0,0 +1,174 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// custSpec is an MCUST-like table: a snapshot per branch, keyed by
+// KODECABANG.
+func custSpec(mode string, maxRatio float64) TableSpec {
+	return TableSpec{
+		Table:    "dbo.mcust_test",
+		Columns:  []Column{String("KODECABANG", 10), String("CUSTNO", 20), String("NAME", 100)},
+		Keys:     []string{"KODECABANG", "CUSTNO"},
+		Snapshot: &Snapshot{Mode: mode, Scope: "KODECABANG", MaxRatio: maxRatio},
+	}
+}
+
+func openSQLite(t *testing.T) *SQLite {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "sink.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+	return NewSQLite(db)
+}
+
+func upsert(t *testing.T, s *SQLite, spec TableSpec, rows ...[]any) (Result, error) {
+	t.Helper()
+	ch := make(chan []any, len(rows))
+	for _, r := range rows {
+		ch <- r
+	}
+	close(ch)
+	return s.Upsert(context.Background(), spec, ch)
+}
+
+func mustUpsert(t *testing.T, s *SQLite, spec TableSpec, rows ...[]any) Result {
+	t.Helper()
+	res, err := upsert(t, s, spec, rows...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return res
+}
+
+func count(t *testing.T, s *SQLite, q string) int {
+	t.Helper()
+	var n int
+	if err := s.DB().QueryRow(q).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestSnapshotRetiresMissingRows(t *testing.T) {
+	tests := []struct {
+		mode        string
+		retired     string // marks a retired row
+		live        string // marks a live row
+		wantRetired int    // retired C2 rows left in the table
+	}{
+		{SnapshotDeactivate, "IS_ACTIVE = 0", "IS_ACTIVE = 1", 1},
+		{SnapshotFlag, "DELETED_AT IS NOT NULL", "DELETED_AT IS NULL", 1},
+		{SnapshotDelete, "1 = 1", "1 = 1", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			s := openSQLite(t)
+			spec := custSpec(tt.mode, 1)
+
+			mustUpsert(t, s, spec,
+				[]any{"A", "C1", "one"}, []any{"A", "C2", "two"}, []any{"B", "C3", "three"})
+			res := mustUpsert(t, s, spec, []any{"A", "C1", "one"})
+
+			if res.Retired != 1 {
+				t.Errorf("retired %d rows, want 1", res.Retired)
+			}
+			if n := count(t, s, "SELECT COUNT(*) FROM mcust_test WHERE CUSTNO = 'C2' AND "+tt.retired); n != tt.wantRetired {
+				t.Errorf("%d retired C2 rows, want %d", n, tt.wantRetired)
+			}
+			if n := count(t, s, "SELECT COUNT(*) FROM mcust_test WHERE CUSTNO = 'C2' AND "+tt.live); n != 0 {
+				t.Errorf("C2 is still live")
+			}
+			// Branch B is not in the file: its rows are left alone.
+			if n := count(t, s, "SELECT COUNT(*) FROM mcust_test WHERE CUSTNO IN ('C1', 'C3') AND "+tt.live); n != 2 {
+				t.Errorf("%d of C1, C3 live, want 2", n)
+			}
+		})
+	}
+}
+
+func TestSnapshotRevivesReturningRow(t *testing.T) {
+	s := openSQLite(t)
+	spec := custSpec(SnapshotDeactivate, 1)
+
+	mustUpsert(t, s, spec, []any{"A", "C1", "one"}, []any{"A", "C2", "two"})
+	mustUpsert(t, s, spec, []any{"A", "C1", "one"})
+	res := mustUpsert(t, s, spec, []any{"A", "C1", "one"}, []any{"A", "C2", "two"})
+
+	if res.Retired != 0 {
+		t.Errorf("retired %d rows, want 0", res.Retired)
+	}
+	if n := count(t, s, "SELECT COUNT(*) FROM mcust_test WHERE IS_ACTIVE = 1"); n != 2 {
+		t.Errorf("%d live rows, want 2", n)
+	}
+}
+
+func TestSnapshotEmptyFileRetiresNothing(t *testing.T) {
+	s := openSQLite(t)
+	spec := custSpec(SnapshotDelete, 1)
+
+	mustUpsert(t, s, spec, []any{"A", "C1", "one"})
+	res := mustUpsert(t, s, spec)
+
+	if res.Retired != 0 {
+		t.Errorf("retired %d rows, want 0", res.Retired)
+	}
+	if n := count(t, s, "SELECT COUNT(*) FROM mcust_test"); n != 1 {
+		t.Errorf("%d rows, want 1", n)
+	}
+}
+
+func TestSnapshotAboveMaxRatioRollsBack(t *testing.T) {
+	s := openSQLite(t)
+	spec := custSpec(SnapshotDeactivate, 0.5)
+
+	mustUpsert(t, s, spec, []any{"A", "C1", "one"}, []any{"A", "C2", "two"}, []any{"A", "C3", "three"})
+	_, err := upsert(t, s, spec, []any{"A", "C1", "renamed"})
+
+	if err == nil || !strings.Contains(err.Error(), "above max_ratio") {
+		t.Fatalf("err = %v, want above max_ratio", err)
+	}
+	// The merge of the same run is rolled back too.
+	if n := count(t, s, "SELECT COUNT(*) FROM mcust_test WHERE IS_ACTIVE = 1 AND NAME <> 'renamed'"); n != 3 {
+		t.Errorf("%d untouched live rows, want 3", n)
+	}
+}
+
+func TestSnapshotClosesHistoryOfRetiredRows(t *testing.T) {
+	for _, mode := range []string{SnapshotDeactivate, SnapshotFlag, SnapshotDelete} {
+		t.Run(mode, func(t *testing.T) {
+			s := openSQLite(t)
+			spec := custSpec(mode, 1)
+			spec.History = &History{ProcessID: "p1"}
+
+			mustUpsert(t, s, spec, []any{"A", "C1", "one"}, []any{"A", "C2", "two"}, []any{"B", "C3", "three"})
+			mustUpsert(t, s, spec, []any{"A", "C1", "one"})
+
+			if n := count(t, s, "SELECT COUNT(*) FROM mcust_test_history WHERE CUSTNO = 'C2' AND VALID_TO IS NULL"); n != 0 {
+				t.Errorf("retired C2 has %d open versions, want 0", n)
+			}
+			if n := count(t, s, "SELECT COUNT(*) FROM mcust_test_history WHERE VALID_TO IS NULL"); n != 2 {
+				t.Errorf("%d open versions, want C1 and C3", n)
+			}
+
+			// A row that comes back opens a new version.
+			mustUpsert(t, s, spec, []any{"A", "C1", "one"}, []any{"A", "C2", "two"})
+			if n := count(t, s, "SELECT COUNT(*) FROM mcust_test_history WHERE CUSTNO = 'C2'"); n != 2 {
+				t.Errorf("C2 has %d versions, want 2", n)
+			}
+			if n := count(t, s, "SELECT COUNT(*) FROM mcust_test_history WHERE CUSTNO = 'C2' AND VALID_TO IS NULL"); n != 1 {
+				t.Errorf("revived C2 has %d open versions, want 1", n)
+			}
+		})
+	}
+}
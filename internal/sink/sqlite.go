@@ -0,0 +1,464 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SQLite is meant for local development and CI: tables are created from the
+// TableSpecs on first use, so a fresh database file is enough to run a full
+// import of fixture files. Schemas are dropped (dbo.fcustmst -> fcustmst).
+//
+// SQLite allows one writer at a time, so every load first drains its rows
+// into memory and only then takes the connection. Holding the connection
+// while waiting for rows could block the parsers feeding other writers.
+type SQLite struct {
+	db *sql.DB
+}
+
+func NewSQLite(db *sql.DB) *SQLite {
+	return &SQLite{db: db}
+}
+
+func (s *SQLite) Driver() string { return DriverSQLite }
+func (s *SQLite) DB() *sql.DB    { return s.db }
+
+func sqliteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func sqliteIdents(prefix string, cols []string) []string {
+	out := make([]string, len(cols))
+	for i, c := range cols {
+		out[i] = prefix + sqliteIdent(c)
+	}
+	return out
+}
+
+func (s *SQLite) table(spec TableSpec) string {
+	return sqliteIdent(spec.BareName())
+}
+
+func drain(rows <-chan []any) [][]any {
+	var out [][]any
+	for row := range rows {
+		out = append(out, row)
+	}
+	return out
+}
+
+/* =========================
+   SCHEMA
+========================= */
+
+func sqliteType(c Column) string {
+	switch c.Type {
+	case TypeInt, TypeBigInt:
+		return "INTEGER"
+	case TypeFloat:
+		return "REAL"
+	case TypeDecimal:
+		return fmt.Sprintf("NUMERIC(%d,%d)", c.Precision, c.Scale)
+	case TypeDate:
+		return "DATE"
+	case TypeDateTime:
+		return "DATETIME"
+	}
+	if c.Size <= 0 {
+		return "TEXT"
+	}
+	return fmt.Sprintf("VARCHAR(%d)", c.Size)
+}
+
+func (s *SQLite) columnDefs(spec TableSpec) string {
+	defs := make([]string, len(spec.Columns))
+	for i, c := range spec.Columns {
+		defs[i] = "\t" + sqliteIdent(c.Name) + " " + sqliteType(c)
+	}
+	return strings.Join(defs, ",\n")
+}
+
+// snapshotDefs are the marker columns of a snapshot table, which the
+// writers do not fill themselves.
+func (s *SQLite) snapshotDefs(spec TableSpec) string {
+	if spec.Snapshot == nil {
+		return ""
+	}
+	return ",\n\t" + sqliteIdent(ActiveColumn) + " INTEGER NOT NULL DEFAULT 1" +
+		",\n\t" + sqliteIdent(DeletedColumn) + " DATETIME"
+}
+
+func (s *SQLite) historyTable(spec TableSpec) string {
+	return sqliteIdent(spec.BareName() + "_history")
+}
+
+func (s *SQLite) historyDefs(spec TableSpec) string {
+	defs := ",\n\t" + sqliteIdent(ValidFromColumn) + " DATETIME NOT NULL" +
+		",\n\t" + sqliteIdent(ValidToColumn) + " DATETIME"
+	if _, withProcessID := spec.historyColumns(); withProcessID {
+		defs += ",\n\t" + sqliteIdent(ProcessIDColumn) + " VARCHAR(36)"
+	}
+	return defs
+}
+
+// ensureTable creates the target table, plus a unique index on spec.Keys
+// that ON CONFLICT needs.
+func (s *SQLite) ensureTable(ctx context.Context, tx *sql.Tx, spec TableSpec) error {
+	ddl := "CREATE TABLE IF NOT EXISTS " + s.table(spec) + " (\n" + s.columnDefs(spec) + s.snapshotDefs(spec) + "\n)"
+	if _, err := tx.ExecContext(ctx, ddl); err != nil {
+		return fmt.Errorf("create %s: %w", spec.BareName(), err)
+	}
+
+	if spec.History != nil {
+		ddl := "CREATE TABLE IF NOT EXISTS " + s.historyTable(spec) + " (\n" + s.columnDefs(spec) + s.historyDefs(spec) + "\n)"
+		if _, err := tx.ExecContext(ctx, ddl); err != nil {
+			return fmt.Errorf("create %s: %w", spec.HistoryTable(), err)
+		}
+	}
+
+	if len(spec.Keys) == 0 {
+		return nil
+	}
+
+	index := "CREATE UNIQUE INDEX IF NOT EXISTS " + sqliteIdent("ux_"+spec.BareName()) +
+		" ON " + s.table(spec) + " (" + strings.Join(sqliteIdents("", spec.Keys), ", ") + ")"
+	if _, err := tx.ExecContext(ctx, index); err != nil {
+		return fmt.Errorf("create index on %s: %w", spec.BareName(), err)
+	}
+	return nil
+}
+
+func (s *SQLite) insertRows(ctx context.Context, tx *sql.Tx, table string, cols []string, rows [][]any) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(cols)), ", ")
+
+	stmt, err := tx.PrepareContext(ctx,
+		"INSERT INTO "+table+" ("+strings.Join(sqliteIdents("", cols), ", ")+") VALUES ("+placeholders+")",
+	)
+	if err != nil {
+		return fmt.Errorf("prepare: %w", err)
+	}
+	defer stmt.Close()
+
+	var p progress
+	defer p.flush()
+
+	for i, row := range rows {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return fmt.Errorf(
+				"exec failed at row #%d\nColumns: %v\nValues : %#v\nError  : %w",
+				i+1, cols, row, err,
+			)
+		}
+		p.add()
+	}
+	return nil
+}
+
+/* =========================
+   BULK LOAD
+========================= */
+
+func (s *SQLite) BulkLoad(ctx context.Context, spec TableSpec, rows <-chan []any) (Result, error) {
+	var res Result
+
+	data := drain(rows)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return res, fmt.Errorf("begin tx: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := s.ensureTable(ctx, tx, spec); err != nil {
+		return res, err
+	}
+
+	if err := s.insertRows(ctx, tx, s.table(spec), spec.ColumnNames(), data); err != nil {
+		return res, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return res, fmt.Errorf("commit: %w", err)
+	}
+
+	res.Rows = int64(len(data))
+	res.Inserted = res.Rows
+	return res, nil
+}
+
+func (s *SQLite) BulkLoadBatches(ctx context.Context, spec TableSpec, rows <-chan []any, b Batch) (Result, error) {
+	return loadBatches(ctx, s, spec, rows, b)
+}
+
+func (s *SQLite) begin(ctx context.Context) (*sql.Tx, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	return tx, nil
+}
+
+// load is only called by loadBatches, which hands over a filled and
+// closed channel.
+func (s *SQLite) load(ctx context.Context, tx *sql.Tx, spec TableSpec, rows <-chan []any) (int64, error) {
+	if err := s.ensureTable(ctx, tx, spec); err != nil {
+		return 0, err
+	}
+
+	data := drain(rows)
+	if err := s.insertRows(ctx, tx, s.table(spec), spec.ColumnNames(), data); err != nil {
+		return 0, err
+	}
+	return int64(len(data)), nil
+}
+
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+func (s *SQLite) ensureCheckpointTable(ctx context.Context, db execer) error {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS import_checkpoint (
+			process_id VARCHAR(36) NOT NULL,
+			table_name VARCHAR(128) NOT NULL,
+			file_name VARCHAR(255) NOT NULL,
+			rows_committed INTEGER NOT NULL,
+			updated_at DATETIME NOT NULL,
+			PRIMARY KEY (process_id, table_name, file_name)
+		)`)
+	return err
+}
+
+func (s *SQLite) saveCheckpoint(ctx context.Context, tx *sql.Tx, processID, table, file string, rows int64) error {
+	if err := s.ensureCheckpointTable(ctx, tx); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO import_checkpoint (process_id, table_name, file_name, rows_committed, updated_at)
+		VALUES (?, ?, ?, ?, CURRENT_TIMESTAMP)
+		ON CONFLICT (process_id, table_name, file_name)
+		DO UPDATE SET rows_committed = excluded.rows_committed, updated_at = excluded.updated_at`,
+		processID, table, file, rows,
+	)
+	return err
+}
+
+func (s *SQLite) Checkpoints(ctx context.Context, processID, table string) (map[string]int64, error) {
+	if err := s.ensureCheckpointTable(ctx, s.db); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT file_name, rows_committed FROM import_checkpoint WHERE process_id = ? AND table_name = ?",
+		processID, table,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanCheckpoints(rows)
+}
+
+/* =========================
+   UPSERT VIA TEMP TABLE
+========================= */
+
+func (s *SQLite) Upsert(ctx context.Context, spec TableSpec, rows <-chan []any) (Result, error) {
+	var res Result
+
+	data := drain(rows)
+	res.Rows = int64(len(data))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback()
+
+	if err := s.ensureTable(ctx, tx, spec); err != nil {
+		return res, err
+	}
+
+	tempTable := sqliteIdent("tmp_" + spec.BareName())
+
+	if _, err := tx.ExecContext(ctx, "CREATE TEMP TABLE "+tempTable+" (\n"+s.columnDefs(spec)+"\n)"); err != nil {
+		return res, err
+	}
+
+	if err := s.insertRows(ctx, tx, tempTable, spec.ColumnNames(), data); err != nil {
+		return res, err
+	}
+
+	srcSQL := s.sourceSQL(tempTable, spec)
+
+	if err := recordHistory(ctx, tx, DriverSQLite, s.historyTable(spec), srcSQL, spec, sqliteIdent); err != nil {
+		return res, err
+	}
+
+	merged, err := s.upsertFromTemp(ctx, tx, spec, srcSQL)
+	if err != nil {
+		return res, err
+	}
+
+	merged.Retired, err = retireMissing(ctx, tx, DriverSQLite, s.table(spec), s.historyTable(spec), tempTable, spec, sqliteIdent)
+	if err != nil {
+		return res, err
+	}
+
+	if _, err := tx.ExecContext(ctx, "DROP TABLE "+tempTable); err != nil {
+		return res, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return res, err
+	}
+
+	merged.Rows = res.Rows
+	return merged, nil
+}
+
+// sourceSQL mirrors the SQL Server variant: DISTINCT rows, or the first
+// row per spec.Dedup partition.
+func (s *SQLite) sourceSQL(tempTable string, spec TableSpec) string {
+	cols := strings.Join(sqliteIdents("", spec.ColumnNames()), ", ")
+
+	if len(spec.Dedup) == 0 {
+		return "SELECT DISTINCT " + cols + " FROM " + tempTable
+	}
+
+	partition := strings.Join(sqliteIdents("", spec.Dedup), ", ")
+	return "SELECT " + cols + " FROM (" +
+		"SELECT DISTINCT ROW_NUMBER() OVER (PARTITION BY " + partition + " ORDER BY " + partition + ") AS RowNum, " + cols +
+		" FROM " + tempTable +
+		") WHERE RowNum = 1"
+}
+
+// upsertFromTemp counts matched and unchanged rows before the upsert, since
+// SQLite cannot aggregate RETURNING output.
+func (s *SQLite) upsertFromTemp(ctx context.Context, tx *sql.Tx, spec TableSpec, srcSQL string) (Result, error) {
+	var res Result
+
+	target := s.table(spec)
+
+	keyConds := make([]string, len(spec.Keys))
+	for i, k := range spec.Keys {
+		q := sqliteIdent(k)
+		keyConds[i] = "tgt." + q + " = src." + q
+	}
+
+	same := "0"
+	if cmp := spec.compareColumns(); len(cmp) > 0 {
+		conds := make([]string, len(cmp))
+		for i, c := range cmp {
+			q := sqliteIdent(c)
+			conds[i] = "src." + q + " IS tgt." + q
+		}
+		same = strings.Join(conds, " AND ")
+	}
+
+	keys := strings.Join(sqliteIdents("", spec.Keys), ", ")
+	srcKeys := strings.Join(sqliteIdents("src.", spec.Keys), ", ")
+	join := " FROM src INNER JOIN " + target + " AS tgt ON " + strings.Join(keyConds, " AND ")
+
+	// Keys are counted once: staged rows sharing a key end up as one row.
+	countSQL := `
+		WITH src AS (` + srcSQL + `)
+		SELECT
+			(SELECT COUNT(*) FROM (SELECT DISTINCT ` + keys + ` FROM src)),
+			(SELECT COUNT(*) FROM (SELECT DISTINCT ` + srcKeys + join + `)),
+			(SELECT COUNT(*)` + join + ` WHERE ` + same + `)`
+
+	var total, matched int64
+	if err := tx.QueryRowContext(ctx, countSQL).Scan(&total, &matched, &res.Unchanged); err != nil {
+		return Result{}, err
+	}
+
+	cols := sqliteIdents("", spec.ColumnNames())
+
+	sets := make([]string, 0, len(cols))
+	for _, c := range spec.UpdateColumns() {
+		q := sqliteIdent(c)
+		sets = append(sets, q+" = excluded."+q)
+	}
+	if revive := spec.Snapshot.reviveSet(sqliteIdent); revive != "" {
+		sets = append(sets, revive)
+	}
+
+	// WHERE true keeps the parser from reading ON CONFLICT as a join clause.
+	upsertSQL := `
+		WITH src AS (` + srcSQL + `)
+		INSERT INTO ` + target + ` (` + strings.Join(cols, ", ") + `)
+		SELECT ` + strings.Join(cols, ", ") + ` FROM src WHERE true
+		ON CONFLICT (` + strings.Join(sqliteIdents("", spec.Keys), ", ") + `)
+		DO UPDATE SET ` + strings.Join(sets, ", ")
+
+	if _, err := tx.ExecContext(ctx, upsertSQL); err != nil {
+		return Result{}, err
+	}
+
+	res.Inserted = total - matched
+	res.Updated = matched - res.Unchanged
+	if res.Updated < 0 {
+		res.Updated = 0
+	}
+
+	return res, nil
+}
+
+func (s *SQLite) AsOf(ctx context.Context, spec TableSpec, t time.Time) (*sql.Rows, error) {
+	return s.db.QueryContext(ctx, asOfSQL(DriverSQLite, s.historyTable(spec), sqliteIdent), t)
+}
+
+func (s *SQLite) Select(ctx context.Context, spec TableSpec) (*sql.Rows, error) {
+	cols := sqliteIdents("", spec.ColumnNames())
+	return s.db.QueryContext(ctx, "SELECT "+strings.Join(cols, ", ")+" FROM "+s.table(spec))
+}
+
+func (s *SQLite) Dangling(ctx context.Context, ref Reference, processID string, samples int) (Dangling, error) {
+	table := func(t string) string { return s.table(TableSpec{Table: t}) }
+	return dangling(ctx, s.db, DriverSQLite, table, sqliteIdent, ref, processID, samples)
+}
+
+/* =========================
+   TRUNCATE / PROCEDURES
+========================= */
+
+// Truncate empties the tables that exist; a table that was never loaded
+// has nothing to truncate.
+func (s *SQLite) Truncate(ctx context.Context, tables ...string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, t := range tables {
+		name := TableSpec{Table: t}.BareName()
+
+		var n int
+		if err := tx.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name,
+		).Scan(&n); err != nil {
+			return err
+		}
+		if n == 0 {
+			continue
+		}
+
+		if _, err := tx.ExecContext(ctx, "DELETE FROM "+sqliteIdent(name)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *SQLite) ExecProcedure(ctx context.Context, name string, args ...sql.NamedArg) error {
+	return fmt.Errorf("execute %s failed: sqlite has no stored procedures", name)
+}
@@ -0,0 +1,502 @@
+package sink
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	mssql "github.com/microsoft/go-mssqldb"
+
+	"go-import-file/internal/retry"
+)
+
+type SQLServer struct {
+	db    *sql.DB
+	retry retry.Policy
+}
+
+// NewSQLServer retries upsert merges that fail with a transient error
+// according to policy.
+func NewSQLServer(db *sql.DB, policy retry.Policy) *SQLServer {
+	return &SQLServer{db: db, retry: policy}
+}
+
+func (s *SQLServer) Driver() string { return DriverSQLServer }
+func (s *SQLServer) DB() *sql.DB    { return s.db }
+
+/* =========================
+   BULK LOAD
+========================= */
+
+func (s *SQLServer) BulkLoad(ctx context.Context, spec TableSpec, rows <-chan []any) (Result, error) {
+	var res Result
+
+	tx, err := s.begin(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer tx.Rollback()
+
+	n, err := s.load(ctx, tx, spec, rows)
+	if err != nil {
+		return res, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return res, fmt.Errorf("commit: %w", err)
+	}
+
+	res.Rows = n
+	res.Inserted = n
+	return res, nil
+}
+
+func (s *SQLServer) BulkLoadBatches(ctx context.Context, spec TableSpec, rows <-chan []any, b Batch) (Result, error) {
+	return loadBatches(ctx, s, spec, rows, b)
+}
+
+func (s *SQLServer) begin(ctx context.Context) (*sql.Tx, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("begin tx: %w", err)
+	}
+	return tx, nil
+}
+
+func (s *SQLServer) load(ctx context.Context, tx *sql.Tx, spec TableSpec, rows <-chan []any) (int64, error) {
+	cols := spec.ColumnNames()
+
+	stmt, err := tx.Prepare(mssql.CopyIn(spec.Table, mssql.BulkOptions{}, cols...))
+	if err != nil {
+		return 0, fmt.Errorf("prepare: %w", err)
+	}
+	defer stmt.Close()
+
+	return copyRows(ctx, stmt, cols, rows)
+}
+
+func (s *SQLServer) saveCheckpoint(ctx context.Context, tx *sql.Tx, processID, table, file string, rows int64) error {
+	_, err := tx.ExecContext(ctx, `
+		MERGE `+CheckpointTable+` WITH (HOLDLOCK) AS t
+		USING (SELECT @p1 AS process_id, @p2 AS table_name, @p3 AS file_name) AS s
+			ON t.process_id = s.process_id
+			AND t.table_name = s.table_name
+			AND t.file_name = s.file_name
+		WHEN MATCHED THEN
+			UPDATE SET rows_committed = @p4, updated_at = SYSDATETIME()
+		WHEN NOT MATCHED THEN
+			INSERT (process_id, table_name, file_name, rows_committed, updated_at)
+			VALUES (s.process_id, s.table_name, s.file_name, @p4, SYSDATETIME());`,
+		processID, table, file, rows,
+	)
+	return err
+}
+
+func (s *SQLServer) Checkpoints(ctx context.Context, processID, table string) (map[string]int64, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT file_name, rows_committed FROM "+CheckpointTable+" WHERE process_id = @p1 AND table_name = @p2",
+		processID, table,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return scanCheckpoints(rows)
+}
+
+// copyRows streams rows into a prepared CopyIn statement and flushes it.
+func copyRows(ctx context.Context, stmt *sql.Stmt, cols []string, rows <-chan []any) (int64, error) {
+	var (
+		rowNum int64
+		p      progress
+	)
+	defer p.flush()
+
+	for row := range rows {
+		select {
+		case <-ctx.Done():
+			return rowNum, ctx.Err()
+		default:
+		}
+
+		rowNum++
+		if _, err := stmt.Exec(row...); err != nil {
+			return rowNum, fmt.Errorf(
+				"exec failed at row #%d\nColumns: %v\nValues : %#v\nError  : %w",
+				rowNum, cols, row, err,
+			)
+		}
+		p.add()
+	}
+
+	if _, err := stmt.Exec(); err != nil {
+		return rowNum, fmt.Errorf("final exec: %w", err)
+	}
+	return rowNum, nil
+}
+
+/* =========================
+   UPSERT VIA TEMP TABLE
+========================= */
+
+// Upsert stages the rows in a temp table on a dedicated connection and
+// merges in a separate transaction. A merge that fails on a deadlock or
+// lock timeout is rolled back and replayed from the temp table, which
+// outlives the transaction; the channel is read only once. A lost
+// connection takes the temp table with it, so it fails the load instead.
+func (s *SQLServer) Upsert(ctx context.Context, spec TableSpec, rows <-chan []any) (Result, error) {
+	var res Result
+
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return res, err
+	}
+	defer conn.Close()
+
+	// === SQL Server safety & performance ===
+	if _, err := conn.ExecContext(ctx, `SET XACT_ABORT ON;`); err != nil {
+		return res, err
+	}
+
+	tempTable := "#tmp_" + spec.BareName()
+
+	if _, err := conn.ExecContext(ctx, s.tempTableDDL(tempTable, spec)); err != nil {
+		return res, err
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), `DROP TABLE IF EXISTS `+tempTable)
+
+	n, err := s.stage(ctx, conn, tempTable, spec, rows)
+	res.Rows = n
+	if err != nil {
+		return res, err
+	}
+
+	srcSQL := s.sourceSQL(tempTable, spec)
+
+	var merged Result
+	err = s.retry.DoWhen(ctx, "MERGE "+spec.Table, retry.Contention, func(ctx context.Context) error {
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		if err := recordHistory(ctx, tx, DriverSQLServer, spec.HistoryTable(), srcSQL, spec, sqlServerIdent); err != nil {
+			return err
+		}
+
+		merged, err = s.mergeFromTemp(ctx, tx, spec, srcSQL)
+		if err != nil {
+			return err
+		}
+
+		merged.Retired, err = retireMissing(ctx, tx, DriverSQLServer, spec.Table, spec.HistoryTable(), tempTable, spec, sqlServerIdent)
+		if err != nil {
+			return err
+		}
+		return tx.Commit()
+	})
+	if err != nil {
+		return res, err
+	}
+
+	merged.Rows = n
+	return merged, nil
+}
+
+// stage bulk copies the rows into the temp table and commits, so the
+// staged rows survive a rolled back merge.
+func (s *SQLServer) stage(ctx context.Context, conn *sql.Conn, tempTable string, spec TableSpec, rows <-chan []any) (int64, error) {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	cols := spec.ColumnNames()
+
+	stmt, err := tx.Prepare(mssql.CopyIn(tempTable, mssql.BulkOptions{}, cols...))
+	if err != nil {
+		return 0, err
+	}
+	defer stmt.Close()
+
+	n, err := copyRows(ctx, stmt, cols, rows)
+	if err != nil {
+		return n, err
+	}
+
+	return n, tx.Commit()
+}
+
+func (s *SQLServer) tempTableDDL(tempTable string, spec TableSpec) string {
+	defs := make([]string, len(spec.Columns))
+	for i, c := range spec.Columns {
+		defs[i] = "\t[" + c.Name + "] " + sqlServerType(c)
+	}
+	return "CREATE TABLE " + tempTable + " (\n" + strings.Join(defs, ",\n") + "\n)"
+}
+
+func sqlServerIdent(name string) string {
+	return "[" + name + "]"
+}
+
+func sqlServerType(c Column) string {
+	switch c.Type {
+	case TypeInt:
+		return "INT"
+	case TypeBigInt:
+		return "BIGINT"
+	case TypeFloat:
+		return "FLOAT"
+	case TypeDecimal:
+		return fmt.Sprintf("DECIMAL(%d,%d)", c.Precision, c.Scale)
+	case TypeDate:
+		return "DATE"
+	case TypeDateTime:
+		return "DATETIME2"
+	}
+	if c.Size <= 0 {
+		return "NVARCHAR(MAX)"
+	}
+	return fmt.Sprintf("NVARCHAR(%d)", c.Size)
+}
+
+// sourceSQL selects the staged rows to merge: DISTINCT rows, or the first
+// row per spec.Dedup partition.
+func (s *SQLServer) sourceSQL(tempTable string, spec TableSpec) string {
+	cols := spec.ColumnNames()
+
+	if len(spec.Dedup) == 0 {
+		return `
+			SELECT DISTINCT
+				` + strings.Join(cols, ", ") + `
+			FROM ` + tempTable
+	}
+
+	insertValsSQL := strings.Join(prefixed("src.", cols), ", ")
+	partition := strings.Join(prefixed("src.", spec.Dedup), ", ")
+
+	return `
+			SELECT
+				` + insertValsSQL + `
+			FROM (
+				SELECT DISTINCT
+					ROW_NUMBER() OVER (PARTITION BY ` + partition + ` ORDER BY ` + partition + `) AS RowNum,
+					` + insertValsSQL + `
+				FROM ` + tempTable + ` as src
+			) as src
+			WHERE src.RowNum = 1`
+}
+
+func joinCondition(spec TableSpec) string {
+	conds := make([]string, len(spec.Keys))
+	for i, k := range spec.Keys {
+		conds[i] = "tgt." + k + " = src." + k
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// mergeFromTemp merges srcSQL (a SELECT over the temp table) into the
+// target and aggregates OUTPUT $action the same way
+// importer.RunMPriceFinalize does. Matched rows whose non-audit columns are
+// identical are reported as unchanged instead of updated.
+func (s *SQLServer) mergeFromTemp(ctx context.Context, tx *sql.Tx, spec TableSpec, srcSQL string) (Result, error) {
+	cols := spec.ColumnNames()
+	join := joinCondition(spec)
+
+	sets := make([]string, 0, len(cols))
+	for _, c := range spec.UpdateColumns() {
+		sets = append(sets, "tgt."+c+" = src."+c)
+	}
+	if revive := spec.Snapshot.reviveSet(sqlServerIdent); revive != "" {
+		sets = append(sets, "tgt."+revive)
+	}
+
+	unchangedSQL := "SET @Unchanged = 0;"
+	if cmp := spec.compareColumns(); len(cmp) > 0 {
+		unchangedSQL = `
+		;WITH src AS (` + srcSQL + `
+		)
+		SELECT @Unchanged = COUNT(*)
+		FROM src
+		INNER JOIN ` + spec.Table + ` AS tgt
+			ON ` + join + `
+		WHERE EXISTS (
+			SELECT ` + strings.Join(prefixed("src.", cmp), ", ") + `
+			INTERSECT
+			SELECT ` + strings.Join(prefixed("tgt.", cmp), ", ") + `
+		);`
+	}
+
+	mergeSQL := `
+		SET NOCOUNT ON;
+
+		DECLARE @SummaryOfChanges TABLE (
+			ACTION VARCHAR(10)
+		);
+		DECLARE @Unchanged INT;
+		` + unchangedSQL + `
+
+		;WITH src AS (` + srcSQL + `
+		)
+		MERGE ` + spec.Table + ` WITH (HOLDLOCK) AS tgt
+		USING src
+			ON ` + join + `
+		WHEN MATCHED THEN
+			UPDATE SET ` + strings.Join(sets, ",\n\t\t\t\t") + `
+		WHEN NOT MATCHED BY TARGET THEN
+			INSERT (` + strings.Join(cols, ", ") + `)
+			VALUES (` + strings.Join(prefixed("src.", cols), ", ") + `)
+		OUTPUT $action INTO @SummaryOfChanges;
+
+		SELECT
+			ISNULL(SUM(CASE WHEN ACTION = 'INSERT' THEN 1 ELSE 0 END), 0),
+			ISNULL(SUM(CASE WHEN ACTION = 'UPDATE' THEN 1 ELSE 0 END), 0),
+			ISNULL(@Unchanged, 0)
+		FROM @SummaryOfChanges;
+	`
+
+	var (
+		res     Result
+		matched int64
+	)
+	if err := tx.QueryRowContext(ctx, mergeSQL).Scan(&res.Inserted, &matched, &res.Unchanged); err != nil {
+		return Result{}, err
+	}
+
+	res.Updated = matched - res.Unchanged
+	if res.Updated < 0 {
+		res.Updated = 0
+	}
+
+	return res, nil
+}
+
+func (s *SQLServer) AsOf(ctx context.Context, spec TableSpec, t time.Time) (*sql.Rows, error) {
+	return s.db.QueryContext(ctx, asOfSQL(DriverSQLServer, spec.HistoryTable(), sqlServerIdent), t)
+}
+
+func (s *SQLServer) Select(ctx context.Context, spec TableSpec) (*sql.Rows, error) {
+	cols := make([]string, len(spec.Columns))
+	for i, c := range spec.Columns {
+		cols[i] = sqlServerIdent(c.Name)
+	}
+	return s.db.QueryContext(ctx, "SELECT "+strings.Join(cols, ", ")+" FROM "+spec.Table)
+}
+
+func (s *SQLServer) Dangling(ctx context.Context, ref Reference, processID string, samples int) (Dangling, error) {
+	table := func(t string) string { return t }
+	return dangling(ctx, s.db, DriverSQLServer, table, sqlServerIdent, ref, processID, samples)
+}
+
+/* =========================
+   TRUNCATE / PROCEDURES
+========================= */
+
+func (s *SQLServer) Truncate(ctx context.Context, tables ...string) error {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{
+		Isolation: sql.LevelSerializable,
+	})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var b strings.Builder
+	for _, t := range tables {
+		b.WriteString("TRUNCATE TABLE " + t + ";\n")
+	}
+
+	if _, err := tx.ExecContext(ctx, b.String()); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// ExecProcedure runs a stored procedure in its own transaction. Procedures
+// that catch their own errors return one row of ERROR_NUMBER(),
+// ERROR_STATE(), ERROR_LINE() and ERROR_MESSAGE(); a non-zero number is
+// turned into an error and the transaction is rolled back.
+func (s *SQLServer) ExecProcedure(ctx context.Context, name string, args ...sql.NamedArg) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := ExecProcedureTx(ctx, tx, name, args...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// ExecProcedureTx runs a SQL Server stored procedure inside tx and leaves
+// the commit to the caller. Errors are reported as by ExecProcedure.
+func ExecProcedureTx(ctx context.Context, tx *sql.Tx, name string, args ...sql.NamedArg) error {
+	params := make([]string, len(args))
+	values := make([]any, len(args))
+	for i, a := range args {
+		params[i] = "@" + a.Name + " = @" + a.Name
+		values[i] = a
+	}
+
+	rows, err := tx.QueryContext(ctx, "EXEC "+name+" "+strings.Join(params, ", "), values...)
+	if err != nil {
+		return fmt.Errorf("execute %s failed: %w", name, err)
+	}
+
+	return procedureError(name, rows)
+}
+
+// ProcedureError is an error a procedure caught and returned as a row. It
+// exposes the server error number so retries can classify it.
+type ProcedureError struct {
+	Name    string
+	Number  int32
+	State   sql.NullInt64
+	Line    sql.NullInt64
+	Message string
+}
+
+func (e *ProcedureError) Error() string {
+	return fmt.Sprintf(
+		"%s failed | number=%d state=%v line=%v msg=%s",
+		e.Name, e.Number, e.State, e.Line, e.Message,
+	)
+}
+
+func (e *ProcedureError) SQLErrorNumber() int32 { return e.Number }
+
+func procedureError(name string, rows *sql.Rows) error {
+	defer rows.Close()
+
+	cols, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+	if len(cols) != 4 || !rows.Next() {
+		return rows.Err()
+	}
+
+	var (
+		number, state, line sql.NullInt64
+		message             sql.NullString
+	)
+	if err := rows.Scan(&number, &state, &line, &message); err != nil {
+		return fmt.Errorf("execute %s failed: %w", name, err)
+	}
+
+	if number.Valid && number.Int64 != 0 {
+		return &ProcedureError{
+			Name:    name,
+			Number:  int32(number.Int64),
+			State:   state,
+			Line:    line,
+			Message: message.String,
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,195 @@
+// Package uom converts prices and quantities along the unit ladder of an
+// SKU as fmaster stores it.
+//
+// UNIT1 is the largest unit and UNIT5 the smallest. CONVUNIT2 says how many
+// UNIT5 one UNIT4 holds, CONVUNIT3 how many UNIT4 one UNIT3 holds, and so
+// on up to CONVUNIT5 (UNIT2 per UNIT1). Levels number the ladder from the
+// smallest unit up, matching SELPRICE1..5:
+//
+//	level 1 = UNIT5
+//	level 2 = UNIT4, CONVUNIT2 x level 1
+//	level 3 = UNIT3, CONVUNIT3 x level 2
+//	level 4 = UNIT2, CONVUNIT4 x level 3
+//	level 5 = UNIT1, CONVUNIT5 x level 4
+package uom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"go-import-file/internal/model"
+)
+
+const Levels = 5
+
+var (
+	// ErrUnknownUnit means the unit is none of the SKU's units.
+	ErrUnknownUnit = errors.New("unit is not on the SKU's unit ladder")
+
+	// ErrNoConversion means a conversion factor on the way is missing or
+	// not positive.
+	ErrNoConversion = errors.New("no conversion factor between the units")
+)
+
+type Level struct {
+	Unit string
+
+	// Per is how many units of the level below one unit holds; 1 on
+	// level 1.
+	Per int
+}
+
+type Ladder struct {
+	Pcode  string
+	Levels [Levels]Level // index 0 is level 1, the smallest unit
+}
+
+// FromMsku builds the ladder of an MSKU row.
+func FromMsku(m model.Msku) Ladder {
+	return New(m.Pcode,
+		[Levels]string{m.Unit1, m.Unit2, m.Unit3, m.Unit4, m.Unit5},
+		[Levels - 1]int{m.Convunit2, m.Convunit3, m.Convunit4, m.Convunit5},
+	)
+}
+
+// New builds a ladder from UNIT1..5 and CONVUNIT2..5 in fmaster order.
+func New(pcode string, units [Levels]string, conv [Levels - 1]int) Ladder {
+	l := Ladder{Pcode: pcode}
+	for i := range Levels {
+		l.Levels[i].Unit = strings.TrimSpace(units[Levels-1-i])
+	}
+	l.Levels[0].Per = 1
+	for i := 1; i < Levels; i++ {
+		l.Levels[i].Per = conv[i-1]
+	}
+	return l
+}
+
+// Level returns the level (1..5) of unit. Like the old CASE ladders the
+// smallest matching unit wins when an SKU repeats a unit.
+func (l Ladder) Level(unit string) (int, error) {
+	unit = strings.TrimSpace(unit)
+	if unit != "" {
+		for i, lv := range l.Levels {
+			if strings.EqualFold(lv.Unit, unit) {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: PCODE %s unit %q", ErrUnknownUnit, l.Pcode, unit)
+}
+
+// Factor is how many units of level from one unit of level to holds, for
+// from <= to.
+func (l Ladder) Factor(from, to int) (int64, error) {
+	if from < 1 || to > Levels || from > to {
+		return 0, fmt.Errorf("invalid levels %d..%d", from, to)
+	}
+
+	f := int64(1)
+	for lv := from + 1; lv <= to; lv++ {
+		per := l.Levels[lv-1].Per
+		if per <= 0 {
+			return 0, fmt.Errorf(
+				"%w: PCODE %s %s -> %s (CONVUNIT%d = %d)",
+				ErrNoConversion, l.Pcode, l.Levels[lv-2].Unit, l.Levels[lv-1].Unit, lv, per,
+			)
+		}
+		f *= int64(per)
+	}
+	return f, nil
+}
+
+// ratio is how many from units one to unit holds, in either direction.
+func (l Ladder) ratio(from, to string) (float64, error) {
+	a, err := l.Level(from)
+	if err != nil {
+		return 0, err
+	}
+	b, err := l.Level(to)
+	if err != nil {
+		return 0, err
+	}
+
+	if a <= b {
+		f, err := l.Factor(a, b)
+		return float64(f), err
+	}
+	f, err := l.Factor(b, a)
+	return 1 / float64(f), err
+}
+
+// ConvertQty converts a quantity counted in from units into to units.
+func (l Ladder) ConvertQty(qty float64, from, to string) (float64, error) {
+	r, err := l.ratio(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return qty / r, nil
+}
+
+// ConvertPrice converts a price per from unit into the price per to unit.
+func (l Ladder) ConvertPrice(price float64, from, to string) (float64, error) {
+	r, err := l.ratio(from, to)
+	if err != nil {
+		return 0, err
+	}
+	return price * r, nil
+}
+
+// SellPrices returns SELPRICE1..5 for a price given per unit: the price of
+// the unit's level and of every larger unit, up to the first level without
+// a unit. Smaller units stay 0, as they always did in the finalize steps.
+func (l Ladder) SellPrices(price decimal.Decimal, unit string) ([Levels]decimal.Decimal, error) {
+	var out [Levels]decimal.Decimal
+
+	lv, err := l.Level(unit)
+	if err != nil {
+		return out, err
+	}
+
+	for to := lv; to <= Levels && l.Levels[to-1].Unit != ""; to++ {
+		f, err := l.Factor(lv, to)
+		if err != nil {
+			return [Levels]decimal.Decimal{}, err
+		}
+		out[to-1] = price.Mul(decimal.NewFromInt(f))
+	}
+	return out, nil
+}
+
+// ResolveMain picks the main unit of an SKU: the first of the configured
+// uomMain units ("PCS|BOX") found among units, else the last unit that is
+// set. position is the index in units, -1 when no unit is set.
+func ResolveMain(uomMain string, units []string) (unit string, position int) {
+	index := make(map[string]int)
+	lastValid := ""
+	lastIndex := -1
+
+	for i, u := range units {
+		u = strings.TrimSpace(u)
+		if u == "" {
+			continue
+		}
+		if _, exists := index[u]; !exists {
+			index[u] = i
+		}
+		lastValid = u
+		lastIndex = i
+	}
+
+	for _, m := range strings.Split(uomMain, "|") {
+		m = strings.TrimSpace(m)
+		if m == "" {
+			continue
+		}
+		if i, ok := index[m]; ok {
+			return m, i
+		}
+	}
+
+	return lastValid, lastIndex
+}
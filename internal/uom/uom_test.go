@@ -0,0 +1,170 @@
+package uom
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/shopspring/decimal"
+
+	"go-import-file/internal/model"
+)
+
+// carton is an SKU sold per PCS, BOX of 10 PCS and CTN of 4 BOX.
+var carton = FromMsku(model.Msku{
+	Pcode: "P1",
+	Unit1: "CTN", Unit2: "BOX", Unit3: "PCS",
+	Convunit4: 10, Convunit5: 4,
+})
+
+func TestLevel(t *testing.T) {
+	tests := []struct {
+		name   string
+		ladder Ladder
+		unit   string
+		want   int
+		err    error
+	}{
+		{"smallest unit", carton, "PCS", 3, nil},
+		{"largest unit", carton, "CTN", 5, nil},
+		{"case and spaces", carton, " box ", 4, nil},
+		{"repeated unit takes the smallest", New("P2", [Levels]string{"PCS", "PCS"}, [Levels - 1]int{0, 0, 0, 1}), "PCS", 4, nil},
+		{"unknown unit", carton, "KG", 0, ErrUnknownUnit},
+		{"empty unit", carton, "", 0, ErrUnknownUnit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ladder.Level(tt.unit)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if got != tt.want {
+				t.Errorf("Level(%q) = %d, want %d", tt.unit, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		name      string
+		ladder    Ladder
+		value     float64
+		from, to  string
+		wantQty   float64
+		wantPrice float64
+		err       error
+	}{
+		{"same unit", carton, 5, "PCS", "PCS", 5, 5, nil},
+		{"one level up", carton, 40, "PCS", "BOX", 4, 400, nil},
+		{"two levels up", carton, 40, "PCS", "CTN", 1, 1600, nil},
+		{"down the ladder", carton, 2, "CTN", "PCS", 80, 0.05, nil},
+		{"unknown unit", carton, 1, "PCS", "KG", 0, 0, ErrUnknownUnit},
+		{"missing factor", New("P3", [Levels]string{"CTN", "BOX", "PCS"}, [Levels - 1]int{0, 0, 0, 4}), 1, "PCS", "CTN", 0, 0, ErrNoConversion},
+		{"negative factor", New("P4", [Levels]string{"", "BOX", "PCS"}, [Levels - 1]int{0, 0, -10, 0}), 1, "BOX", "PCS", 0, 0, ErrNoConversion},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			qty, err := tt.ladder.ConvertQty(tt.value, tt.from, tt.to)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ConvertQty err = %v, want %v", err, tt.err)
+			}
+			price, err := tt.ladder.ConvertPrice(tt.value, tt.from, tt.to)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ConvertPrice err = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if qty != tt.wantQty {
+				t.Errorf("ConvertQty(%v, %s, %s) = %v, want %v", tt.value, tt.from, tt.to, qty, tt.wantQty)
+			}
+			if price != tt.wantPrice {
+				t.Errorf("ConvertPrice(%v, %s, %s) = %v, want %v", tt.value, tt.from, tt.to, price, tt.wantPrice)
+			}
+		})
+	}
+}
+
+func TestFactor(t *testing.T) {
+	tests := []struct {
+		name     string
+		from, to int
+		want     int64
+		wantErr  bool
+	}{
+		{"same level", 3, 3, 1, false},
+		{"one level", 3, 4, 10, false},
+		{"two levels", 3, 5, 40, false},
+		{"level 0", 0, 3, 0, true},
+		{"above the ladder", 3, 6, 0, true},
+		{"downwards", 5, 3, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := carton.Factor(tt.from, tt.to)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Factor(%d, %d) = %d, want %d", tt.from, tt.to, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSellPrices(t *testing.T) {
+	tests := []struct {
+		name   string
+		ladder Ladder
+		price  string
+		unit   string
+		want   [Levels]string
+		err    error
+	}{
+		{"from the smallest unit", carton, "1500", "PCS", [Levels]string{"0", "0", "1500", "15000", "60000"}, nil},
+		{"from a larger unit", carton, "15000", "BOX", [Levels]string{"0", "0", "0", "15000", "60000"}, nil},
+		// The ladder multiplies exactly, a price with 4 decimals keeps
+		// every one of them on every level.
+		{"no rounding", carton, "0.3333", "PCS", [Levels]string{"0", "0", "0.3333", "3.333", "13.332"}, nil},
+		{"stops at the first level without a unit", New("P5", [Levels]string{"", "BOX", "PCS"}, [Levels - 1]int{0, 0, 12, 0}), "2.5", "PCS", [Levels]string{"0", "0", "2.5", "30", "0"}, nil},
+		{"missing factor", New("P6", [Levels]string{"CTN", "BOX", "PCS"}, [Levels - 1]int{0, 0, 10, 0}), "1", "PCS", [Levels]string{"0", "0", "0", "0", "0"}, ErrNoConversion},
+		{"unknown unit", carton, "1", "KG", [Levels]string{"0", "0", "0", "0", "0"}, ErrUnknownUnit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ladder.SellPrices(decimal.RequireFromString(tt.price), tt.unit)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			for i := range Levels {
+				if want := decimal.RequireFromString(tt.want[i]); !got[i].Equal(want) {
+					t.Errorf("SELPRICE%d = %s, want %s", i+1, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestResolveMain(t *testing.T) {
+	tests := []struct {
+		name     string
+		uomMain  string
+		units    []string
+		wantUnit string
+		wantPos  int
+	}{
+		{"first configured unit", "PCS|BOX", []string{"CTN", "BOX", "PCS"}, "PCS", 2},
+		{"configured order wins", "BOX|PCS", []string{"CTN", "BOX", "PCS"}, "BOX", 1},
+		{"repeated unit keeps its first position", "PCS", []string{"PCS", "BOX", "PCS"}, "PCS", 0},
+		{"falls back to the last unit set", "KG", []string{"CTN", " BOX ", "", ""}, "BOX", 1},
+		{"no unit set", "PCS", []string{"", " "}, "", -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unit, pos := ResolveMain(tt.uomMain, tt.units)
+			if unit != tt.wantUnit || pos != tt.wantPos {
+				t.Errorf("ResolveMain(%q, %q) = %q, %d, want %q, %d", tt.uomMain, tt.units, unit, pos, tt.wantUnit, tt.wantPos)
+			}
+		})
+	}
+}
@@ -11,7 +11,8 @@ func EnsureDir(path string) error {
 	return os.MkdirAll(path, 0755)
 }
 
-func MoveFile(src, dstDir string) error {
+// MoveFile moves src into dstDir and returns its new path.
+func MoveFile(src, dstDir string) (string, error) {
 	base := filepath.Base(src)
 	dst := filepath.Join(dstDir, base)
 
@@ -25,5 +26,5 @@ func MoveFile(src, dstDir string) error {
 		)
 	}
 
-	return os.Rename(src, dst)
+	return dst, os.Rename(src, dst)
 }
@@ -1,9 +1,10 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
 	"go-import-file/internal/utils"
-	"time"
 )
 
 type Block01Handler struct {
@@ -16,7 +17,10 @@ func (h *Block01Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	ClimitVal, _ := utils.ParseAccountingInt(safe(fields, 11))
+	ClimitVal, err := safeDecimal(fields, 11, decimals.Accounting)
+	if err != nil {
+		return err
+	}
 	RppVal, _ := utils.ParseAccountingInt(safe(fields, 20))
 	LsalesVal, _ := utils.ParseAccountingInt(safe(fields, 21))
 
@@ -53,7 +57,7 @@ func (h *Block01Handler) Handle(
 		La:              safe(fields, 31),
 		Lg:              safe(fields, 32),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
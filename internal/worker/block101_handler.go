@@ -1,8 +1,7 @@
 package worker
 
 import (
-	"time"
-
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
 )
 
@@ -21,7 +20,7 @@ func (h *Block101Handler) Handle(
 		ProvinsiId:      safe(fields, 2),
 		ProvinsiName:    safe(fields, 3),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
@@ -1,8 +1,7 @@
 package worker
 
 import (
-	"time"
-
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
 )
 
@@ -22,7 +21,7 @@ func (h *Block102Handler) Handle(
 		DistrikName:     safe(fields, 3),
 		KodeCabang:      safe(fields, 2),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
@@ -1,8 +1,7 @@
 package worker
 
 import (
-	"time"
-
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
 )
 
@@ -23,7 +22,7 @@ func (h *Block104Handler) Handle(
 		RcRayonId:       safe(fields, 4),
 		RcRayonDesc:     safe(fields, 5),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
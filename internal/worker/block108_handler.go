@@ -1,8 +1,10 @@
 package worker
 
 import (
+	"fmt"
+
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
-	"time"
 )
 
 type Block108Handler struct {
@@ -15,10 +17,16 @@ func (h *Block108Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	TglOrderVal := safe(fields, 2)[:4] + "-" + safe(fields, 2)[4:6] + "-" + safe(fields, 2)[6:]
+	TglOrderVal, err := safeDate(fields, 2)
+	if err != nil {
+		return err
+	}
+	if !TglOrderVal.Valid {
+		return fmt.Errorf("field 2: order date is required")
+	}
 
 	h.Out <- model.MBackOrder{
-		TglOrder:        TglOrderVal,
+		TglOrder:        TglOrderVal.Time,
 		OrderNo:         safe(fields, 3),
 		SlsNo:           safe(fields, 4),
 		CustNo:          safe(fields, 5),
@@ -28,7 +36,7 @@ func (h *Block108Handler) Handle(
 		Status:          safe(fields, 9),
 		StatusDetail:    safe(fields, 10),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
@@ -1,8 +1,7 @@
 package worker
 
 import (
-	"time"
-
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
 )
 
@@ -23,7 +22,7 @@ func (h *Block109Handler) Handle(
 		DescCustNoShip:  safe(fields, 4),
 		Kodecabang:      safe(fields, 5),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
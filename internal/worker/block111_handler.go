@@ -1,8 +1,8 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
-	"time"
 )
 
 type Block111Handler struct {
@@ -25,7 +25,7 @@ func (h *Block111Handler) Handle(
 		CustName:        safe(fields, 11),
 		InvTotal:        safe(fields, 12),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
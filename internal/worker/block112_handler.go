@@ -1,9 +1,9 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
-	"go-import-file/internal/utils"
-	"time"
 )
 
 type Block112Handler struct {
@@ -16,10 +16,22 @@ func (h *Block112Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	InvAmountVal, _ := utils.ParseNumber(safe(fields, 15))
-	InvOutStandingVal, _ := utils.ParseNumber(safe(fields, 16))
-	InvDateVal := safe(fields, 13)[:4] + "-" + safe(fields, 13)[4:6] + "-" + safe(fields, 13)[6:]
-	DueDateVal := safe(fields, 14)[:4] + "-" + safe(fields, 14)[4:6] + "-" + safe(fields, 14)[6:]
+	InvAmountVal, err := safeDecimal(fields, 15, decimals.Number)
+	if err != nil {
+		return err
+	}
+	InvOutStandingVal, err := safeDecimal(fields, 16, decimals.Number)
+	if err != nil {
+		return err
+	}
+	InvDateVal, err := safeDate(fields, 13)
+	if err != nil {
+		return err
+	}
+	DueDateVal, err := safeDate(fields, 14)
+	if err != nil {
+		return err
+	}
 
 	h.Out <- model.McustInvD{
 		Bid:             safe(fields, 6),
@@ -34,7 +46,7 @@ func (h *Block112Handler) Handle(
 		InvAmount:       InvAmountVal,
 		InvOutStanding:  InvOutStandingVal,
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
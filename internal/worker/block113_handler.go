@@ -1,9 +1,9 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
-	"go-import-file/internal/utils"
-	"time"
 )
 
 type Block113Handler struct {
@@ -16,8 +16,15 @@ func (h *Block113Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	PriceValueVal, _ := utils.ParseNumber(safe(fields, 4))
-	now := time.Now()
+	PriceValueVal, err := safeDecimal(fields, 4, decimals.Number)
+	if err != nil {
+		return err
+	}
+	sell, err := job.SellPrices(safe(fields, 3), PriceValueVal, safe(fields, 5))
+	if err != nil {
+		return err
+	}
+	now := dates.Now()
 	by := "system"
 
 	h.Out <- model.MkplPrice{
@@ -27,13 +34,18 @@ func (h *Block113Handler) Handle(
 		Pcode:           safe(fields, 3),
 		PriceValue:      PriceValueVal,
 		PriceUom:        safe(fields, 5),
+		SellPrice1:      sell[0],
+		SellPrice2:      sell[1],
+		SellPrice3:      sell[2],
+		SellPrice4:      sell[3],
+		SellPrice5:      sell[4],
 		BranchID:        safe(fields, 6),
 		Cby:             by,
 		Cdate:           now,
 		Mby:             by,
 		Mdate:           now,
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
@@ -1,9 +1,8 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
-
-	"time"
 )
 
 type Block120Handler struct {
@@ -16,13 +15,16 @@ func (h *Block120Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	now := time.Now()
-
-	ValidFrom := safe(fields, 13)
-	ValidUntil := safe(fields, 12)
+	now := dates.Now()
 
-	Validf, _ := time.Parse("20060102", ValidFrom)
-	Validt, _ := time.Parse("20060102", ValidUntil)
+	ValidFromVal, err := safeDate(fields, 13)
+	if err != nil {
+		return err
+	}
+	ValidUntilVal, err := safeDate(fields, 12)
+	if err != nil {
+		return err
+	}
 
 	ConditionTypeVal := safe(fields, 2)
 	KeyCombinationVal := safe(fields, 3)
@@ -51,8 +53,8 @@ func (h *Block120Handler) Handle(
 		Customer:            CustomerVal,
 		Material:            safe(fields, 10),
 		Attribut2:           Attribut2Val,
-		ValidUntil:          Validt,
-		ValidFrom:           Validf,
+		ValidUntil:          ValidUntilVal,
+		ValidFrom:           ValidFromVal,
 		ConditionRecordno:   safe(fields, 14),
 		Scale:               safe(fields, 15),
 		FileName:            job.FileName,
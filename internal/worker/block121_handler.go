@@ -1,9 +1,8 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
-
-	"time"
 )
 
 type Block121Handler struct {
@@ -16,13 +15,16 @@ func (h *Block121Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	now := time.Now()
-
-	ValidFrom := safe(fields, 18)
-	ValidUntil := safe(fields, 19)
+	now := dates.Now()
 
-	Validf, _ := time.Parse("20060102", ValidFrom)
-	Validt, _ := time.Parse("20060102", ValidUntil)
+	ValidFromVal, err := safeDate(fields, 18)
+	if err != nil {
+		return err
+	}
+	ValidUntilVal, err := safeDate(fields, 19)
+	if err != nil {
+		return err
+	}
 
 	ConditionTypeVal := safe(fields, 2)
 	KeyCombinationVal := safe(fields, 3)
@@ -63,8 +65,8 @@ func (h *Block121Handler) Handle(
 		Attribute2:          Attribute2Val,
 		Material:            safe(fields, 16),
 		SalesUnit:           SalesUnitVal,
-		ValidFrom:           Validf,
-		ValidUntil:          Validt,
+		ValidFrom:           ValidFromVal,
+		ValidUntil:          ValidUntilVal,
 		ConditionRecordNo:   safe(fields, 20),
 		Scale:               safe(fields, 21),
 		FileName:            job.FileName,
@@ -1,9 +1,9 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
-	"go-import-file/internal/utils"
-	"time"
 )
 
 type Block122Handler struct {
@@ -16,9 +16,15 @@ func (h *Block122Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	AmountVal, _ := utils.ParseAccountingFloat(safe(fields, 3))
-	Perval, _ := utils.ParseNumber(safe(fields, 5))
-	now := time.Now()
+	AmountVal, err := safeDecimal(fields, 3, decimals.Accounting)
+	if err != nil {
+		return err
+	}
+	Perval, err := safeDecimal(fields, 5, decimals.Number)
+	if err != nil {
+		return err
+	}
+	now := dates.Now()
 
 	h.Out <- model.SpProsesDpZddet{
 		ProcessId:         processID,
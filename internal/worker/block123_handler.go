@@ -1,9 +1,9 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
 	"strconv"
-	"time"
 )
 
 type Block123Handler struct {
@@ -17,13 +17,16 @@ func (h *Block123Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	now := time.Now()
+	now := dates.Now()
 
-	ValidFrom := safe(fields, 17)
-	ValidUntil := safe(fields, 16)
-
-	Validf, _ := time.Parse("20060102", ValidFrom)
-	Validt, _ := time.Parse("20060102", ValidUntil)
+	ValidFromVal, err := safeDate(fields, 17)
+	if err != nil {
+		return err
+	}
+	ValidUntilVal, err := safeDate(fields, 16)
+	if err != nil {
+		return err
+	}
 
 	LineItemVal, _ := strconv.Atoi(safe(fields, 19))
 	VKelipatanVal, _ := strconv.Atoi(safe(fields, 27))
@@ -48,8 +51,8 @@ func (h *Block123Handler) Handle(
 		Pl:             safe(fields, 13),
 		Payt:           safe(fields, 14),
 		Material:       safe(fields, 15),
-		ValidFrom:      Validf,
-		ValidUntil:     Validt,
+		ValidFrom:      ValidFromVal,
+		ValidUntil:     ValidUntilVal,
 		PromoId:        safe(fields, 18),
 		LineItem:       LineItemVal,
 		FileName:       job.FileName,
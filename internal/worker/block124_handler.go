@@ -1,10 +1,10 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
-	"go-import-file/internal/utils"
 	"strconv"
-	"time"
 )
 
 type Block124Handler struct {
@@ -19,9 +19,15 @@ func (h *Block124Handler) Handle(
 ) error {
 	NoVal, _ := strconv.Atoi(safe(fields, 3))
 	LsnoVal, _ := strconv.Atoi(safe(fields, 4))
-	DiscRegHdrQtyVal, _ := utils.ParseAccountingFloat(safe(fields, 5))
-	AmountVal, _ := utils.ParseAccountingFloat(safe(fields, 6))
-	now := time.Now()
+	DiscRegHdrQtyVal, err := safeDecimal(fields, 5, decimals.Accounting)
+	if err != nil {
+		return err
+	}
+	AmountVal, err := safeDecimal(fields, 6, decimals.Accounting)
+	if err != nil {
+		return err
+	}
+	now := dates.Now()
 
 	h.Out <- model.SpProsesDpZscreg{
 		ProcessId:         processID,
@@ -1,10 +1,10 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
-	"go-import-file/internal/utils"
 	"strconv"
-	"time"
 )
 
 type Block125Handler struct {
@@ -18,13 +18,31 @@ func (h *Block125Handler) Handle(
 	processID string,
 ) error {
 	LineItemVal, _ := strconv.Atoi(safe(fields, 3))
-	ScaleQtyVal, _ := utils.ParseAccountingFloat(safe(fields, 4))
-	AmountVal, _ := utils.ParseAccountingFloat(safe(fields, 6))
-	PerVal, _ := utils.ParseAccountingFloat(safe(fields, 8))
-	ScaleQtyToVal, _ := utils.ParseAccountingFloat(safe(fields, 10))
-	AmountSclVal, _ := utils.ParseAccountingFloat(safe(fields, 11))
-	AmountSclToVal, _ := utils.ParseAccountingFloat(safe(fields, 12))
-	now := time.Now()
+	ScaleQtyVal, err := safeDecimal(fields, 4, decimals.Accounting)
+	if err != nil {
+		return err
+	}
+	AmountVal, err := safeDecimal(fields, 6, decimals.Accounting)
+	if err != nil {
+		return err
+	}
+	PerVal, err := safeDecimal(fields, 8, decimals.Accounting)
+	if err != nil {
+		return err
+	}
+	ScaleQtyToVal, err := safeDecimal(fields, 10, decimals.Accounting)
+	if err != nil {
+		return err
+	}
+	AmountSclVal, err := safeDecimal(fields, 11, decimals.Accounting)
+	if err != nil {
+		return err
+	}
+	AmountSclToVal, err := safeDecimal(fields, 12, decimals.Accounting)
+	if err != nil {
+		return err
+	}
+	now := dates.Now()
 
 	h.Out <- model.SpProsesDpZscmix{
 		ProcessId:   processID,
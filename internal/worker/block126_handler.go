@@ -1,9 +1,8 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
-	"strconv"
-	"time"
 )
 
 type Block126Handler struct {
@@ -16,9 +15,15 @@ func (h *Block126Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	ValidFromVal, _ := strconv.Atoi(safe(fields, 7))
-	ValidToVal, _ := strconv.Atoi(safe(fields, 8))
-	now := time.Now()
+	ValidFromVal, err := safeDate(fields, 7)
+	if err != nil {
+		return err
+	}
+	ValidToVal, err := safeDate(fields, 8)
+	if err != nil {
+		return err
+	}
+	now := dates.Now()
 
 	h.Out <- model.SpProsesDpZ00001{
 		ProcessId:     processID,
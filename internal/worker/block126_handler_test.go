@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"go-import-file/internal/model"
+)
+
+func TestBlock126ValidityDates(t *testing.T) {
+	tests := []struct {
+		name       string
+		from, to   string
+		wantFrom   string // "" is NULL
+		wantTo     string
+		rejectCode string
+	}{
+		{"dates", "20260131", "99991231", "2026-01-31", "9999-12-31", ""},
+		{"empty and zeros are NULL", "", "00000000", "", "", ""},
+		{"bad from", "2026013", "20260131", "", "", "field 7: invalid date"},
+		{"bad to", "20260131", "20261332", "", "", "field 8: invalid date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := make(chan model.SpProsesDpZ00001, 1)
+			h := &Block126Handler{Out: out}
+			fields := []string{"126", "Z00001", "", "10", "1", "ZD01", "discount", tt.from, tt.to, "G1", "R1", "D"}
+
+			err := h.Handle(fields, 1, FileJob{FileName: "A_SDEAL.txt"}, "p1")
+			if tt.rejectCode != "" {
+				if err == nil || rejectCode(err) != tt.rejectCode {
+					t.Fatalf("err = %v, want reject code %q", err, tt.rejectCode)
+				}
+				if len(out) != 0 {
+					t.Error("a rejected line was sent on")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			r := <-out
+			for _, d := range []struct {
+				name  string
+				valid bool
+				at    time.Time
+				want  string
+			}{
+				{"ValidFrom", r.ValidFrom.Valid, r.ValidFrom.Time, tt.wantFrom},
+				{"ValidTo", r.ValidTo.Valid, r.ValidTo.Time, tt.wantTo},
+			} {
+				got := ""
+				if d.valid {
+					got = d.at.Format(time.DateOnly)
+				}
+				if got != d.want {
+					t.Errorf("%s = %q, want %q", d.name, got, d.want)
+				}
+			}
+		})
+	}
+}
@@ -1,11 +1,10 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
-	"go-import-file/internal/utils"
 	"strconv"
-
-	"time"
 )
 
 type Block130Handler struct {
@@ -19,13 +18,16 @@ func (h *Block130Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	now := time.Now()
-
-	ValidFrom := safe(fields, 15)
-	ValidUntil := safe(fields, 16)
+	now := dates.Now()
 
-	Validf, _ := time.Parse("20060102", ValidFrom)
-	Validt, _ := time.Parse("20060102", ValidUntil)
+	ValidFromVal, err := safeDate(fields, 16)
+	if err != nil {
+		return err
+	}
+	ValidUntilVal, err := safeDate(fields, 15)
+	if err != nil {
+		return err
+	}
 
 	ConditionTypeVal := safe(fields, 2)
 	if ConditionTypeVal == "" {
@@ -44,8 +46,14 @@ func (h *Block130Handler) Handle(
 	SoldToPartyVal := safe(fields, 13)
 
 	FKelipatanVal, _ := strconv.Atoi(safe(fields, 23))
-	QtyVal, _ := strconv.Atoi(safe(fields, 28))
-	UomVal, _ := utils.ParseNumber(safe(fields, 29))
+	QtyVal, err := safeDecimal(fields, 28, decimals.Number)
+	if err != nil {
+		return err
+	}
+	UomVal, err := safeDecimal(fields, 29, decimals.Number)
+	if err != nil {
+		return err
+	}
 	FPerbandingan1Val, _ := strconv.Atoi(safe(fields, 25))
 	FPerbandingan2Val, _ := strconv.Atoi(safe(fields, 26))
 
@@ -69,8 +77,8 @@ func (h *Block130Handler) Handle(
 		IndustryCode5:       safe(fields, 12),
 		SoldToParty:         safe(fields, 13),
 		Material:            safe(fields, 14),
-		ValidUntil:          Validf,
-		ValidFrom:           Validt,
+		ValidUntil:          ValidUntilVal,
+		ValidFrom:           ValidFromVal,
 		ConditionRecordNo:   safe(fields, 17),
 		PromoId:             safe(fields, 18),
 		PromoItem:           safe(fields, 19),
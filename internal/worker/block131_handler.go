@@ -1,9 +1,9 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
-	"go-import-file/internal/utils"
-	"time"
 )
 
 type Block131Handler struct {
@@ -16,10 +16,19 @@ func (h *Block131Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	MinimumQtyVal, _ := utils.ParseNumber(safe(fields, 3))
-	FreeGoodsQtyVal, _ := utils.ParseNumber(safe(fields, 4))
-	FreeGoodsAgrredQtyVal, _ := utils.ParseNumber(safe(fields, 6))
-	now := time.Now()
+	MinimumQtyVal, err := safeDecimal(fields, 3, decimals.Number)
+	if err != nil {
+		return err
+	}
+	FreeGoodsQtyVal, err := safeDecimal(fields, 4, decimals.Number)
+	if err != nil {
+		return err
+	}
+	FreeGoodsAgrredQtyVal, err := safeDecimal(fields, 6, decimals.Number)
+	if err != nil {
+		return err
+	}
+	now := dates.Now()
 
 	h.Out <- model.SpProsesFgZfrdet{
 		ProcessId:          processID,
@@ -1,9 +1,9 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
-	"go-import-file/internal/utils"
-	"time"
 )
 
 type Block132Handler struct {
@@ -16,10 +16,19 @@ func (h *Block132Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	ScaleQtyVal, _ := utils.ParseNumber(safe(fields, 4))
-	QtyVal, _ := utils.ParseAccountingFloat(safe(fields, 7))
-	AmountSclfVal, _ := utils.ParseAccountingFloat(safe(fields, 9))
-	now := time.Now()
+	ScaleQtyVal, err := safeDecimal(fields, 4, decimals.Number)
+	if err != nil {
+		return err
+	}
+	QtyVal, err := safeDecimal(fields, 7, decimals.Accounting)
+	if err != nil {
+		return err
+	}
+	AmountSclfVal, err := safeDecimal(fields, 9, decimals.Accounting)
+	if err != nil {
+		return err
+	}
+	now := dates.Now()
 
 	h.Out <- model.SpProsesFgZfrmix{
 		ProcessId:   processID,
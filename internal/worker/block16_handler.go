@@ -1,8 +1,8 @@
 package worker
 
 import (
-	"time"
-
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
 )
 
@@ -17,7 +17,16 @@ func (h *Block16Handler) Handle(
 	processID string,
 ) error {
 
-	now := time.Now()
+	price, err := safeDecimal(fields, 9, decimals.Number)
+	if err != nil {
+		return err
+	}
+	sel, err := job.SellPrices(safe(fields, 3), price, safe(fields, 10))
+	if err != nil {
+		return err
+	}
+
+	now := dates.Now()
 	by := "system"
 
 	h.Out <- model.Mprice{
@@ -26,8 +35,13 @@ func (h *Block16Handler) Handle(
 		PriceCode:       safe(fields, 2),
 		BranchID:        safe(fields, 4),
 		Pcode:           safe(fields, 3),
-		PriceValue:      safe(fields, 9),
+		PriceValue:      price,
 		PriceUom:        safe(fields, 10),
+		SelPrice1:       sel[0],
+		SelPrice2:       sel[1],
+		SelPrice3:       sel[2],
+		SelPrice4:       sel[3],
+		SelPrice5:       sel[4],
 		Cby:             by,
 		Cdate:           now,
 		Mby:             by,
@@ -1,8 +1,7 @@
 package worker
 
 import (
-	"time"
-
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
 )
 
@@ -36,7 +35,7 @@ func (h *Block19Handler) Handle(
 		MTiga:           safe(fields, 17),
 		MEmpat:          safe(fields, 18),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
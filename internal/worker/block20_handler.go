@@ -1,8 +1,7 @@
 package worker
 
 import (
-	"time"
-
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
 )
 
@@ -33,7 +32,7 @@ func (h *Block20Handler) Handle(
 		Kodecabang:      safe(fields, 20),
 		AtasanId:        safe(fields, 21),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
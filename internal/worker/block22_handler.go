@@ -1,8 +1,7 @@
 package worker
 
 import (
-	"time"
-
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
 )
 
@@ -22,7 +21,7 @@ func (h *Block22Handler) Handle(
 		PrliName:        safe(fields, 3),
 		KompFlag:        safe(fields, 4),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
@@ -1,15 +1,17 @@
 package worker
 
 import (
-	"go-import-file/internal/config"
+	"go-import-file/internal/dates"
 	"go-import-file/internal/model"
+	"go-import-file/internal/uom"
 	"strconv"
 	"strings"
-	"time"
 )
 
 type Block25Handler struct {
-	Out chan<- model.Msku
+	Out     chan<- model.Msku
+	UomBuy  string
+	UomMain string
 }
 
 func (h *Block25Handler) Handle(
@@ -18,11 +20,9 @@ func (h *Block25Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	cfg := config.Load()
-
 	uomFlags := [5]string{}
 
-	parts := strings.Split(cfg.UomBuy, "|")
+	parts := strings.Split(h.UomBuy, "|")
 
 	for i := range len(uomFlags) {
 		if safe(parts, i) != "" {
@@ -30,7 +30,7 @@ func (h *Block25Handler) Handle(
 		}
 	}
 
-	ConvUnit := safe(fields, 7) + "|" + safe(fields, 8) + "|" + safe(fields, 9) + "|" + safe(fields, 10) + "|" + safe(fields, 11)
+	ConvUnit := []string{safe(fields, 7), safe(fields, 8), safe(fields, 9), safe(fields, 10), safe(fields, 11)}
 
 	UomBuy1 := uomFlags[0]
 	UomBuy2 := uomFlags[1]
@@ -38,7 +38,7 @@ func (h *Block25Handler) Handle(
 	UomBuy4 := uomFlags[3]
 	UomBuy5 := uomFlags[4]
 
-	uom, pos := ResolveUOM(cfg.UomMain, ConvUnit)
+	unit, pos := uom.ResolveMain(h.UomMain, ConvUnit)
 
 	h.Out <- model.Msku{
 		Prlin:           safe(fields, 2),
@@ -59,7 +59,7 @@ func (h *Block25Handler) Handle(
 		FlagAktif:       safe(fields, 17),
 		FlagGift:        safe(fields, 26),
 		ShortName1:      safe(fields, 28),
-		UomBase:         uom,
+		UomBase:         unit,
 		UomMain:         strconv.Itoa(pos + 1),
 		Uom1Buy:         UomBuy1,
 		Uom2Buy:         UomBuy2,
@@ -67,7 +67,7 @@ func (h *Block25Handler) Handle(
 		Uom4Buy:         UomBuy4,
 		Uom5Buy:         UomBuy5,
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
@@ -80,37 +80,3 @@ func toInt(s string) int {
 	}
 	return val
 }
-
-func ResolveUOM(uomMain, convUnit string) (value string, position int) {
-	convParts := strings.Split(convUnit, "|")
-	convSet := make(map[string]int)
-	lastValid := ""
-	lastIndex := -1
-
-	// Bangun lookup + simpan index
-	for i, c := range convParts {
-		c = strings.TrimSpace(c)
-		if c == "" {
-			continue
-		}
-		if _, exists := convSet[c]; !exists {
-			convSet[c] = i
-		}
-		lastValid = c
-		lastIndex = i
-	}
-
-	// Cek berdasarkan prioritas UOM_MAIN
-	for _, m := range strings.Split(uomMain, "|") {
-		m = strings.TrimSpace(m)
-		if m == "" {
-			continue
-		}
-		if idx, ok := convSet[m]; ok {
-			return m, idx
-		}
-	}
-
-	// Fallback: ambil nilai terakhir dari CONV_UNIT
-	return lastValid, lastIndex
-}
@@ -1,9 +1,8 @@
 package worker
 
 import (
-	"strconv"
-	"time"
-
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
 )
 
@@ -17,21 +16,35 @@ func (h *Block35Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	InvAmountVal, _ := strconv.ParseFloat(safe(fields, 6), 64)
-	AmountPaidVal, _ := strconv.ParseFloat(safe(fields, 7), 64)
+	InvAmountVal, err := safeDecimal(fields, 6, decimals.Number)
+	if err != nil {
+		return err
+	}
+	AmountPaidVal, err := safeDecimal(fields, 7, decimals.Number)
+	if err != nil {
+		return err
+	}
+	InvDateVal, err := safeDate(fields, 4)
+	if err != nil {
+		return err
+	}
+	DueDateVal, err := safeDate(fields, 5)
+	if err != nil {
+		return err
+	}
 
 	h.Out <- model.ArInvoice{
 		CustNo:          safe(fields, 2),
 		InvNo:           safe(fields, 3),
-		InvDate:         safe(fields, 4),
-		DueDate:         safe(fields, 5),
+		InvDate:         InvDateVal,
+		DueDate:         DueDateVal,
 		InvAmount:       InvAmountVal,
 		AmountPaid:      AmountPaidVal,
 		SlsNo:           safe(fields, 8),
 		Kodecabang:      safe(fields, 9),
 		InvType:         safe(fields, 10),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
@@ -1,9 +1,9 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
-	"go-import-file/internal/utils"
-	"time"
 )
 
 type Block39Handler struct {
@@ -16,7 +16,10 @@ func (h *Block39Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	StockVal, _ := utils.ParseNumber(safe(fields, 5))
+	StockVal, err := safeDecimal(fields, 5, decimals.Number)
+	if err != nil {
+		return err
+	}
 
 	h.Out <- model.ImStkbal{
 		Kg:              safe(fields, 2),
@@ -24,7 +27,7 @@ func (h *Block39Handler) Handle(
 		Stock:           StockVal,
 		Kodecabang:      safe(fields, 6),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
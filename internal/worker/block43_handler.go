@@ -1,10 +1,8 @@
 package worker
 
 import (
-	"strconv"
-	"strings"
-	"time"
-
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
 )
 
@@ -19,16 +17,34 @@ func (h *Block43Handler) Handle(
 	processID string,
 ) error {
 
-	QtyVal, _ := strconv.Atoi(safe(fields, 11))
-	PriceCVal := strings.ReplaceAll(safe(fields, 12), ",", "")
-	PriceVal, _ := strconv.Atoi(PriceCVal)
-	DiskonCval := strings.ReplaceAll(safe(fields, 13), ",", "")
-	DiskonVal, _ := strconv.Atoi(DiskonCval)
-	InvamountCVal := strings.ReplaceAll(safe(fields, 17), ",", "")
-	InvamountVal, _ := strconv.Atoi(InvamountCVal)
-	SfaOrderDateVal := safe(fields, 5)[:4] + "-" + safe(fields, 5)[4:6] + "-" + safe(fields, 5)[6:]
-	OrderDateVal := safe(fields, 7)[:4] + "-" + safe(fields, 7)[4:6] + "-" + safe(fields, 7)[6:]
-	InvoiceDateVal := safe(fields, 9)[:4] + "-" + safe(fields, 9)[4:6] + "-" + safe(fields, 9)[6:]
+	QtyVal, err := safeDecimal(fields, 11, decimals.Grouped)
+	if err != nil {
+		return err
+	}
+	PriceVal, err := safeDecimal(fields, 12, decimals.Grouped)
+	if err != nil {
+		return err
+	}
+	DiskonVal, err := safeDecimal(fields, 13, decimals.Grouped)
+	if err != nil {
+		return err
+	}
+	InvamountVal, err := safeDecimal(fields, 17, decimals.Grouped)
+	if err != nil {
+		return err
+	}
+	SfaOrderDateVal, err := safeDate(fields, 5)
+	if err != nil {
+		return err
+	}
+	OrderDateVal, err := safeDate(fields, 7)
+	if err != nil {
+		return err
+	}
+	InvoiceDateVal, err := safeDate(fields, 9)
+	if err != nil {
+		return err
+	}
 
 	h.Out <- model.SlsInv{
 		SlsNo:           safe(fields, 2),
@@ -48,7 +64,7 @@ func (h *Block43Handler) Handle(
 		RefCn:           safe(fields, 16),
 		Invamount:       InvamountVal,
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
@@ -1,9 +1,9 @@
 package worker
 
 import (
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/model"
-	"go-import-file/internal/utils"
-	"time"
 )
 
 type Block44Handler struct {
@@ -16,8 +16,14 @@ func (h *Block44Handler) Handle(
 	job FileJob,
 	processID string,
 ) error {
-	CreditLimitVal, _ := utils.ParseAccountingFloat(safe(fields, 4))
-	SisaCreditLimitVal, _ := utils.ParseAccountingFloat(safe(fields, 5))
+	CreditLimitVal, err := safeDecimal(fields, 4, decimals.Accounting)
+	if err != nil {
+		return err
+	}
+	SisaCreditLimitVal, err := safeDecimal(fields, 5, decimals.Accounting)
+	if err != nil {
+		return err
+	}
 
 	h.Out <- model.McustCl{
 		CustNo:          safe(fields, 2),
@@ -26,7 +32,7 @@ func (h *Block44Handler) Handle(
 		SisaCreditLimit: SisaCreditLimitVal,
 		Kodecabang:      safe(fields, 6),
 		CoreFilename:    job.FileName,
-		CoreProcessdate: time.Now(),
+		CoreProcessdate: dates.Now(),
 	}
 
 	return nil
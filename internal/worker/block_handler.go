@@ -1,6 +1,9 @@
 package worker
 
-import "go-import-file/internal/model"
+import (
+	"go-import-file/internal/config"
+	"go-import-file/internal/model"
+)
 
 type BlockHandler interface {
 	Handle(
@@ -12,6 +15,7 @@ type BlockHandler interface {
 }
 
 func BuildBlockHandlers(
+	cfg *config.Config,
 	ch16 chan<- model.Mprice,
 	ch15 chan<- model.MpriceGrp,
 	ch01 chan<- model.Mcust,
@@ -59,7 +63,7 @@ func BuildBlockHandlers(
 		"16":  &Block16Handler{Out: ch16},
 		"15":  &Block15Handler{Out: ch15},
 		"01":  &Block01Handler{Out: ch01},
-		"25":  &Block25Handler{Out: ch25},
+		"25":  &Block25Handler{Out: ch25, UomBuy: cfg.UomBuy, UomMain: cfg.UomMain},
 		"02":  &Block02Handler{Out: ch02},
 		"05":  &Block05Handler{Out: ch05},
 		"20":  &Block20Handler{Out: ch20},
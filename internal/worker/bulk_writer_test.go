@@ -0,0 +1,115 @@
+package worker
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-import-file/internal/config"
+	"go-import-file/internal/db"
+	"go-import-file/internal/sink"
+)
+
+var batchSpec = sink.TableSpec{
+	Table: "dbo.batch_test",
+	Columns: []sink.Column{
+		sink.Int("N"),
+		sink.String("CORE_FILENAME", 255),
+	},
+}
+
+type testLogger struct{ t *testing.T }
+
+func (l testLogger) Printf(format string, args ...any) { l.t.Logf(format, args...) }
+
+// batchedConfig commits every 2 rows of block T under process p1.
+func batchedConfig(t *testing.T, resume bool) *config.Config {
+	return &config.Config{
+		DB:          config.DBConfig{Driver: "sqlite", Name: filepath.Join(t.TempDir(), "test.db")},
+		BatchSize:   2,
+		CommitModes: map[string]string{"T": config.CommitBatched},
+		Run:         config.Run{Block: "T", ProcessID: "p1", Resume: resume},
+	}
+}
+
+func openSQLite(t *testing.T, cfg *config.Config) *sql.DB {
+	t.Helper()
+	conn, err := db.NewSQLite(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// load runs bulkInsert on lines 1..n of file a.txt and returns the error
+// the writer reported.
+func load(t *testing.T, cfg *config.Config, s sink.Sink, spec sink.TableSpec, n int) error {
+	rows := make(chan []any, n)
+	for i := 1; i <= n; i++ {
+		rows <- []any{i, "a.txt"}
+	}
+	close(rows)
+
+	done := make(chan error, 1)
+	bulkInsert(context.Background(), cfg, s, spec, rows, done, testLogger{t})
+	return <-done
+}
+
+func TestBulkInsertResumeSkipsCommittedBatches(t *testing.T) {
+	cfg := batchedConfig(t, false)
+	conn := openSQLite(t, cfg)
+	s := sink.NewSQLite(conn)
+
+	// The first attempt got through 3 lines before it stopped.
+	if err := load(t, cfg, s, batchSpec, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg.Run.Resume = true
+	if err := load(t, cfg, s, batchSpec, 5); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	rows, err := conn.Query(`SELECT N FROM batch_test ORDER BY N`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var n string
+		rows.Scan(&n)
+		got = append(got, n)
+	}
+	if strings.Join(got, ",") != "1,2,3,4,5" {
+		t.Errorf("rows = %v, want each of 1..5 once", got)
+	}
+
+	committed, err := s.Checkpoints(context.Background(), "p1", batchSpec.Table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if committed["a.txt"] != 5 {
+		t.Errorf("checkpoint = %d, want 5", committed["a.txt"])
+	}
+}
+
+func TestBulkInsertReportsWriterError(t *testing.T) {
+	for _, resume := range []bool{false, true} {
+		cfg := batchedConfig(t, resume)
+		conn := openSQLite(t, cfg)
+
+		// A table of the same name without the spec's columns.
+		if _, err := conn.Exec(`CREATE TABLE batch_test (X INTEGER)`); err != nil {
+			t.Fatal(err)
+		}
+
+		err := load(t, cfg, sink.NewSQLite(conn), batchSpec, 5)
+		if err == nil || !strings.Contains(err.Error(), "no column named N") {
+			t.Errorf("resume=%v: err = %v, want the insert error", resume, err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package worker
+
+import (
+	"strings"
+
+	"go-import-file/internal/sink"
+)
+
+// IntegrityRule is a reference from a table Block loads to a table RefBlock
+// loads.
+type IntegrityRule struct {
+	Block    string
+	RefBlock string
+	sink.Reference
+}
+
+func (r IntegrityRule) Name() string {
+	return strings.Join(r.Columns, "+") + " -> " + r.RefBlock
+}
+
+func reference(spec sink.TableSpec, cols []string, ref sink.TableSpec, refCols []string) sink.Reference {
+	return sink.Reference{Table: spec.Table, Columns: cols, RefTable: ref.Table, RefColumns: refCols}
+}
+
+var integrityRules = []IntegrityRule{
+	{"MCUST", "MCUSTGRP", reference(fcustmstTable, []string{"GRUPOUT"}, fgrupoutTable, []string{"GROUPOUT"})},
+	{"MCUST", "MCUSTTYPE", reference(fcustmstTable, []string{"TYPEOUT"}, ftypeoutTable, []string{"TYPE"})},
+	{"MCUST", "MDISTRICT", reference(fcustmstTable, []string{"DISTRIK", "KODECABANG"}, fdistrikTable, []string{"DISTRIK", "KODECABANG"})},
+	{"MCUST", "MBEAT", reference(fcustmstTable, []string{"DISTRIK", "BEAT"}, gmCustWilayahTable, []string{"wc_district_id", "wc_wilayah_id"})},
+	{"MCUST", "MSUBBEAT", reference(fcustmstTable, []string{"DISTRIK", "BEAT", "SUBBEAT"}, gmCustRayonTable, []string{"rc_district_id", "rc_wilayah_id", "rc_rayon_id"})},
+	{"MCUST", "MCUSTINDUS", reference(fcustmstTable, []string{"KINDUS"}, findustriTable, []string{"INDUSID"})},
+	{"MCUST", "MPRICEGRP", reference(fcustmstTable, []string{"GHARGA"}, fghargaTable, []string{"GHARGA"})},
+	{"MPRICE", "MPRICEGRP", staged(reference(mPriceDummyTable, []string{"PRICE_CODE"}, fghargaTable, []string{"GHARGA"}))},
+}
+
+// staged limits a reference from a staging table to the run's rows.
+func staged(ref sink.Reference) sink.Reference {
+	ref.ProcessColumn = "UNIQ_ID"
+	return ref
+}
+
+// IntegrityRules returns the rules of the tables block loads, or all rules
+// for "".
+func IntegrityRules(block string) []IntegrityRule {
+	var out []IntegrityRule
+	for _, r := range integrityRules {
+		if block == "" || strings.EqualFold(r.Block, block) {
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -1,6 +1,104 @@
 package worker
 
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+
+	"go-import-file/internal/decimals"
+	"go-import-file/internal/sink"
+	"go-import-file/internal/uom"
+)
+
 type FileJob struct {
 	FilePath string
 	FileName string
+
+	// Ladders are the unit ladders of fmaster by PCODE, for the price
+	// blocks. Nil for the other blocks.
+	Ladders map[string]uom.Ladder
+}
+
+var fmasterLadderTable = sink.TableSpec{
+	Table: fmasterTable.Table,
+	Columns: []sink.Column{
+		sink.String("PCODE", 225),
+		sink.String("UNIT1", 225),
+		sink.String("UNIT2", 225),
+		sink.String("UNIT3", 225),
+		sink.String("UNIT4", 225),
+		sink.String("UNIT5", 225),
+		sink.Int("CONVUNIT2"),
+		sink.Int("CONVUNIT3"),
+		sink.Int("CONVUNIT4"),
+		sink.Int("CONVUNIT5"),
+	},
+}
+
+// LoadLadders reads the unit ladder of every SKU in fmaster.
+func LoadLadders(ctx context.Context, s sink.Sink) (map[string]uom.Ladder, error) {
+	rows, err := s.Select(ctx, fmasterLadderTable)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ladders := make(map[string]uom.Ladder)
+	for rows.Next() {
+		var (
+			pcode string
+			units [uom.Levels]sql.NullString
+			conv  [uom.Levels - 1]sql.NullInt64
+		)
+		if err := rows.Scan(
+			&pcode,
+			&units[0], &units[1], &units[2], &units[3], &units[4],
+			&conv[0], &conv[1], &conv[2], &conv[3],
+		); err != nil {
+			return nil, err
+		}
+
+		var (
+			u [uom.Levels]string
+			c [uom.Levels - 1]int
+		)
+		for i := range units {
+			u[i] = units[i].String
+		}
+		for i := range conv {
+			c[i] = int(conv[i].Int64)
+		}
+		pcode = strings.TrimSpace(pcode)
+		ladders[pcode] = uom.New(pcode, u, c)
+	}
+	return ladders, rows.Err()
+}
+
+// SellPrices converts a price per unit into SELPRICE1..5 of pcode. A PCODE
+// missing from fmaster gets no prices; the finalize join drops it anyway.
+func (j FileJob) SellPrices(pcode string, price decimal.Decimal, unit string) ([uom.Levels]decimal.Decimal, error) {
+	ladder, ok := j.Ladders[strings.TrimSpace(pcode)]
+	if !ok {
+		return [uom.Levels]decimal.Decimal{}, nil
+	}
+
+	sell, err := ladder.SellPrices(price, unit)
+	if err != nil {
+		return sell, &rejectError{
+			code: "unit conversion: " + problem(err, uom.ErrUnknownUnit, uom.ErrNoConversion),
+			err:  err,
+		}
+	}
+	for i, p := range sell {
+		if err := decimals.Money.Check(p); err != nil {
+			return sell, &rejectError{
+				code: fmt.Sprintf("SELPRICE%d: %s", i+1, problem(err, decimals.ErrInexact, decimals.ErrRange)),
+				err:  fmt.Errorf("SELPRICE%d %s: %w", i+1, p, err),
+			}
+		}
+	}
+	return sell, nil
 }
@@ -3,13 +3,23 @@ package worker
 import (
 	"bufio"
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"go-import-file/internal/config"
+	"go-import-file/internal/dates"
+	"go-import-file/internal/decimals"
 	"go-import-file/internal/metrics"
 	"go-import-file/internal/model"
 	"go-import-file/internal/utils"
@@ -17,6 +27,7 @@ import (
 
 func ParseWorker(
 	ctx context.Context,
+	cfg *config.Config,
 	wg *sync.WaitGroup,
 	jobs <-chan FileJob,
 	fileMetrics chan<- metrics.FileMetric,
@@ -64,19 +75,84 @@ func ParseWorker(
 	ch132 chan<- model.SpProsesFgZfrmix,
 ) {
 	defer wg.Done()
+	defer metrics.BeginParse()()
 
-	handlers := BuildBlockHandlers(ch16, ch15, ch01, ch25, ch02, ch05, ch20, ch43, ch35, ch39, ch108, ch103, ch44, ch112, ch111, ch03, ch102, ch46, ch113, ch105, ch110, ch101, ch19, ch23, ch109, ch22, ch104, ch47, ch07, ch120, ch121, ch122, ch123, ch123Promo, ch124, ch125, ch126, ch130, ch130Promo, ch131, ch132)
-	cfg := config.Load()
+	handlers := BuildBlockHandlers(cfg, ch16, ch15, ch01, ch25, ch02, ch05, ch20, ch43, ch35, ch39, ch108, ch103, ch44, ch112, ch111, ch03, ch102, ch46, ch113, ch105, ch110, ch101, ch19, ch23, ch109, ch22, ch104, ch47, ch07, ch120, ch121, ch122, ch123, ch123Promo, ch124, ch125, ch126, ch130, ch130Promo, ch131, ch132)
 
 	for job := range jobs {
+		// After a shutdown request files stay in file_path for the next run.
+		if ctx.Err() != nil {
+			continue
+		}
+
 		err := parseOneFile(ctx, job, fileMetrics, processID, handlers)
+		if ctx.Err() != nil {
+			continue
+		}
 
 		if err != nil {
-			utils.MoveFile(job.FilePath, cfg.FileFailedDir)
+			moveFile(job.FilePath, cfg.FileFailedDir)
+			continue
+		}
+		moveFile(job.FilePath, cfg.FileSuccessDir)
+	}
+}
+
+// moved remembers where this run moved each file until the step that
+// loaded it commits, so a step that is cancelled or fails can put its
+// files back: their rows were rolled back with it.
+var moved struct {
+	sync.Mutex
+	files map[string]string // destination -> original path
+}
+
+func moveFile(path, dir string) {
+	dst, err := utils.MoveFile(path, dir)
+	if err != nil {
+		log.Printf("move %s to %s failed: %v", path, dir, err)
+		return
+	}
+
+	moved.Lock()
+	defer moved.Unlock()
+	if moved.files == nil {
+		moved.files = map[string]string{}
+	}
+	moved.files[dst] = path
+}
+
+// CommitMoves keeps the files moved so far where they are: the step that
+// loaded them committed, restoring them would import them twice.
+func CommitMoves() {
+	moved.Lock()
+	defer moved.Unlock()
+	moved.files = nil
+}
+
+// ResetMoves forgets the files moved by an earlier run of this process.
+func ResetMoves() {
+	CommitMoves()
+}
+
+// RestoreMoves moves the files whose load did not commit back to where
+// they were read from and returns how many it restored.
+func RestoreMoves() (int, error) {
+	moved.Lock()
+	defer moved.Unlock()
+
+	var (
+		n    int
+		errs []error
+	)
+	for dst, src := range moved.files {
+		if err := os.Rename(dst, src); err != nil {
+			errs = append(errs, err)
 			continue
 		}
-		utils.MoveFile(job.FilePath, cfg.FileSuccessDir)
+		delete(moved.files, dst)
+		n++
 	}
+	return n, errors.Join(errs...)
 }
 
 func parseOneFile(
@@ -116,9 +192,11 @@ func parseOneFile(
 		atomic.AddInt64(&totalLines, 1)
 		atomic.AddInt64(&metrics.ProcessedLines, 1)
 
-		fields := strings.Split(scanner.Text(), "|")
+		line := scanner.Text()
+		fields := strings.Split(line, "|")
 		if len(fields) < 2 {
 			errCount++
+			metrics.RecordReject(job.FileName, lineNumber, "line has no block separator", "", line)
 			continue
 		}
 
@@ -128,8 +206,9 @@ func parseOneFile(
 			continue // unknown block → skip
 		}
 
-		if err := handler.Handle(fields, lineNumber, job, processID); err != nil {
+		if err := handleLine(handler, fields, lineNumber, job, processID); err != nil {
 			errCount++
+			metrics.RecordReject(job.FileName, lineNumber, "block "+blockID+": "+rejectCode(err), "block "+blockID+": "+err.Error(), line)
 			continue
 		}
 
@@ -154,6 +233,64 @@ func parseOneFile(
 	return nil
 }
 
+// handleLine runs handler on one line. A panic in it rejects only that
+// line instead of taking down the process; the frames it came from are
+// logged and kept in the reject message, its code stays the same for
+// every panic so they are counted together.
+func handleLine(handler BlockHandler, fields []string, lineNo int, job FileJob, processID string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			at := panicFrames(3)
+			log.Printf("[PANIC] %s line %d: %v at %s", job.FileName, lineNo, r, at)
+			err = &rejectError{code: rejectPanic, err: fmt.Errorf("panic: %v at %s", r, at)}
+		}
+	}()
+	return handler.Handle(fields, lineNo, job, processID)
+}
+
+// panicFrames names the innermost n frames of a recovered panic outside
+// the runtime, e.g. "worker.(*Block43Handler).Handle block43_handler.go:29".
+func panicFrames(n int) string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+
+	var out []string
+	for len(out) < n {
+		f, more := frames.Next()
+		if !strings.HasPrefix(f.Function, "runtime.") {
+			fn := f.Function[strings.LastIndex(f.Function, "/")+1:]
+			out = append(out, fmt.Sprintf("%s %s:%d", fn, filepath.Base(f.File), f.Line))
+		}
+		if !more {
+			break
+		}
+	}
+	return strings.Join(out, " < ")
+}
+
+// rejectPanic is the reject code of a line whose handler panicked.
+const rejectPanic = "handler panic"
+
+// rejectError is a rejected line whose message carries the offending
+// value. Rejects are counted per code, the message shows in the samples.
+type rejectError struct {
+	code string
+	err  error
+}
+
+func (e *rejectError) Error() string { return e.err.Error() }
+func (e *rejectError) Unwrap() error { return e.err }
+
+// rejectCode is what rejects are grouped by: the code of a rejectError,
+// else the message, which then holds no values.
+func rejectCode(err error) string {
+	var re *rejectError
+	if errors.As(err, &re) {
+		return re.code
+	}
+	return err.Error()
+}
+
 /*
 =====================================================
  Helper function
@@ -166,3 +303,41 @@ func safe(arr []string, idx int) string {
 	}
 	return strings.TrimSpace(arr[idx])
 }
+
+// safeDate reads the YYYYMMDD value at idx in the business time zone. An
+// empty, missing or all-zero value is NULL, anything else that is not a date
+// an error.
+func safeDate(arr []string, idx int) (sql.NullTime, error) {
+	d, err := dates.YMD.Parse(safe(arr, idx))
+	if err != nil {
+		return d, &rejectError{
+			code: fmt.Sprintf("field %d: invalid date", idx),
+			err:  fmt.Errorf("field %d: %w", idx, err),
+		}
+	}
+	return d, nil
+}
+
+// safeDecimal reads the number at idx, written in format, as an exact
+// DECIMAL(19,4). An empty or missing value is 0; one that is not a number
+// or does not fit without rounding is an error.
+func safeDecimal(arr []string, idx int, format decimals.Format) (decimal.Decimal, error) {
+	d, err := decimals.Money.Parse(safe(arr, idx), format)
+	if err != nil {
+		return d, &rejectError{
+			code: fmt.Sprintf("field %d: %s", idx, problem(err, decimals.ErrNotNumber, decimals.ErrInexact, decimals.ErrRange)),
+			err:  fmt.Errorf("field %d: %w", idx, err),
+		}
+	}
+	return d, nil
+}
+
+// problem is the first of kinds that err is, for a reject code.
+func problem(err error, kinds ...error) string {
+	for _, k := range kinds {
+		if errors.Is(err, k) {
+			return k.Error()
+		}
+	}
+	return "invalid value"
+}
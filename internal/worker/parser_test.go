@@ -0,0 +1,63 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go-import-file/internal/metrics"
+)
+
+// panicky slices the field of every line like the old Block43Handler did,
+// so a short value panics.
+type panicky struct {
+	handled []int
+}
+
+func (h *panicky) Handle(fields []string, lineNo int, job FileJob, processID string) error {
+	_ = safe(fields, 2)[:4]
+	h.handled = append(h.handled, lineNo)
+	return nil
+}
+
+func TestParsePanicRejectsLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "A_SLSINV.txt")
+	lines := "43|INV1|20260131\n43|INV2|202\n43|INV3|\n43|INV4|20260201\n"
+	if err := os.WriteFile(path, []byte(lines), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	metrics.BeginRun("p1")
+	metrics.BeginBlock("SLSINV")
+
+	h := &panicky{}
+	fileMetrics := make(chan metrics.FileMetric, 1)
+	job := FileJob{FilePath: path, FileName: "A_SLSINV.txt"}
+	if err := parseOneFile(context.Background(), job, fileMetrics, "p1", map[string]BlockHandler{"43": h}); err != nil {
+		t.Fatal(err)
+	}
+
+	m := <-fileMetrics
+	if m.TotalLines != 4 || m.ParsedRows != 2 || m.ErrorCount != 2 {
+		t.Errorf("lines %d parsed %d errors %d, want 4 2 2", m.TotalLines, m.ParsedRows, m.ErrorCount)
+	}
+	if len(h.handled) != 2 || h.handled[1] != 4 {
+		t.Errorf("handled lines %v, want 1 and 4", h.handled)
+	}
+
+	rejects := metrics.Snapshot().Blocks[0].Rejects
+	if len(rejects) != 1 {
+		t.Fatalf("rejects = %+v, want one reason", rejects)
+	}
+	rr := rejects[0]
+	if rr.Reason != "block 43: "+rejectPanic || rr.Count != 2 {
+		t.Errorf("reason %q count %d, want %q twice", rr.Reason, rr.Count, "block 43: "+rejectPanic)
+	}
+	s := rr.Samples[0]
+	if s.Line != 2 || !strings.Contains(s.Detail, "panic: runtime error: slice bounds out of range") ||
+		!strings.Contains(s.Detail, "(*panicky).Handle parser_test.go:") {
+		t.Errorf("sample = %+v, want the panic and its frame", s)
+	}
+}